@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"tidebot/pkg/common"
+	"tidebot/pkg/exports"
+	spotModels "tidebot/pkg/spots/models"
+	spotRepos "tidebot/pkg/spots/repositories"
+	"tidebot/pkg/worldtides"
+)
+
+const exportCommand = "export"
+
+// runExportCommand handles `tidebot export -spot <slug> -from YYYY-MM-DD -to YYYY-MM-DD`, writing the export
+// to stdout or to the -o file
+func runExportCommand(ctx context.Context, args []string, exportsService exports.ExportsService, spotRepository spotRepos.SpotRepository, stdout io.Writer) error {
+	flags := flag.NewFlagSet(exportCommand, flag.ContinueOnError)
+	spotSlug := flags.String("spot", spotModels.DefaultSpotSlug, "Spot slug")
+	fromParam := flags.String("from", "", "First day, YYYY-MM-DD (default today)")
+	toParam := flags.String("to", "", fmt.Sprintf("Last day, YYYY-MM-DD (default %d days from the first)", exports.DefaultDays))
+	formatParam := flags.String("format", string(exports.FormatCSV), "csv or json")
+	unitsParam := flags.String("units", string(common.UnitsMeters), "meters or feet")
+	datumParam := flags.String("datum", "", fmt.Sprintf("One of %s (default the spot's)", strings.Join(worldtides.Datums, ", ")))
+	withHeights := flags.Bool("heights", true, "Include the heights every 30 minutes, not just the extremes")
+	output := flags.String("o", "", "Output file (default stdout)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	format, ok := exports.ParseFormat(*formatParam)
+	if !ok {
+		return fmt.Errorf("invalid format %q, use csv or json", *formatParam)
+	}
+
+	units, ok := common.ParseUnits(*unitsParam)
+	if !ok {
+		return fmt.Errorf("invalid units %q, use meters or feet", *unitsParam)
+	}
+
+	spot, err := spotRepository.GetBySlug(*spotSlug)
+	if err != nil {
+		return fmt.Errorf("unknown spot %q: %w", *spotSlug, err)
+	}
+
+	if *datumParam != "" {
+		datum, ok := worldtides.ParseDatum(*datumParam)
+		if !ok {
+			return fmt.Errorf("invalid datum %q, use one of %s", *datumParam, strings.Join(worldtides.Datums, ", "))
+		}
+		spot = worldtides.WithDatum(spot, &datum)
+	}
+
+	from, to, err := exports.ParseRange(spot, *fromParam, *toParam)
+	if err != nil {
+		return err
+	}
+
+	export, err := exportsService.ExportTides(ctx, spot, from, to, units, *withHeights, worldtides.AdminTrigger("export-cli"))
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return export.Write(stdout, format)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *output, err)
+	}
+
+	err = export.Write(file, format)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+
+	return file.Close()
+}
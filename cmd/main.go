@@ -1,16 +1,36 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"tidebot/pkg/calendars"
+	calendarRepos "tidebot/pkg/calendars/repositories"
+	"tidebot/pkg/charts"
+	conversationRepos "tidebot/pkg/conversations/repositories"
+	"tidebot/pkg/credits"
+	creditRepos "tidebot/pkg/credits/repositories"
+	creditServices "tidebot/pkg/credits/services"
 	"tidebot/pkg/environment"
+	"tidebot/pkg/exports"
+	"tidebot/pkg/harmonics"
+	"tidebot/pkg/i18n"
 	"tidebot/pkg/jobs"
+	"tidebot/pkg/noaa"
 	notificationRepos "tidebot/pkg/notifications/repositories"
+	"tidebot/pkg/openmeteo"
+	spotRepos "tidebot/pkg/spots/repositories"
+	"tidebot/pkg/stations"
+	stationSearchRepos "tidebot/pkg/stationsearches/repositories"
+	tidePredictionRepos "tidebot/pkg/tidepredictions/repositories"
+	"tidebot/pkg/tides"
 	"tidebot/pkg/ui/home"
 	"tidebot/pkg/users/repositories"
 	"tidebot/pkg/users/services"
+	weatherForecastRepos "tidebot/pkg/weatherforecasts/repositories"
 	"tidebot/pkg/whatsapp"
 	"tidebot/pkg/worldtides"
 
@@ -36,6 +56,11 @@ func main() {
 	envFlagValue := flag.String("env", "", fmt.Sprintf("Environment ('%s' or '%s')", environment.EnvDevelopment, environment.EnvProduction))
 	flag.Parse()
 
+	// The export subcommand writes to stdout, so logs go to stderr
+	if flag.Arg(0) == exportCommand {
+		e.Logger.SetOutput(os.Stderr)
+	}
+
 	env, err := environment.ParseEnvironment(*envFlagValue)
 
 	if err != nil {
@@ -73,29 +98,92 @@ func main() {
 	// Initialize repositories
 	userRepository := repositories.NewUserRepository(db, e.Logger)
 	notificationSubscriptionRepository := notificationRepos.NewNotificationSubscriptionRepository(db, e.Logger)
+	spotRepository := spotRepos.NewSpotRepository(db, e.Logger)
+	tidePredictionRepository := tidePredictionRepos.NewTidePredictionRepository(db, e.Logger)
+	creditUsageRepository := creditRepos.NewCreditUsageRepository(db, e.Logger)
+	weatherForecastRepository := weatherForecastRepos.NewWeatherForecastRepository(db, e.Logger)
+	stationSearchRepository := stationSearchRepos.NewStationSearchRepository(db, e.Logger)
+	calendarFeedRepository := calendarRepos.NewCalendarFeedRepository(db, e.Logger)
+	conversationRepository := conversationRepos.NewConversationRepository(db, e.Logger)
+
+	creditBudgetService := creditServices.NewCreditBudgetService(creditUsageRepository, envVars.MonthlyCreditBudget, envVars.CreditReservePercent, e.Logger)
 
 	// Initialize clients
 	whatsappClient := whatsapp.NewWhatsappClient(envVars.TwilioWhatsAppFrom, e.Logger)
-	worldTidesClient := worldtides.NewWorldTidesClient(envVars.WorldTidesApiKey, e.Logger)
+	worldTidesClient := worldtides.NewWorldTidesClient(envVars.WorldTidesApiKey, tidePredictionRepository, stationSearchRepository, envVars.TideCacheTTL, envVars.StationsCacheTTL, creditBudgetService, e.Logger)
+	tidesProviderChain := tides.NewProviderChain(e.Logger, buildTidesProviders(envVars, worldTidesClient, e.Logger)...)
+	marineWeatherClient := openmeteo.NewMarineWeatherClient(envVars.OpenMeteoForecastURL, envVars.OpenMeteoMarineURL, weatherForecastRepository, envVars.WeatherCacheTTL, e.Logger)
+	geocodingClient := openmeteo.NewGeocodingClient(envVars.OpenMeteoGeocodingURL, e.Logger)
+
+	// Untranslated messages are shown in English
+	for language, keys := range i18n.MissingTranslations() {
+		e.Logger.Warnf("%d messages aren't translated to %s: %v", len(keys), language, keys)
+	}
 
 	// Initialize services
 	userService := services.NewUserService(userRepository, db, e.Logger)
-	whatsappService := whatsapp.NewWhatsAppService(userService, notificationSubscriptionRepository, worldTidesClient, whatsappClient, e.Logger)
-	jobsService := jobs.NewJobsService(userService, notificationSubscriptionRepository, whatsappService, worldTidesClient, e.Logger)
+	stationsService := stations.NewStationsService(worldTidesClient, geocodingClient, spotRepository, tidePredictionRepository, e.Logger)
+	calendarsService := calendars.NewCalendarsService(calendarFeedRepository, userService, notificationSubscriptionRepository, spotRepository, tidesProviderChain, e.Logger)
+	whatsappService := whatsapp.NewWhatsAppService(userService, notificationSubscriptionRepository, spotRepository, tidesProviderChain, marineWeatherClient, stationsService, calendarsService, conversationRepository, envVars.ConversationTTL, whatsappClient, envVars.PublicBaseURL, e.Logger)
+	exportsService := exports.NewExportsService(tidesProviderChain, e.Logger)
+	jobsService := jobs.NewJobsService(userService, notificationSubscriptionRepository, spotRepository, tidePredictionRepository, weatherForecastRepository, stationSearchRepository, conversationRepository, whatsappService, tidesProviderChain, envVars.AdminPhoneNumbers, e.Logger)
+
+	if flag.Arg(0) == exportCommand {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		err = runExportCommand(ctx, flag.Args()[1:], exportsService, spotRepository, os.Stdout)
+		if err != nil {
+			e.Logger.Fatalf("Export failed: %v", err)
+		}
+		return
+	}
 
 	// Initialize controllers
-	jobsController := jobs.NewJobsController(jobsService, envVars.ApiKey, e.Logger)
+	jobsController := jobs.NewJobsController(jobsService, tidesProviderChain, envVars.ApiKey, e.Logger)
+	chartsController := charts.NewChartsController(spotRepository, tidesProviderChain, e.Logger)
+	creditsController := credits.NewCreditsController(creditBudgetService, envVars.ApiKey, e.Logger)
+	stationsController := stations.NewStationsController(stationsService, envVars.ApiKey, e.Logger)
+	calendarsController := calendars.NewCalendarsController(calendarsService, spotRepository, e.Logger)
+	exportsController := exports.NewExportsController(exportsService, spotRepository, envVars.ApiKey, e.Logger)
 
 	// Register routes
 	whatsapp.RegisterWhatsappWebhook(e, whatsappService)
 	whatsapp.RegisterComponents(e, envVars.TwilioWhatsAppFrom)
 	jobsController.RegisterRoutes(e)
+	chartsController.RegisterRoutes(e)
+	creditsController.RegisterRoutes(e)
+	stationsController.RegisterRoutes(e)
+	calendarsController.RegisterRoutes(e)
+	exportsController.RegisterRoutes(e)
 
 	home.RegisterHomeRoutes(e)
 
 	e.Logger.Fatal(e.Start(fmt.Sprintf(":%d", envVars.ServerPort)))
 }
 
+func buildTidesProviders(envVars environment.EnvVars, worldTidesClient worldtides.WorldTidesClient, log echo.Logger) []tides.Provider {
+	var providers []tides.Provider
+
+	for _, name := range envVars.TideProviders {
+		switch name {
+		case "worldtides":
+			providers = append(providers, tides.Provider{Name: "WorldTides", Client: worldTidesClient})
+		case "noaa":
+			providers = append(providers, tides.Provider{Name: "NOAA CO-OPS", Client: noaa.NewCoopsClient(noaa.CoopsAPIURL, log)})
+		case "fixtures":
+			providers = append(providers, tides.Provider{Name: "Local fixtures", Client: tides.NewFixtureClient(envVars.TideFixturesDir, log)})
+		case "harmonic":
+			providers = append(providers, tides.Provider{Name: "Offline harmonic model", Client: harmonics.NewHarmonicTidesClient(envVars.HarmonicsDataDir, log)})
+		default:
+			log.Warnf("Unknown tides provider '%s', ignoring", name)
+		}
+	}
+
+	log.Infof("Tides providers in priority order: %v", envVars.TideProviders)
+	return providers
+}
+
 func runMigrations(db *sql.DB, log echo.Logger) error {
 	log.Infof("Database migration started")
 
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_spots_slug;
+DROP TABLE IF EXISTS spots;
@@ -0,0 +1,19 @@
+CREATE TABLE spots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL,
+    timezone TEXT NOT NULL,
+    display_label TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_spots_slug ON spots(slug);
+
+INSERT INTO spots (name, slug, latitude, longitude, timezone, display_label)
+VALUES
+    ('Risco del Paso', 'risco-del-paso', 28.110419112734185, -14.260264983464896, 'Atlantic/Canary', 'Fuerteventura, Risco del Paso, Canary Islands'),
+    ('Flag Beach', 'flag-beach', 28.716, -13.836, 'Atlantic/Canary', 'Fuerteventura, Flag Beach (Corralejo), Canary Islands'),
+    ('El Cotillo', 'el-cotillo', 28.683, -14.014, 'Atlantic/Canary', 'Fuerteventura, El Cotillo, Canary Islands');
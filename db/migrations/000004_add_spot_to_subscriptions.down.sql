@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notification_subscriptions_spot_id;
+ALTER TABLE notification_subscriptions DROP COLUMN spot_id;
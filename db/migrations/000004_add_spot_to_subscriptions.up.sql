@@ -0,0 +1,7 @@
+ALTER TABLE notification_subscriptions ADD COLUMN spot_id INTEGER REFERENCES spots(id);
+
+UPDATE notification_subscriptions
+SET spot_id = (SELECT id FROM spots WHERE slug = 'risco-del-paso')
+WHERE spot_id IS NULL;
+
+CREATE INDEX idx_notification_subscriptions_spot_id ON notification_subscriptions(spot_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tide_predictions_expires_at;
+DROP TABLE IF EXISTS tide_predictions;
@@ -0,0 +1,14 @@
+CREATE TABLE tide_predictions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL,
+    date TEXT NOT NULL,
+    datum TEXT NOT NULL,
+    response TEXT NOT NULL,
+    fetched_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+
+    UNIQUE(latitude, longitude, date, datum)
+);
+
+CREATE INDEX idx_tide_predictions_expires_at ON tide_predictions(expires_at);
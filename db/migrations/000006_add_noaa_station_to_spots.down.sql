@@ -0,0 +1 @@
+ALTER TABLE spots DROP COLUMN noaa_station_id;
@@ -0,0 +1 @@
+ALTER TABLE spots ADD COLUMN noaa_station_id TEXT;
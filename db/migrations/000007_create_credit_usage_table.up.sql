@@ -0,0 +1,12 @@
+CREATE TABLE credit_usage (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    credits INTEGER NOT NULL,
+    trigger_kind TEXT NOT NULL,
+    trigger_ref TEXT NOT NULL,
+    spot_slug TEXT NOT NULL,
+    date TEXT NOT NULL,
+    days INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_credit_usage_created_at ON credit_usage(created_at);
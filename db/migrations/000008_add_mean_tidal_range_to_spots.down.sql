@@ -0,0 +1 @@
+ALTER TABLE spots DROP COLUMN mean_tidal_range;
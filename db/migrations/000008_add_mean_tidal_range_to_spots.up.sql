@@ -0,0 +1,5 @@
+-- Mean range between high and low water in meters, the reference for tidal coefficients
+ALTER TABLE spots ADD COLUMN mean_tidal_range REAL;
+
+-- Twice the M2 amplitude of the bundled harmonic constants
+UPDATE spots SET mean_tidal_range = 1.7 WHERE slug IN ('risco-del-paso', 'flag-beach', 'el-cotillo');
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_weather_forecasts_expires_at;
+DROP TABLE IF EXISTS weather_forecasts;
@@ -0,0 +1,13 @@
+CREATE TABLE weather_forecasts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL,
+    date TEXT NOT NULL,
+    response TEXT NOT NULL,
+    fetched_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+
+    UNIQUE(latitude, longitude, date)
+);
+
+CREATE INDEX idx_weather_forecasts_expires_at ON weather_forecasts(expires_at);
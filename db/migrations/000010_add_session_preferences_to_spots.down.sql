@@ -0,0 +1,4 @@
+ALTER TABLE spots DROP COLUMN max_wind_speed;
+ALTER TABLE spots DROP COLUMN min_wind_speed;
+ALTER TABLE spots DROP COLUMN best_wind_directions;
+ALTER TABLE spots DROP COLUMN best_tides;
@@ -0,0 +1,13 @@
+-- Conditions a spot works best in, used to recommend sessions. Comma separated tide states such as
+-- "low", "mid-rising" or "high-falling", and compass points the wind should come from.
+ALTER TABLE spots ADD COLUMN best_tides TEXT;
+ALTER TABLE spots ADD COLUMN best_wind_directions TEXT;
+-- Wind speed range in knots
+ALTER TABLE spots ADD COLUMN min_wind_speed REAL;
+ALTER TABLE spots ADD COLUMN max_wind_speed REAL;
+
+-- The lagoon fills from mid tide and the trade winds blow side-shore
+UPDATE spots SET best_tides = 'mid,high', best_wind_directions = 'N,NNE,NE,ENE', min_wind_speed = 14, max_wind_speed = 30 WHERE slug = 'risco-del-paso';
+UPDATE spots SET best_tides = 'mid,high', best_wind_directions = 'N,NNE,NE,ENE,E', min_wind_speed = 14, max_wind_speed = 30 WHERE slug = 'flag-beach';
+-- A reef break that works from low to mid tide on the push, with offshore easterlies
+UPDATE spots SET best_tides = 'low-rising,mid-rising', best_wind_directions = 'NE,ENE,E,ESE,SE', max_wind_speed = 15 WHERE slug = 'el-cotillo';
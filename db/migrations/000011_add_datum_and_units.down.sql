@@ -0,0 +1,4 @@
+ALTER TABLE tide_predictions DROP COLUMN response_datum;
+ALTER TABLE users DROP COLUMN units;
+ALTER TABLE users DROP COLUMN datum;
+ALTER TABLE spots DROP COLUMN datum;
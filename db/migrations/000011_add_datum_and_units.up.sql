@@ -0,0 +1,9 @@
+-- Datum heights are relative to, NULL for the default mean sea level
+ALTER TABLE spots ADD COLUMN datum TEXT;
+
+-- A user's datum overrides the spot's, units are "meters" or "feet"
+ALTER TABLE users ADD COLUMN datum TEXT;
+ALTER TABLE users ADD COLUMN units TEXT NOT NULL DEFAULT 'meters';
+
+-- The datum the provider actually answered in, which can differ from the requested one
+ALTER TABLE tide_predictions ADD COLUMN response_datum TEXT;
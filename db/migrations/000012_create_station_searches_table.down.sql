@@ -0,0 +1,2 @@
+ALTER TABLE spots DROP COLUMN worldtides_station_id;
+DROP TABLE IF EXISTS station_searches;
@@ -0,0 +1,16 @@
+CREATE TABLE station_searches (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    latitude REAL NOT NULL,
+    longitude REAL NOT NULL,
+    radius_km INTEGER NOT NULL,
+    response TEXT NOT NULL,
+    fetched_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+
+    UNIQUE(latitude, longitude, radius_km)
+);
+
+CREATE INDEX idx_station_searches_expires_at ON station_searches(expires_at);
+
+-- WorldTides station the tides of a spot are requested from instead of its coordinates
+ALTER TABLE spots ADD COLUMN worldtides_station_id TEXT;
@@ -0,0 +1,2 @@
+-- The language replies are in, NULL to guess it from the phone number
+ALTER TABLE users ADD COLUMN language TEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE notification_subscriptions DROP COLUMN notify_hour;
+DROP TABLE IF EXISTS conversations;
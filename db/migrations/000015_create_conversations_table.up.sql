@@ -0,0 +1,15 @@
+-- The step a phone number is at in a guided flow, e.g. onboarding, forgotten once it expires
+CREATE TABLE conversations (
+    phone_number TEXT PRIMARY KEY,
+    flow TEXT NOT NULL,
+    step TEXT NOT NULL,
+    data TEXT NOT NULL DEFAULT '{}',
+    expires_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_conversations_expires_at ON conversations(expires_at);
+
+-- Hour of the day, in the spot's time zone, the daily notification is sent at when the job runs hourly,
+-- NULL for the default hour
+ALTER TABLE notification_subscriptions ADD COLUMN notify_hour INTEGER;
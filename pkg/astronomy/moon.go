@@ -0,0 +1,71 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+type MoonPhaseName string
+
+const (
+	NewMoon        MoonPhaseName = "New moon"
+	WaxingCrescent MoonPhaseName = "Waxing crescent"
+	FirstQuarter   MoonPhaseName = "First quarter"
+	WaxingGibbous  MoonPhaseName = "Waxing gibbous"
+	FullMoon       MoonPhaseName = "Full moon"
+	WaningGibbous  MoonPhaseName = "Waning gibbous"
+	LastQuarter    MoonPhaseName = "Last quarter"
+	WaningCrescent MoonPhaseName = "Waning crescent"
+)
+
+// phases in order of elongation, each covering 45° centered on its elongation
+var phases = []struct {
+	name  MoonPhaseName
+	emoji string
+}{
+	{NewMoon, "🌑"},
+	{WaxingCrescent, "🌒"},
+	{FirstQuarter, "🌓"},
+	{WaxingGibbous, "🌔"},
+	{FullMoon, "🌕"},
+	{WaningGibbous, "🌖"},
+	{LastQuarter, "🌗"},
+	{WaningCrescent, "🌘"},
+}
+
+type MoonPhase struct {
+	// Elongation is the angle in degrees between the moon and the sun as seen from the earth,
+	// 0 at new moon, 90 at first quarter, 180 at full moon and 270 at last quarter
+	Elongation float64
+	// Illumination is the illuminated fraction of the disk, between 0 and 1
+	Illumination float64
+	Name         MoonPhaseName
+	Emoji        string
+}
+
+// NewMoonPhase computes the moon phase at t with the low precision formulas from Meeus, Astronomical Algorithms, chapter 48
+func NewMoonPhase(t time.Time) MoonPhase {
+	centuries := (toJulianDay(t) - julianDayJ2000) / 36525
+
+	elongation := normalizeDegrees(297.8501921 + 445267.1114034*centuries)
+	sunAnomaly := normalizeDegrees(357.5291092 + 35999.0502909*centuries)
+	moonAnomaly := normalizeDegrees(134.9633964 + 477198.8675055*centuries)
+
+	phaseAngle := 180 - elongation -
+		6.289*sin(moonAnomaly) +
+		2.100*sin(sunAnomaly) -
+		1.274*sin(2*elongation-moonAnomaly) -
+		0.658*sin(2*elongation) -
+		0.214*sin(2*moonAnomaly) -
+		0.110*sin(elongation)
+
+	trueElongation := normalizeDegrees(180 - phaseAngle)
+	phase := phases[int(math.Floor(normalizeDegrees(trueElongation+22.5)/45))%len(phases)]
+
+	return MoonPhase{
+		Elongation:   trueElongation,
+		Illumination: (1 + math.Cos(radians(phaseAngle))) / 2,
+		Name:         phase.name,
+		Emoji:        phase.emoji,
+	}
+}
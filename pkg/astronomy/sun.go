@@ -0,0 +1,134 @@
+package astronomy
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	julianDayUnixEpoch = 2440587.5
+	julianDayJ2000     = 2451545.0
+	// Sunrise and sunset are when the top of the sun touches the horizon, accounting for refraction
+	sunriseAltitude       = -0.833
+	civilTwilightAltitude = -6
+	obliquity             = 23.4397
+)
+
+// Daylight holds the sun times of a day. Times are zero when the sun doesn't reach
+// the corresponding altitude that day, as happens close to the poles.
+type Daylight struct {
+	// Dawn and Dusk delimit civil twilight, when the sun is less than 6° below the horizon
+	Dawn    time.Time
+	Sunrise time.Time
+	Sunset  time.Time
+	Dusk    time.Time
+	// AlwaysUp is set during polar day, when the sun doesn't set
+	AlwaysUp bool
+}
+
+type Light string
+
+const (
+	LightDay      Light = "day"
+	LightTwilight Light = "twilight"
+	LightDark     Light = "dark"
+)
+
+// NewDaylight computes the sun times on the given day at the given position following the sunrise equation.
+// The times are in date's location.
+func NewDaylight(latitude float64, longitude float64, date time.Time) Daylight {
+	var daylight Daylight
+	location := date.Location()
+
+	transit, hourAngle, crosses := solarTransit(latitude, longitude, date, sunriseAltitude)
+	if crosses {
+		daylight.Sunrise = fromJulianDay(transit - hourAngle/360).In(location)
+		daylight.Sunset = fromJulianDay(transit + hourAngle/360).In(location)
+	} else {
+		daylight.AlwaysUp = hourAngle == 180
+	}
+
+	transit, hourAngle, crosses = solarTransit(latitude, longitude, date, civilTwilightAltitude)
+	if crosses {
+		daylight.Dawn = fromJulianDay(transit - hourAngle/360).In(location)
+		daylight.Dusk = fromJulianDay(transit + hourAngle/360).In(location)
+	}
+
+	return daylight
+}
+
+// LightAt tells whether t, on the same day, is in daylight, civil twilight or darkness
+func (d Daylight) LightAt(t time.Time) Light {
+	if d.AlwaysUp || (!d.Sunrise.IsZero() && !t.Before(d.Sunrise) && t.Before(d.Sunset)) {
+		return LightDay
+	}
+
+	if !d.Dawn.IsZero() && !t.Before(d.Dawn) && t.Before(d.Dusk) {
+		return LightTwilight
+	}
+
+	// White nights, the sun sets but it never gets darker than civil twilight
+	if d.Dawn.IsZero() && !d.Sunrise.IsZero() {
+		return LightTwilight
+	}
+
+	return LightDark
+}
+
+// solarTransit returns the Julian day of solar noon on the date and the hour angle, in degrees,
+// at which the sun is at the given altitude. When the sun never reaches the altitude, the hour angle
+// is 180 if the sun stays above it and 0 if it stays below.
+func solarTransit(latitude float64, longitude float64, date time.Time, altitude float64) (float64, float64, bool) {
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location())
+
+	// Days since J2000 of the solar noon closest to the local noon
+	cycle := math.Round(toJulianDay(noon) - julianDayJ2000 - 0.0009 + longitude/360)
+	approximateNoon := cycle + 0.0009 - longitude/360
+
+	meanAnomaly := normalizeDegrees(357.5291 + 0.98560028*approximateNoon)
+	center := 1.9148*sin(meanAnomaly) + 0.0200*sin(2*meanAnomaly) + 0.0003*sin(3*meanAnomaly)
+	eclipticLongitude := normalizeDegrees(meanAnomaly + center + 180 + 102.9372)
+
+	transit := julianDayJ2000 + approximateNoon + 0.0053*sin(meanAnomaly) - 0.0069*sin(2*eclipticLongitude)
+
+	sinDeclination := sin(eclipticLongitude) * sin(obliquity)
+	cosDeclination := math.Cos(math.Asin(sinDeclination))
+
+	cosHourAngle := (sin(altitude) - sin(latitude)*sinDeclination) / (math.Cos(radians(latitude)) * cosDeclination)
+	if cosHourAngle < -1 {
+		return transit, 180, false
+	}
+	if cosHourAngle > 1 {
+		return transit, 0, false
+	}
+
+	return transit, degrees(math.Acos(cosHourAngle)), true
+}
+
+func toJulianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + julianDayUnixEpoch
+}
+
+func fromJulianDay(julianDay float64) time.Time {
+	return time.Unix(int64(math.Round((julianDay-julianDayUnixEpoch)*86400)), 0)
+}
+
+func sin(degrees float64) float64 {
+	return math.Sin(radians(degrees))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+func normalizeDegrees(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
@@ -0,0 +1,150 @@
+package calendars
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"tidebot/pkg/astronomy"
+	"tidebot/pkg/common"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+// refreshInterval is how often calendar clients are asked to refetch the feed
+const refreshInterval = "PT6H"
+
+// Event is an instant in a tide calendar
+type Event struct {
+	UID         string
+	Start       time.Time
+	Summary     string
+	Description string
+}
+
+// TideCalendar is an iCalendar feed of the tide extremes, and optionally sunrise and sunset, of a spot
+type TideCalendar struct {
+	Name   string
+	Spot   spotModels.Spot
+	From   time.Time
+	To     time.Time
+	Events []Event
+	// Stamp is when the data behind the calendar last changed, used for the DTSTAMP of the events
+	// so the feed, and its ETag, only change when the data does
+	Stamp time.Time
+}
+
+// NewTideCalendar builds the calendar of the spot between from and to, with heights in the units
+func NewTideCalendar(name string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, from time.Time, to time.Time, units common.Units, withSun bool, stamp time.Time) TideCalendar {
+	calendar := TideCalendar{
+		Name:  name,
+		Spot:  spot,
+		From:  from,
+		To:    to,
+		Stamp: stamp,
+	}
+
+	datumNote := ""
+	if tides.ResponseDatum != "" {
+		datumNote = fmt.Sprintf(" relative to %s", tides.ResponseDatum)
+	}
+	sourceNote := ""
+	if tides.Source != "" {
+		sourceNote = fmt.Sprintf("\nSource: %s", tides.Source)
+	}
+
+	for _, extreme := range tides.Extremes {
+		at := extreme.Time()
+		if at.Before(from) || !at.Before(to) {
+			continue
+		}
+
+		emoji := "⬇️"
+		if extreme.IsHighTide() {
+			emoji = "⬆️"
+		}
+
+		height := units.FormatHeight(extreme.Height, 2)
+		calendar.Events = append(calendar.Events, Event{
+			UID:         eventUID(spot, strings.ToLower(extreme.Type), at),
+			Start:       at,
+			Summary:     fmt.Sprintf("%s %s tide %s", emoji, extreme.Type, height),
+			Description: fmt.Sprintf("%s tide of %s%s at %s.%s", extreme.Type, height, datumNote, spot.DisplayLabel, sourceNote),
+		})
+	}
+
+	if withSun {
+		location := spot.Location()
+		for day := from.In(location); day.Before(to); day = day.AddDate(0, 0, 1) {
+			daylight := astronomy.NewDaylight(spot.Latitude, spot.Longitude, day)
+			calendar.addSunEvent(spot, "🌅", "Sunrise", daylight.Sunrise)
+			calendar.addSunEvent(spot, "🌇", "Sunset", daylight.Sunset)
+		}
+	}
+
+	sort.SliceStable(calendar.Events, func(i, j int) bool {
+		return calendar.Events[i].Start.Before(calendar.Events[j].Start)
+	})
+
+	return calendar
+}
+
+// addSunEvent skips the days the sun doesn't rise or set
+func (c *TideCalendar) addSunEvent(spot spotModels.Spot, emoji string, name string, at time.Time) {
+	if at.IsZero() || at.Before(c.From) || !at.Before(c.To) {
+		return
+	}
+
+	c.Events = append(c.Events, Event{
+		UID:         eventUID(spot, strings.ToLower(name), at),
+		Start:       at,
+		Summary:     fmt.Sprintf("%s %s", emoji, name),
+		Description: fmt.Sprintf("%s at %s.", name, spot.DisplayLabel),
+	})
+}
+
+// eventUID identifies an event by its spot, kind and time. Subscribed clients replace the whole feed
+// on refresh, so an event moved by a new prediction simply replaces the old one.
+func eventUID(spot spotModels.Spot, kind string, at time.Time) string {
+	return fmt.Sprintf("%s-%s-%s@tidebot", spot.Slug, kind, at.UTC().Format("20060102T1504Z"))
+}
+
+// Render writes the calendar in the iCalendar format -- https://www.rfc-editor.org/rfc/rfc5545
+func (c TideCalendar) Render(w io.Writer) error {
+	location := c.Spot.Location()
+	tzid := location.String()
+
+	iw := newICalWriter(w)
+
+	iw.line("BEGIN", "VCALENDAR")
+	iw.line("VERSION", "2.0")
+	iw.line("PRODID", "-//tidebot//Tide calendar//EN")
+	iw.line("CALSCALE", "GREGORIAN")
+	iw.line("METHOD", "PUBLISH")
+	iw.text("X-WR-CALNAME", c.Name)
+	iw.text("X-WR-TIMEZONE", tzid)
+	iw.line("REFRESH-INTERVAL;VALUE=DURATION", refreshInterval)
+	iw.line("X-PUBLISHED-TTL", refreshInterval)
+
+	writeVTimezone(iw, location, c.From, c.To)
+
+	stamp := c.Stamp.UTC().Format(utcTimeFormat)
+	for _, event := range c.Events {
+		iw.line("BEGIN", "VEVENT")
+		iw.text("UID", event.UID)
+		iw.line("DTSTAMP", stamp)
+		iw.line("DTSTART;TZID="+tzid, event.Start.In(location).Format(localTimeFormat))
+		iw.text("SUMMARY", event.Summary)
+		iw.text("DESCRIPTION", event.Description)
+		iw.text("LOCATION", c.Spot.DisplayLabel)
+		iw.line("GEO", strconv.FormatFloat(c.Spot.Latitude, 'f', 6, 64)+";"+strconv.FormatFloat(c.Spot.Longitude, 'f', 6, 64))
+		iw.line("TRANSP", "TRANSPARENT")
+		iw.line("END", "VEVENT")
+	}
+
+	iw.line("END", "VCALENDAR")
+
+	return iw.flush()
+}
@@ -0,0 +1,183 @@
+package calendars
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"tidebot/pkg/common"
+	spotRepos "tidebot/pkg/spots/repositories"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	calendarContentType = "text/calendar; charset=utf-8"
+
+	// Feeds only change when the tides are refetched or the day changes
+	spotCalendarCacheControl = "public, max-age=3600"
+	userCalendarCacheControl = "private, max-age=3600"
+)
+
+type CalendarsController struct {
+	calendarsService CalendarsService
+	spotRepository   spotRepos.SpotRepository
+	log              echo.Logger
+}
+
+func NewCalendarsController(calendarsService CalendarsService, spotRepository spotRepos.SpotRepository, log echo.Logger) *CalendarsController {
+	return &CalendarsController{
+		calendarsService: calendarsService,
+		spotRepository:   spotRepository,
+		log:              log,
+	}
+}
+
+func (cc *CalendarsController) RegisterRoutes(e *echo.Echo) {
+	e.GET("/calendars/tides/:file", cc.GetSpotCalendar)
+	e.GET("/calendars/users/:file", cc.GetUserCalendar)
+}
+
+// GetSpotCalendar serves /calendars/tides/<spot slug>.ics, optionally with ?units=feet, ?datum=LAT, ?sun=true and ?days=14
+func (cc *CalendarsController) GetSpotCalendar(c echo.Context) error {
+	slug, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok {
+		return c.String(http.StatusNotFound, "Unsupported calendar format")
+	}
+
+	spot, err := cc.spotRepository.GetBySlug(slug)
+	if err != nil {
+		return c.String(http.StatusNotFound, "Unknown spot")
+	}
+
+	units := common.UnitsMeters
+	if unitsParam := c.QueryParam("units"); unitsParam != "" {
+		parsed, ok := common.ParseUnits(unitsParam)
+		if !ok {
+			return c.String(http.StatusBadRequest, "Invalid units, use meters or feet")
+		}
+		units = parsed
+	}
+
+	if datumParam := c.QueryParam("datum"); datumParam != "" {
+		datum, ok := worldtides.ParseDatum(datumParam)
+		if !ok {
+			return c.String(http.StatusBadRequest, fmt.Sprintf("Invalid datum, use one of %s", strings.Join(worldtides.Datums, ", ")))
+		}
+		spot = worldtides.WithDatum(spot, &datum)
+	}
+
+	withSun, days, err := parseCalendarParams(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	calendar, err := cc.calendarsService.SpotCalendar(c.Request().Context(), spot, units, withSun, days, worldtides.UserTrigger("calendar "+c.RealIP()))
+	if err != nil {
+		cc.log.Errorf("Failed to build calendar of %s: %v", slug, err)
+		return c.String(http.StatusServiceUnavailable, "Tide data is not available")
+	}
+
+	return cc.serveCalendar(c, calendar, spotCalendarCacheControl)
+}
+
+// GetUserCalendar serves /calendars/users/<token>.ics, optionally with ?sun=true and ?days=14
+func (cc *CalendarsController) GetUserCalendar(c echo.Context) error {
+	token, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok {
+		return c.String(http.StatusNotFound, "Unsupported calendar format")
+	}
+
+	withSun, days, err := parseCalendarParams(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	calendar, err := cc.calendarsService.UserCalendar(c.Request().Context(), token, withSun, days)
+	if errors.Is(err, ErrFeedNotFound) {
+		return c.String(http.StatusNotFound, "Unknown calendar")
+	}
+	if err != nil {
+		cc.log.Errorf("Failed to build personal calendar: %v", err)
+		return c.String(http.StatusServiceUnavailable, "Tide data is not available")
+	}
+
+	return cc.serveCalendar(c, calendar, userCalendarCacheControl)
+}
+
+// serveCalendar answers conditional requests with 304 so calendar clients polling the feed cost nothing
+func (cc *CalendarsController) serveCalendar(c echo.Context, calendar TideCalendar, cacheControl string) error {
+	var body bytes.Buffer
+	err := calendar.Render(&body)
+	if err != nil {
+		cc.log.Errorf("Failed to render calendar of %s: %v", calendar.Spot.Slug, err)
+		return c.String(http.StatusInternalServerError, "Failed to render calendar")
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	lastModified := calendar.Stamp.UTC().Truncate(time.Second)
+
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	header.Set("Cache-Control", cacheControl)
+
+	if notModified(c.Request(), etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	filename := path.Base(c.Request().URL.Path)
+	header.Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+
+	return c.Blob(http.StatusOK, calendarContentType, body.Bytes())
+}
+
+// notModified evaluates If-None-Match, and only without it If-Modified-Since -- https://www.rfc-editor.org/rfc/rfc9110#section-13.2.2
+func notModified(request *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.After(ifModifiedSince)
+}
+
+func parseCalendarParams(c echo.Context) (bool, int, error) {
+	withSun := false
+	if sunParam := c.QueryParam("sun"); sunParam != "" {
+		parsed, err := strconv.ParseBool(sunParam)
+		if err != nil {
+			return false, 0, fmt.Errorf("Invalid sun, use true or false")
+		}
+		withSun = parsed
+	}
+
+	days := DefaultDays
+	if daysParam := c.QueryParam("days"); daysParam != "" {
+		parsed, err := strconv.Atoi(daysParam)
+		if err != nil || parsed < 1 || parsed > MaxDays {
+			return false, 0, fmt.Errorf("Invalid days, use 1 to %d", MaxDays)
+		}
+		days = parsed
+	}
+
+	return withSun, days, nil
+}
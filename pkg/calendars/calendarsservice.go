@@ -0,0 +1,168 @@
+package calendars
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"tidebot/pkg/calendars/models"
+	"tidebot/pkg/calendars/repositories"
+	"tidebot/pkg/common"
+	notificationRepos "tidebot/pkg/notifications/repositories"
+	spotModels "tidebot/pkg/spots/models"
+	spotRepos "tidebot/pkg/spots/repositories"
+	"tidebot/pkg/users/services"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	DefaultDays = 7
+	// MaxDays keeps a public feed from spending credits far ahead
+	MaxDays = 14
+
+	tokenBytes = 16
+)
+
+// ErrFeedNotFound is returned for a personal feed token that doesn't exist
+var ErrFeedNotFound = errors.New("calendar feed not found")
+
+type CalendarsService interface {
+	// SpotCalendar builds the calendar of the spot for the days from today
+	SpotCalendar(ctx context.Context, spot spotModels.Spot, units common.Units, withSun bool, days int, trigger worldtides.Trigger) (TideCalendar, error)
+	// UserCalendar builds the calendar of the feed owner's spot with their units and datum
+	UserCalendar(ctx context.Context, token string, withSun bool, days int) (TideCalendar, error)
+	// GetOrCreateFeed returns the user's personal feed, creating it on first use
+	GetOrCreateFeed(userID int) (models.CalendarFeed, error)
+}
+
+type calendarsServiceImpl struct {
+	calendarFeedRepository             repositories.CalendarFeedRepository
+	userService                        services.UserService
+	notificationSubscriptionRepository notificationRepos.NotificationSubscriptionRepository
+	spotRepository                     spotRepos.SpotRepository
+	tidesClient                        worldtides.WorldTidesClient
+	log                                echo.Logger
+}
+
+func NewCalendarsService(calendarFeedRepository repositories.CalendarFeedRepository, userService services.UserService, notificationSubscriptionRepository notificationRepos.NotificationSubscriptionRepository, spotRepository spotRepos.SpotRepository, tidesClient worldtides.WorldTidesClient, log echo.Logger) CalendarsService {
+	return &calendarsServiceImpl{
+		calendarFeedRepository:             calendarFeedRepository,
+		userService:                        userService,
+		notificationSubscriptionRepository: notificationSubscriptionRepository,
+		spotRepository:                     spotRepository,
+		tidesClient:                        tidesClient,
+		log:                                log,
+	}
+}
+
+func (s *calendarsServiceImpl) SpotCalendar(ctx context.Context, spot spotModels.Spot, units common.Units, withSun bool, days int, trigger worldtides.Trigger) (TideCalendar, error) {
+	name := fmt.Sprintf("Tides at %s", spot.Name)
+	return s.buildCalendar(ctx, name, spot, units, withSun, days, trigger, spot.UpdatedAt)
+}
+
+func (s *calendarsServiceImpl) UserCalendar(ctx context.Context, token string, withSun bool, days int) (TideCalendar, error) {
+	feed, err := s.calendarFeedRepository.GetByToken(token)
+	if err != nil {
+		return TideCalendar{}, err
+	}
+	if feed == nil {
+		return TideCalendar{}, ErrFeedNotFound
+	}
+
+	user, err := s.userService.GetUserByID(feed.UserID)
+	if err != nil {
+		return TideCalendar{}, err
+	}
+
+	spot, err := s.getUserSpot(user.ID)
+	if err != nil {
+		return TideCalendar{}, err
+	}
+	spot = worldtides.WithDatum(spot, user.Datum)
+
+	units, ok := common.ParseUnits(user.Units)
+	if !ok {
+		units = common.UnitsMeters
+	}
+
+	// Changing units or datum changes the feed too
+	changedAt := spot.UpdatedAt
+	if user.UpdatedAt.After(changedAt) {
+		changedAt = user.UpdatedAt
+	}
+
+	name := fmt.Sprintf("My tides at %s", spot.Name)
+	return s.buildCalendar(ctx, name, spot, units, withSun, days, worldtides.UserTrigger(user.PhoneNumber), changedAt)
+}
+
+// getUserSpot returns the spot the user is subscribed to, or the default spot
+func (s *calendarsServiceImpl) getUserSpot(userID int) (spotModels.Spot, error) {
+	subscription, err := s.notificationSubscriptionRepository.GetSubscriptionByUserID(userID)
+	if err != nil || subscription == nil {
+		s.log.Debugf("No subscription for user %d, using the default spot: %v", userID, err)
+		return s.spotRepository.GetBySlug(spotModels.DefaultSpotSlug)
+	}
+
+	return s.spotRepository.GetByID(subscription.SpotID)
+}
+
+// buildCalendar stamps the calendar with the latest of changedAt, the start of today and the time the tides
+// were fetched, as the feed changes with any of them
+func (s *calendarsServiceImpl) buildCalendar(ctx context.Context, name string, spot spotModels.Spot, units common.Units, withSun bool, days int, trigger worldtides.Trigger, changedAt time.Time) (TideCalendar, error) {
+	today := common.TodayIn(spot.Location())
+
+	tidesResponses, err := s.tidesClient.GetTidesRange(ctx, spot, today, days, trigger)
+	if err != nil {
+		return TideCalendar{}, fmt.Errorf("failed to get tides of spot %s for the calendar: %w", spot.Slug, err)
+	}
+
+	stamp := today
+	if changedAt.After(stamp) {
+		stamp = changedAt
+	}
+	for _, response := range tidesResponses {
+		if fetchedAt := time.Unix(response.FetchedAt, 0); response.FetchedAt > 0 && fetchedAt.After(stamp) {
+			stamp = fetchedAt
+		}
+	}
+
+	return NewTideCalendar(name, spot, worldtides.Merge(tidesResponses), today, today.AddDate(0, 0, days), units, withSun, stamp), nil
+}
+
+func (s *calendarsServiceImpl) GetOrCreateFeed(userID int) (models.CalendarFeed, error) {
+	feed, err := s.calendarFeedRepository.GetByUserID(userID)
+	if err != nil {
+		return models.CalendarFeed{}, err
+	}
+	if feed != nil {
+		return *feed, nil
+	}
+
+	token := make([]byte, tokenBytes)
+	_, err = rand.Read(token)
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+
+	created, err := s.calendarFeedRepository.Save(userID, hex.EncodeToString(token))
+	if err != nil {
+		return models.CalendarFeed{}, err
+	}
+
+	s.log.Infof("Created calendar feed for user %d", userID)
+	return created, nil
+}
+
+// SpotFeedURL is the public calendar of the spot
+func SpotFeedURL(baseURL string, spotSlug string) string {
+	return fmt.Sprintf("%s/calendars/tides/%s.ics", baseURL, spotSlug)
+}
+
+// UserFeedURL is the personal calendar behind the token
+func UserFeedURL(baseURL string, token string) string {
+	return fmt.Sprintf("%s/calendars/users/%s.ics", baseURL, token)
+}
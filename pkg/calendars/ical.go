@@ -0,0 +1,159 @@
+package calendars
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// Content lines are folded at 75 octets -- https://www.rfc-editor.org/rfc/rfc5545#section-3.1
+	maxLineOctets = 75
+
+	localTimeFormat = "20060102T150405"
+	utcTimeFormat   = "20060102T150405Z"
+)
+
+// icalWriter writes content lines with the CRLF endings and folding iCalendar requires
+type icalWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newICalWriter(w io.Writer) *icalWriter {
+	return &icalWriter{w: bufio.NewWriter(w)}
+}
+
+// line writes a property whose value is already encoded
+func (iw *icalWriter) line(name string, value string) {
+	if iw.err != nil {
+		return
+	}
+
+	content := name + ":" + value
+	// Continuation lines start with a space that counts towards the limit
+	limit := maxLineOctets
+	for len(content) > limit {
+		// Never split a UTF-8 sequence
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+
+		_, iw.err = iw.w.WriteString(content[:cut] + "\r\n ")
+		if iw.err != nil {
+			return
+		}
+		content = content[cut:]
+		limit = maxLineOctets - 1
+	}
+
+	_, iw.err = iw.w.WriteString(content + "\r\n")
+}
+
+// text writes a TEXT property, escaping its value
+func (iw *icalWriter) text(name string, value string) {
+	iw.line(name, escapeText(value))
+}
+
+func (iw *icalWriter) flush() error {
+	if iw.err != nil {
+		return iw.err
+	}
+	return iw.w.Flush()
+}
+
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// writeVTimezone describes the location's UTC offsets from from to to, listing each transition
+// explicitly instead of recurrence rules so any zone Go knows about is described exactly
+func writeVTimezone(iw *icalWriter, location *time.Location, from time.Time, to time.Time) {
+	iw.line("BEGIN", "VTIMEZONE")
+	iw.text("TZID", location.String())
+
+	start := from.In(location).Truncate(time.Second)
+	_, offset := start.Zone()
+	writeObservance(iw, offset, start)
+
+	for t := start; t.Before(to); {
+		transition, ok := nextTransition(t, to)
+		if !ok {
+			break
+		}
+		writeObservance(iw, offset, transition)
+		_, offset = transition.Zone()
+		t = transition
+	}
+
+	iw.line("END", "VTIMEZONE")
+}
+
+// writeObservance writes the offset in force from at, whose DTSTART is in the offset before the change
+func writeObservance(iw *icalWriter, offsetFrom int, at time.Time) {
+	kind := "STANDARD"
+	if at.IsDST() {
+		kind = "DAYLIGHT"
+	}
+
+	name, offsetTo := at.Zone()
+	localStart := at.In(time.FixedZone(name, offsetFrom))
+
+	iw.line("BEGIN", kind)
+	iw.line("DTSTART", localStart.Format(localTimeFormat))
+	iw.line("TZOFFSETFROM", formatOffset(offsetFrom))
+	iw.line("TZOFFSETTO", formatOffset(offsetTo))
+	iw.text("TZNAME", name)
+	iw.line("END", kind)
+}
+
+// nextTransition returns the first moment after t, up to limit, with a different UTC offset
+func nextTransition(t time.Time, limit time.Time) (time.Time, bool) {
+	_, offset := t.Zone()
+
+	// Zones change offset at most a few times a year, so stepping by a day finds every change
+	day := t
+	for {
+		next := day.Add(24 * time.Hour)
+		if next.After(limit) {
+			next = limit
+		}
+		if _, nextOffset := next.Zone(); nextOffset != offset {
+			// Narrow down to the second
+			low, high := day, next
+			for high.Sub(low) > time.Second {
+				middle := low.Add(high.Sub(low) / 2).Truncate(time.Second)
+				if _, middleOffset := middle.Zone(); middleOffset == offset {
+					low = middle
+				} else {
+					high = middle
+				}
+			}
+			return high, true
+		}
+		if !next.Before(limit) {
+			return time.Time{}, false
+		}
+		day = next
+	}
+}
+
+// formatOffset formats a UTC offset in seconds as e.g. "+0100" or "-0330"
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+
+	return offset
+}
@@ -0,0 +1,57 @@
+package calendars
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"short", "Low tide"},
+		{"exactly one line", strings.Repeat("a", maxLineOctets-len("DESCRIPTION:"))},
+		// The first line is full, so the continuation starts with the colon
+		{"colon at a fold", strings.Repeat("a", maxLineOctets-len("DESCRIPTION:")) + ":" + strings.Repeat("b", 100)},
+		{"continuation of exactly the limit", strings.Repeat("a", maxLineOctets-len("DESCRIPTION:")) + strings.Repeat("b", maxLineOctets-1)},
+		{"continuation one past the limit", strings.Repeat("a", maxLineOctets-len("DESCRIPTION:")) + strings.Repeat("b", maxLineOctets)},
+		{"multibyte characters", strings.Repeat("🌊 marea ", 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			iw := newICalWriter(&body)
+			iw.line("DESCRIPTION", tt.value)
+			if err := iw.flush(); err != nil {
+				t.Fatalf("flush() error = %v", err)
+			}
+
+			output := body.String()
+			if !strings.HasSuffix(output, "\r\n") {
+				t.Fatalf("output %q doesn't end with CRLF", output)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(output, "\r\n"), "\r\n")
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d is %d octets, want at most %d", i, len(line), maxLineOctets)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence", i)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d doesn't start with a space", i)
+				}
+			}
+
+			unfolded := strings.ReplaceAll(strings.TrimSuffix(output, "\r\n"), "\r\n ", "")
+			if unfolded != "DESCRIPTION:"+tt.value {
+				t.Errorf("unfolded line = %q, want %q", unfolded, "DESCRIPTION:"+tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// CalendarFeed is a user's personal calendar of tides, published at a URL holding the token
+type CalendarFeed struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Token     string    `json:"token"`
+	CreatedAt time.Time `json:"created_at"`
+}
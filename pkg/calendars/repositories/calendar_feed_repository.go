@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"tidebot/pkg/calendars/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CalendarFeedRepository interface {
+	// GetByToken returns the feed with the token, or nil if there is none
+	GetByToken(token string) (*models.CalendarFeed, error)
+	// GetByUserID returns the user's feed, or nil if they don't have one yet
+	GetByUserID(userID int) (*models.CalendarFeed, error)
+	Save(userID int, token string) (models.CalendarFeed, error)
+}
+
+type calendarFeedRepositoryImpl struct {
+	db  *sql.DB
+	log echo.Logger
+}
+
+func NewCalendarFeedRepository(db *sql.DB, log echo.Logger) CalendarFeedRepository {
+	return &calendarFeedRepositoryImpl{db, log}
+}
+
+func (r *calendarFeedRepositoryImpl) GetByToken(token string) (*models.CalendarFeed, error) {
+	r.log.Debugf("Attempting to get calendar feed by token")
+
+	query := `SELECT id, user_id, token, created_at FROM calendar_feeds WHERE token = ? LIMIT 1`
+
+	return r.get(query, token)
+}
+
+func (r *calendarFeedRepositoryImpl) GetByUserID(userID int) (*models.CalendarFeed, error) {
+	r.log.Debugf("Attempting to get calendar feed of user with id='%d'", userID)
+
+	query := `SELECT id, user_id, token, created_at FROM calendar_feeds WHERE user_id = ? LIMIT 1`
+
+	return r.get(query, userID)
+}
+
+func (r *calendarFeedRepositoryImpl) get(query string, argument any) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.QueryRowContext(context.Background(), query, argument).Scan(
+		&feed.ID,
+		&feed.UserID,
+		&feed.Token,
+		&feed.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+func (r *calendarFeedRepositoryImpl) Save(userID int, token string) (models.CalendarFeed, error) {
+	r.log.Debugf("Attempting to save a calendar feed for user with id='%d'", userID)
+
+	query := `
+		INSERT INTO calendar_feeds (user_id, token, created_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		RETURNING id, user_id, token, created_at`
+
+	var feed models.CalendarFeed
+	err := r.db.QueryRowContext(context.Background(), query, userID, token).Scan(
+		&feed.ID,
+		&feed.UserID,
+		&feed.Token,
+		&feed.CreatedAt,
+	)
+	if err != nil {
+		return models.CalendarFeed{}, fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	r.log.Debugf("Saved calendar feed with id='%d' for user with id='%d'", feed.ID, userID)
+	return feed, nil
+}
@@ -0,0 +1,190 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"tidebot/pkg/astronomy"
+	"tidebot/pkg/common"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+const (
+	chartWidth   = 800
+	chartHeight  = 400
+	marginLeft   = 80
+	marginRight  = 24
+	marginTop    = 48
+	marginBottom = 40
+
+	hoursPerTick = 3
+)
+
+// TideChart is the tide curve of a single day at a spot, ready to be rendered as PNG or SVG
+type TideChart struct {
+	Title    string
+	From     time.Time
+	To       time.Time
+	Heights  []worldtides.Height
+	Extremes []worldtides.Extreme
+	// Now is marked on the chart when it falls within the day
+	Now time.Time
+	// Sunrise and Sunset are zero when the sun doesn't rise or set that day
+	Sunrise time.Time
+	Sunset  time.Time
+	// Units the height axis and labels are in
+	Units common.Units
+}
+
+// NewTideChart builds the chart of the spot's local day starting at day. The tides may cover more than the day,
+// e.g. the next one so the curve runs until midnight.
+func NewTideChart(spot spotModels.Spot, tides *worldtides.WorldTidesResponse, day time.Time, now time.Time, units common.Units) TideChart {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, spot.Location())
+	to := from.AddDate(0, 0, 1)
+	daylight := astronomy.NewDaylight(spot.Latitude, spot.Longitude, from)
+
+	return TideChart{
+		Title:    fmt.Sprintf("%s %s", spot.Name, from.Format("Mon 2006-01-02")),
+		From:     from,
+		To:       to,
+		Heights:  clipHeights(tides, from, to),
+		Extremes: tides.Extremes,
+		Now:      now,
+		Sunrise:  daylight.Sunrise,
+		Sunset:   daylight.Sunset,
+		Units:    units,
+	}
+}
+
+// clipHeights keeps the heights between from and to, with the interpolated heights at from and to when the
+// tides run past them, so the curve spans the plot without leaving it
+func clipHeights(tides *worldtides.WorldTidesResponse, from time.Time, to time.Time) []worldtides.Height {
+	var heights []worldtides.Height
+
+	if state, err := tides.StateAt(from); err == nil {
+		heights = append(heights, worldtides.Height{Dt: from.Unix(), Height: state.Height})
+	}
+
+	for _, height := range tides.Heights {
+		if height.Dt > from.Unix() && height.Dt < to.Unix() {
+			heights = append(heights, height)
+		}
+	}
+
+	if state, err := tides.StateAt(to); err == nil {
+		heights = append(heights, worldtides.Height{Dt: to.Unix(), Height: state.Height})
+	}
+
+	return heights
+}
+
+// TideChartURL returns the public URL of the PNG chart served by the charts controller.
+// The units and the datum are only part of the URL when they aren't the defaults.
+func TideChartURL(baseURL string, spotSlug string, date time.Time, units common.Units, datum string) string {
+	chartURL := fmt.Sprintf("%s/charts/tides/%s/%s.png", baseURL, spotSlug, date.Format("2006-01-02"))
+
+	query := url.Values{}
+	if units == common.UnitsFeet {
+		query.Set("units", string(units))
+	}
+	if datum != "" && datum != worldtides.DefaultDatum {
+		query.Set("datum", datum)
+	}
+	if len(query) > 0 {
+		chartURL += "?" + query.Encode()
+	}
+
+	return chartURL
+}
+
+// nightIntervals returns the parts of the day before sunrise and after sunset
+func (c TideChart) nightIntervals() [][2]time.Time {
+	if c.Sunrise.IsZero() || c.Sunset.IsZero() {
+		return nil
+	}
+
+	return [][2]time.Time{{c.From, c.Sunrise}, {c.Sunset, c.To}}
+}
+
+func (c TideChart) showsNow() bool {
+	return !c.Now.Before(c.From) && c.Now.Before(c.To)
+}
+
+// layout maps times and heights, in the chart's units, to pixel coordinates
+type layout struct {
+	from      time.Time
+	to        time.Time
+	minHeight float64
+	maxHeight float64
+	step      float64
+}
+
+func newLayout(c TideChart) layout {
+	minHeight, maxHeight := math.Inf(1), math.Inf(-1)
+	for _, height := range c.Heights {
+		minHeight = math.Min(minHeight, c.Units.FromMeters(height.Height))
+		maxHeight = math.Max(maxHeight, c.Units.FromMeters(height.Height))
+	}
+
+	if len(c.Heights) == 0 {
+		minHeight, maxHeight = -1, 1
+	}
+
+	step := 0.5
+	if maxHeight-minHeight > 4 {
+		step = 1
+	}
+
+	// Leave room for the extreme labels above the highs and below the lows
+	return layout{
+		from:      c.From,
+		to:        c.To,
+		minHeight: math.Floor((minHeight-step/2)/step) * step,
+		maxHeight: math.Ceil((maxHeight+step/2)/step) * step,
+		step:      step,
+	}
+}
+
+func (l layout) x(t time.Time) float64 {
+	fraction := t.Sub(l.from).Seconds() / l.to.Sub(l.from).Seconds()
+	return marginLeft + fraction*float64(chartWidth-marginLeft-marginRight)
+}
+
+func (l layout) y(height float64) float64 {
+	fraction := (height - l.minHeight) / (l.maxHeight - l.minHeight)
+	return float64(chartHeight-marginBottom) - fraction*float64(chartHeight-marginTop-marginBottom)
+}
+
+// heightTicks returns the heights of the horizontal grid lines
+func (l layout) heightTicks() []float64 {
+	var ticks []float64
+	for height := l.minHeight; height <= l.maxHeight+l.step/2; height += l.step {
+		ticks = append(ticks, height)
+	}
+	return ticks
+}
+
+// timeTicks returns the times of the vertical grid lines
+func (l layout) timeTicks() []time.Time {
+	var ticks []time.Time
+	for t := l.from; !t.After(l.to); t = t.Add(hoursPerTick * time.Hour) {
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+// y maps a height in meters to its pixel row
+func (c TideChart) y(l layout, meters float64) float64 {
+	return l.y(c.Units.FromMeters(meters))
+}
+
+// formatTick formats a height already in the chart's units
+func (c TideChart) formatTick(height float64) string {
+	return fmt.Sprintf("%.1f%s", height, c.Units.Suffix())
+}
+
+func (c TideChart) formatExtreme(extreme worldtides.Extreme, location *time.Location) string {
+	return fmt.Sprintf("%s %s", extreme.Time().In(location).Format("15:04"), c.Units.FormatHeight(extreme.Height, 2))
+}
@@ -0,0 +1,111 @@
+package charts
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"tidebot/pkg/common"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+var polylineRegexp = regexp.MustCompile(`<polyline points="([^"]+)"`)
+
+// newTwoDayTides has half-hourly heights over two days, with a tide on the second day three times as large,
+// as the charts controller fetches the next day too
+func newTwoDayTides(from time.Time) *worldtides.WorldTidesResponse {
+	tides := &worldtides.WorldTidesResponse{}
+
+	for t := from; t.Before(from.AddDate(0, 0, 2)); t = t.Add(30 * time.Minute) {
+		amplitude := 1.0
+		if !t.Before(from.AddDate(0, 0, 1)) {
+			amplitude = 3
+		}
+		hours := t.Sub(from).Hours()
+		tides.Heights = append(tides.Heights, worldtides.Height{Dt: t.Unix(), Height: amplitude * math.Sin(2*math.Pi*hours/12.42)})
+	}
+
+	return tides
+}
+
+func newTestChart(t *testing.T) TideChart {
+	t.Helper()
+
+	spot := spotModels.Spot{Name: "Tarifa", Latitude: 36.01, Longitude: -5.60, Timezone: "Europe/Madrid"}
+	day := time.Date(2024, time.June, 21, 0, 0, 0, 0, spot.Location())
+
+	// Start the tides an hour early, so the day's first point is interpolated too
+	return NewTideChart(spot, newTwoDayTides(day.Add(-time.Hour)), day, day.Add(12*time.Hour), common.UnitsMeters)
+}
+
+func TestNewTideChartClipsHeightsToTheDay(t *testing.T) {
+	chart := newTestChart(t)
+
+	first, last := chart.Heights[0], chart.Heights[len(chart.Heights)-1]
+	if !first.Time().Equal(chart.From) || !last.Time().Equal(chart.To) {
+		t.Errorf("heights run from %s to %s, want %s to %s", first.Time(), last.Time(), chart.From, chart.To)
+	}
+
+	for _, height := range chart.Heights {
+		if height.Time().Before(chart.From) || height.Time().After(chart.To) {
+			t.Errorf("height at %s is outside the day", height.Time())
+		}
+	}
+
+	// The larger tide of the next day must not stretch the axis
+	l := newLayout(chart)
+	if l.minHeight < -1.5 || l.maxHeight > 1.5 {
+		t.Errorf("height axis runs from %.1f to %.1f, want within -1.5 to 1.5", l.minHeight, l.maxHeight)
+	}
+}
+
+func TestRenderSVGKeepsTheCurveInThePlot(t *testing.T) {
+	chart := newTestChart(t)
+
+	var body bytes.Buffer
+	if err := chart.RenderSVG(&body); err != nil {
+		t.Fatalf("RenderSVG() error = %v", err)
+	}
+
+	match := polylineRegexp.FindStringSubmatch(body.String())
+	if match == nil {
+		t.Fatal("no curve in the SVG")
+	}
+
+	for _, point := range strings.Fields(match[1]) {
+		xy := strings.Split(point, ",")
+		x, _ := strconv.ParseFloat(xy[0], 64)
+		y, _ := strconv.ParseFloat(xy[1], 64)
+
+		if x < marginLeft || x > chartWidth-marginRight || y < marginTop || y > chartHeight-marginBottom {
+			t.Errorf("point %s is outside the plot", point)
+		}
+	}
+}
+
+func TestRenderPNGKeepsTheWaterInThePlot(t *testing.T) {
+	chart := newTestChart(t)
+
+	var body bytes.Buffer
+	if err := chart.RenderPNG(&body); err != nil {
+		t.Fatalf("RenderPNG() error = %v", err)
+	}
+
+	img, err := png.Decode(&body)
+	if err != nil {
+		t.Fatalf("failed to decode PNG: %v", err)
+	}
+
+	for x := chartWidth - marginRight + 1; x < chartWidth; x++ {
+		for y := 0; y < chartHeight; y++ {
+			if img.At(x, y) == waterColor {
+				t.Fatalf("water drawn at %d,%d, right of the plot", x, y)
+			}
+		}
+	}
+}
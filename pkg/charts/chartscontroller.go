@@ -0,0 +1,112 @@
+package charts
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"tidebot/pkg/common"
+	spotRepos "tidebot/pkg/spots/repositories"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// Charts are only served around today so the public URL can't be used to spend credits on arbitrary dates
+	maxDaysBack  = 1
+	maxDaysAhead = 7
+
+	// Short enough for the now marker to stay roughly right
+	chartCacheControl = "public, max-age=600"
+)
+
+type ChartsController struct {
+	spotRepository spotRepos.SpotRepository
+	tidesClient    worldtides.WorldTidesClient
+	log            echo.Logger
+}
+
+func NewChartsController(spotRepository spotRepos.SpotRepository, tidesClient worldtides.WorldTidesClient, log echo.Logger) *ChartsController {
+	return &ChartsController{
+		spotRepository: spotRepository,
+		tidesClient:    tidesClient,
+		log:            log,
+	}
+}
+
+func (cc *ChartsController) RegisterRoutes(e *echo.Echo) {
+	e.GET("/charts/tides/:spot/:file", cc.GetTideChart)
+}
+
+// GetTideChart serves /charts/tides/<spot slug>/<YYYY-MM-DD>.png or .svg, optionally with ?units=feet and ?datum=LAT
+func (cc *ChartsController) GetTideChart(c echo.Context) error {
+	file := c.Param("file")
+	extension := path.Ext(file)
+
+	if extension != ".png" && extension != ".svg" {
+		return c.String(http.StatusNotFound, "Unsupported chart format")
+	}
+
+	spot, err := cc.spotRepository.GetBySlug(c.Param("spot"))
+	if err != nil {
+		return c.String(http.StatusNotFound, "Unknown spot")
+	}
+
+	units := common.UnitsMeters
+	if unitsParam := c.QueryParam("units"); unitsParam != "" {
+		parsed, ok := common.ParseUnits(unitsParam)
+		if !ok {
+			return c.String(http.StatusBadRequest, "Invalid units, use meters or feet")
+		}
+		units = parsed
+	}
+
+	if datumParam := c.QueryParam("datum"); datumParam != "" {
+		datum, ok := worldtides.ParseDatum(datumParam)
+		if !ok {
+			return c.String(http.StatusBadRequest, fmt.Sprintf("Invalid datum, use one of %s", strings.Join(worldtides.Datums, ", ")))
+		}
+		spot = worldtides.WithDatum(spot, &datum)
+	}
+
+	dateParam := strings.TrimSuffix(file, extension)
+	date, err := time.ParseInLocation("2006-01-02", dateParam, spot.Location())
+	if err != nil {
+		return c.String(http.StatusBadRequest, "Invalid date, use YYYY-MM-DD")
+	}
+
+	today := common.TodayIn(spot.Location())
+	if date.Before(today.AddDate(0, 0, -maxDaysBack)) || date.After(today.AddDate(0, 0, maxDaysAhead)) {
+		return c.String(http.StatusNotFound, fmt.Sprintf("Charts are available from %d days ago to %d days ahead", maxDaysBack, maxDaysAhead))
+	}
+
+	// Include the next day so the curve runs until midnight
+	tidesResponses, err := cc.tidesClient.GetTidesRange(c.Request().Context(), spot, date, 2, worldtides.UserTrigger("chart "+c.RealIP()))
+	if err != nil {
+		cc.log.Errorf("Failed to fetch tides for chart of %s on %s: %v", spot.Slug, dateParam, err)
+		return c.String(http.StatusServiceUnavailable, "Tide data is not available")
+	}
+
+	chart := NewTideChart(spot, worldtides.Merge(tidesResponses), date, time.Now(), units)
+
+	var body bytes.Buffer
+	contentType := "image/png"
+
+	if extension == ".svg" {
+		contentType = "image/svg+xml"
+		err = chart.RenderSVG(&body)
+	} else {
+		err = chart.RenderPNG(&body)
+	}
+
+	if err != nil {
+		cc.log.Errorf("Failed to render chart of %s on %s: %v", spot.Slug, dateParam, err)
+		return c.String(http.StatusInternalServerError, "Failed to render chart")
+	}
+
+	c.Response().Header().Set("Cache-Control", chartCacheControl)
+	return c.Blob(http.StatusOK, contentType, body.Bytes())
+}
@@ -0,0 +1,191 @@
+package charts
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+const textScale = 2
+
+var (
+	backgroundColor = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	nightColor      = color.RGBA{0xe3, 0xe8, 0xf0, 0xff}
+	gridColor       = color.RGBA{0xd0, 0xd5, 0xdd, 0xff}
+	waterColor      = color.RGBA{0xbf, 0xdd, 0xf5, 0xff}
+	curveColor      = color.RGBA{0x1f, 0x6f, 0xb2, 0xff}
+	textColor       = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	nowColor        = color.RGBA{0xe0, 0x45, 0x2b, 0xff}
+)
+
+// RenderPNG draws the chart as a PNG image
+func (c TideChart) RenderPNG(w io.Writer) error {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	l := newLayout(c)
+	location := c.From.Location()
+
+	fillRect(img, img.Bounds(), backgroundColor)
+
+	plotTop, plotBottom := marginTop, chartHeight-marginBottom
+	for _, night := range c.nightIntervals() {
+		fillRect(img, image.Rect(int(l.x(night[0])), plotTop, int(l.x(night[1])), plotBottom), nightColor)
+	}
+
+	for _, height := range l.heightTicks() {
+		y := int(math.Round(l.y(height)))
+		fillRect(img, image.Rect(marginLeft, y, chartWidth-marginRight, y+1), gridColor)
+		label := c.formatTick(height)
+		drawText(img, label, marginLeft-8-textWidth(label, textScale), y-glyphHeight*textScale/2, textColor)
+	}
+
+	for _, tick := range l.timeTicks() {
+		x := int(math.Round(l.x(tick)))
+		fillRect(img, image.Rect(x, plotTop, x+1, plotBottom), gridColor)
+		label := tick.In(location).Format("15")
+		drawText(img, label, x-textWidth(label, textScale)/2, plotBottom+10, textColor)
+	}
+
+	c.drawCurve(img, l)
+
+	for _, extreme := range c.Extremes {
+		t := extreme.Time()
+		if t.Before(c.From) || !t.Before(c.To) {
+			continue
+		}
+
+		x, y := int(math.Round(l.x(t))), int(math.Round(c.y(l, extreme.Height)))
+		fillCircle(img, x, y, 5, curveColor)
+
+		label := c.formatExtreme(extreme, location)
+		labelX := clamp(x-textWidth(label, textScale)/2, marginLeft, chartWidth-marginRight-textWidth(label, textScale))
+		labelY := y - 12 - glyphHeight*textScale
+		if extreme.IsLowTide() {
+			labelY = y + 12
+		}
+		drawText(img, label, labelX, clamp(labelY, plotTop+4, plotBottom-4-glyphHeight*textScale), textColor)
+	}
+
+	if c.showsNow() {
+		x := int(math.Round(l.x(c.Now)))
+		fillRect(img, image.Rect(x-1, plotTop, x+1, plotBottom), nowColor)
+		drawText(img, "NOW", x-textWidth("NOW", textScale)/2, plotTop-6-glyphHeight*textScale, nowColor)
+	}
+
+	drawText(img, c.Title, marginLeft, 12, textColor)
+
+	err := png.Encode(w, img)
+	if err != nil {
+		return fmt.Errorf("failed to encode chart: %w", err)
+	}
+
+	return nil
+}
+
+// drawCurve fills the water below the interpolated heights and strokes the curve on top
+func (c TideChart) drawCurve(img *image.RGBA, l layout) {
+	if len(c.Heights) < 2 {
+		return
+	}
+
+	plotBottom := chartHeight - marginBottom
+	points := make([]image.Point, len(c.Heights))
+	for i, height := range c.Heights {
+		points[i] = image.Pt(int(math.Round(l.x(height.Time()))), int(math.Round(c.y(l, height.Height))))
+	}
+
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		for x := a.X; x <= b.X; x++ {
+			y := a.Y
+			if b.X != a.X {
+				y = a.Y + (b.Y-a.Y)*(x-a.X)/(b.X-a.X)
+			}
+			fillRect(img, image.Rect(x, y, x+1, plotBottom), waterColor)
+		}
+	}
+
+	for i := 1; i < len(points); i++ {
+		drawLine(img, points[i-1], points[i], curveColor)
+	}
+}
+
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.Color) {
+	draw.Draw(img, rect, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// drawLine draws a line 3 pixels thick with Bresenham's algorithm
+func drawLine(img *image.RGBA, a image.Point, b image.Point, c color.RGBA) {
+	dx, dy := abs(b.X-a.X), -abs(b.Y-a.Y)
+	sx, sy := sign(b.X-a.X), sign(b.Y-a.Y)
+	err := dx + dy
+
+	for x, y := a.X, a.Y; ; {
+		fillRect(img, image.Rect(x-1, y-1, x+2, y+2), c)
+		if x == b.X && y == b.Y {
+			return
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+func fillCircle(img *image.RGBA, cx int, cy int, radius int, c color.RGBA) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.SetRGBA(cx+x, cy+y, c)
+			}
+		}
+	}
+}
+
+// drawText draws text with the bitmap font, x and y being its top left corner
+func drawText(img *image.RGBA, text string, x int, y int, c color.RGBA) {
+	for _, r := range text {
+		if g, ok := glyph(r); ok {
+			for row, line := range g {
+				for col, pixel := range line {
+					if pixel == '#' {
+						px, py := x+col*textScale, y+row*textScale
+						fillRect(img, image.Rect(px, py, px+textScale, py+textScale), c)
+					}
+				}
+			}
+		}
+		x += (glyphWidth + glyphSpacing) * textScale
+	}
+}
+
+func clamp(value int, low int, high int) int {
+	return max(low, min(value, high))
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func sign(value int) int {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	default:
+		return 0
+	}
+}
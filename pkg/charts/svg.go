@@ -0,0 +1,86 @@
+package charts
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// RenderSVG draws the chart as an SVG document
+func (c TideChart) RenderSVG(w io.Writer) error {
+	l := newLayout(c)
+	location := c.From.Location()
+	plotTop, plotBottom := float64(marginTop), float64(chartHeight-marginBottom)
+
+	var svg strings.Builder
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="13">`, chartWidth, chartHeight, chartWidth, chartHeight))
+	svg.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, chartWidth, chartHeight, hex(backgroundColor)))
+
+	for _, night := range c.nightIntervals() {
+		svg.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`,
+			l.x(night[0]), plotTop, l.x(night[1])-l.x(night[0]), plotBottom-plotTop, hex(nightColor)))
+	}
+
+	for _, height := range l.heightTicks() {
+		y := l.y(height)
+		svg.WriteString(fmt.Sprintf(`<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="%s"/>`, marginLeft, y, chartWidth-marginRight, y, hex(gridColor)))
+		svg.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`, marginLeft-8, y, hex(textColor), c.formatTick(height)))
+	}
+
+	for _, tick := range l.timeTicks() {
+		x := l.x(tick)
+		svg.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`, x, plotTop, x, plotBottom, hex(gridColor)))
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="middle" fill="%s">%s</text>`, x, plotBottom+24, hex(textColor), tick.In(location).Format("15:04")))
+	}
+
+	if len(c.Heights) >= 2 {
+		var points strings.Builder
+		for _, height := range c.Heights {
+			points.WriteString(fmt.Sprintf("%.1f,%.1f ", l.x(height.Time()), c.y(l, height.Height)))
+		}
+
+		first, last := c.Heights[0], c.Heights[len(c.Heights)-1]
+		svg.WriteString(fmt.Sprintf(`<polygon points="%.1f,%.1f %s%.1f,%.1f" fill="%s"/>`,
+			l.x(first.Time()), plotBottom, points.String(), l.x(last.Time()), plotBottom, hex(waterColor)))
+		svg.WriteString(fmt.Sprintf(`<polyline points="%s" fill="none" stroke="%s" stroke-width="3"/>`, strings.TrimSpace(points.String()), hex(curveColor)))
+	}
+
+	for _, extreme := range c.Extremes {
+		t := extreme.Time()
+		if t.Before(c.From) || !t.Before(c.To) {
+			continue
+		}
+
+		x, y := l.x(t), c.y(l, extreme.Height)
+		labelY := y - 12
+		if extreme.IsLowTide() {
+			labelY = y + 24
+		}
+
+		svg.WriteString(fmt.Sprintf(`<circle cx="%.1f" cy="%.1f" r="5" fill="%s"/>`, x, y, hex(curveColor)))
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="middle" fill="%s">%s</text>`, x, labelY, hex(textColor), c.formatExtreme(extreme, location)))
+	}
+
+	if c.showsNow() {
+		x := l.x(c.Now)
+		svg.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2"/>`, x, plotTop, x, plotBottom, hex(nowColor)))
+		svg.WriteString(fmt.Sprintf(`<text x="%.1f" y="%.1f" text-anchor="middle" fill="%s">now</text>`, x, plotTop-8, hex(nowColor)))
+	}
+
+	svg.WriteString(fmt.Sprintf(`<text x="%d" y="26" font-size="18" fill="%s">%s</text>`, marginLeft, hex(textColor), html.EscapeString(c.Title)))
+	svg.WriteString(`</svg>`)
+
+	_, err := io.WriteString(w, svg.String())
+	if err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+
+	return nil
+}
+
+func hex(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
@@ -0,0 +1,702 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// MaxParsedDays bounds how many days a date expression can cover, so a long range can't trigger a message per day
+const MaxParsedDays = 14
+
+// DayOrder is how numeric dates are read when both readings are valid, e.g. 05/06
+type DayOrder int
+
+const (
+	// DayFirst reads 05/06 as 5 June
+	DayFirst DayOrder = iota
+	// MonthFirst reads 05/06 as May 6
+	MonthFirst
+)
+
+// MONTH_FIRST_REGIONS write dates month first
+var MONTH_FIRST_REGIONS = []string{"US", "PR", "GU", "VI", "AS", "MP", "UM", "PH", "FM", "MH", "PW"}
+
+// DayOrderForPhoneNumber guesses the date order of the country of an international phone number
+func DayOrderForPhoneNumber(phoneNumber string) DayOrder {
+	number, err := phonenumbers.Parse(phoneNumber, "")
+	if err != nil {
+		return DayFirst
+	}
+
+	region := phonenumbers.GetRegionCodeForNumber(number)
+	for _, monthFirst := range MONTH_FIRST_REGIONS {
+		if region == monthFirst {
+			return MonthFirst
+		}
+	}
+
+	return DayFirst
+}
+
+// WEEKDAYS are the weekday names and abbreviations in English, Spanish, German and Polish, with the Polish
+// accusative and genitive forms of "w sobotę" and "od poniedziałku"
+var WEEKDAYS = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday, "domingo": time.Sunday, "dom": time.Sunday, "sonntag": time.Sunday,
+	"niedziela": time.Sunday, "niedzielę": time.Sunday, "niedzieli": time.Sunday, "niedz": time.Sunday, "nd": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday, "lunes": time.Monday, "lun": time.Monday, "montag": time.Monday,
+	"poniedziałek": time.Monday, "poniedzialek": time.Monday, "poniedziałku": time.Monday, "pon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday, "martes": time.Tuesday, "dienstag": time.Tuesday,
+	"wtorek": time.Tuesday, "wtorku": time.Tuesday, "wt": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "miércoles": time.Wednesday, "miercoles": time.Wednesday, "mié": time.Wednesday, "mie": time.Wednesday,
+	"mittwoch": time.Wednesday, "środa": time.Wednesday, "sroda": time.Wednesday, "środę": time.Wednesday, "środy": time.Wednesday, "śr": time.Wednesday, "sr": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday, "jueves": time.Thursday, "jue": time.Thursday, "donnerstag": time.Thursday,
+	"czwartek": time.Thursday, "czwartku": time.Thursday, "czw": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "viernes": time.Friday, "vie": time.Friday, "freitag": time.Friday,
+	"piątek": time.Friday, "piatek": time.Friday, "piątku": time.Friday, "piatku": time.Friday, "pt": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "sábado": time.Saturday, "sabado": time.Saturday, "sáb": time.Saturday, "sab": time.Saturday,
+	"samstag": time.Saturday, "sonnabend": time.Saturday, "sobota": time.Saturday, "sobotę": time.Saturday, "sobote": time.Saturday, "soboty": time.Saturday, "sob": time.Saturday,
+}
+
+// MONTHS are the month names and abbreviations in English, Spanish, German and Polish, with the Polish
+// genitive forms of "24 grudnia"
+var MONTHS = map[string]time.Month{
+	"january": time.January, "jan": time.January, "enero": time.January, "ene": time.January, "januar": time.January, "jänner": time.January,
+	"styczeń": time.January, "styczen": time.January, "stycznia": time.January, "sty": time.January,
+	"february": time.February, "feb": time.February, "febrero": time.February, "februar": time.February,
+	"luty": time.February, "lutego": time.February, "lut": time.February,
+	"march": time.March, "mar": time.March, "marzo": time.March, "märz": time.March, "maerz": time.March,
+	"marzec": time.March, "marca": time.March,
+	"april": time.April, "apr": time.April, "abril": time.April, "abr": time.April,
+	"kwiecień": time.April, "kwiecien": time.April, "kwietnia": time.April, "kwi": time.April,
+	"may": time.May, "mayo": time.May, "mai": time.May, "maj": time.May, "maja": time.May,
+	"june": time.June, "jun": time.June, "junio": time.June, "juni": time.June,
+	"czerwiec": time.June, "czerwca": time.June, "cze": time.June,
+	"july": time.July, "jul": time.July, "julio": time.July, "juli": time.July,
+	"lipiec": time.July, "lipca": time.July, "lip": time.July,
+	"august": time.August, "aug": time.August, "agosto": time.August, "ago": time.August,
+	"sierpień": time.August, "sierpien": time.August, "sierpnia": time.August, "sie": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September, "septiembre": time.September, "setiembre": time.September,
+	"wrzesień": time.September, "wrzesien": time.September, "września": time.September, "wrzesnia": time.September, "wrz": time.September,
+	"october": time.October, "oct": time.October, "octubre": time.October, "oktober": time.October, "okt": time.October,
+	"październik": time.October, "pazdziernik": time.October, "października": time.October, "pazdziernika": time.October, "paź": time.October, "paz": time.October,
+	"november": time.November, "nov": time.November, "noviembre": time.November,
+	"listopad": time.November, "listopada": time.November, "lis": time.November,
+	"december": time.December, "dec": time.December, "diciembre": time.December, "dic": time.December, "dezember": time.December, "dez": time.December,
+	"grudzień": time.December, "grudzien": time.December, "grudnia": time.December, "gru": time.December,
+}
+
+// RELATIVE_DAYS are the days named relative to today
+var RELATIVE_DAYS = map[string]int{
+	"today": 0, "hoy": 0, "heute": 0, "dziś": 0, "dzis": 0, "dzisiaj": 0,
+	"tomorrow": 1, "mañana": 1, "manana": 1, "morgen": 1, "jutro": 1,
+	"yesterday": -1, "ayer": -1, "gestern": -1, "wczoraj": -1,
+	"pasado mañana": 2, "pasado manana": 2, "übermorgen": 2, "uebermorgen": 2, "pojutrze": 2,
+}
+
+// NEXT_WORDS and THIS_WORDS pick the week, weekend or weekday they come before
+var NEXT_WORDS = map[string]bool{
+	"next": true, "próximo": true, "proximo": true, "próxima": true, "proxima": true, "siguiente": true,
+	"nächsten": true, "nächste": true, "nächstes": true, "naechsten": true, "naechste": true, "kommenden": true, "kommende": true,
+	"następny": true, "nastepny": true, "następna": true, "nastepna": true, "przyszły": true, "przyszly": true, "przyszłym": true, "przyszlym": true,
+}
+var THIS_WORDS = map[string]bool{
+	"this": true, "este": true, "esta": true, "diese": true, "diesen": true, "dieses": true, "ten": true, "ta": true, "tę": true, "tym": true,
+}
+
+// WEEK_WORDS and WEEKEND_WORDS name the coming week and weekend
+var WEEK_WORDS = map[string]bool{"week": true, "semana": true, "woche": true, "tydzień": true, "tydzien": true, "tygodniu": true}
+var WEEKEND_WORDS = map[string]bool{"weekend": true, "finde": true, "fin de semana": true, "wochenende": true}
+
+// IN_WORDS start an offset from today, as in "in 3 days", "en 3 días", "in 3 Tagen" or "za 3 dni"
+var IN_WORDS = map[string]bool{"in": true, "en": true, "dentro de": true, "za": true}
+
+// datePhrases are read as a single word
+var datePhrases = []string{"fin de semana", "pasado mañana", "pasado manana", "dentro de"}
+
+// dateFillerWords are skipped between expressions, as in "today and tomorrow", "el sábado" or "am Samstag"
+var dateFillerWords = map[string]bool{
+	"and": true, "on": true, "the": true, "for": true, "&": true,
+	"y": true, "el": true, "la": true, "los": true, "de": true, "del": true, "para": true,
+	"und": true, "am": true, "den": true, "für": true, "vom": true, "von": true,
+	"i": true, "w": true, "we": true, "na": true, "od": true,
+}
+
+// rangeWords join the two ends of a range, as in "monday to friday", "lunes a viernes" or "montag bis freitag"
+var rangeWords = map[string]bool{"-": true, "–": true, "to": true, "until": true, "till": true, "a": true, "al": true, "hasta": true, "bis": true, "do": true}
+
+// monthConnectors may come between a day and its month or year, as in "24 de diciembre de 2026" or "24th of december"
+var monthConnectors = map[string]bool{"de": true, "del": true, "of": true}
+
+// yearlessPastDays is how long ago a date without a year can be before it is read as next year's
+const yearlessPastDays = 31
+
+// DateErrorReason is why a date expression couldn't be understood, which callers word for the user
+type DateErrorReason string
+
+const (
+	NotADate             DateErrorReason = "not a date"
+	NoSuchDay            DateErrorReason = "there's no such day"
+	NotANumberOfDays     DateErrorReason = "not a number of days"
+	NotASingleDay        DateErrorReason = "not a single day"
+	RangeEndsBeforeStart DateErrorReason = "the range ends before it starts"
+	// TooManyDays is more than MaxParsedDays
+	TooManyDays DateErrorReason = "too many days"
+)
+
+// DateError is a date expression that couldn't be understood
+type DateError struct {
+	Expression string
+	Reason     DateErrorReason
+}
+
+func (e *DateError) Error() string {
+	return fmt.Sprintf("%q: %s", e.Expression, e.Reason)
+}
+
+// DateParser reads day expressions relative to Today, whose location the days are returned in
+type DateParser struct {
+	Today time.Time
+	Order DayOrder
+}
+
+func NewDateParser(today time.Time, order DayOrder) DateParser {
+	return DateParser{
+		Today: time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location()),
+		Order: order,
+	}
+}
+
+// ParseDates reads the days named by the words, e.g. "saturday", "next monday", "in 3 days", "+2",
+// "weekend", "week", "mon-fri", "24/12-28/12" or "24 dec", sorted and without duplicates.
+// Words that aren't part of a date expression are a *DateError. The words may be in any language of WEEKDAYS.
+func (p DateParser) ParseDates(words []string) ([]time.Time, error) {
+	var days []time.Time
+
+	words = joinPhrases(words)
+
+	for i := 0; i < len(words); {
+		word := cleanDateWord(words[i])
+		if word == "" || dateFillerWords[word] {
+			i++
+			continue
+		}
+
+		expressionDays, consumed, err := p.parseExpression(words[i:])
+		if err != nil {
+			return nil, err
+		}
+
+		days = append(days, expressionDays...)
+		i += consumed
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+	days = compactDays(days)
+
+	if len(days) > MaxParsedDays {
+		return nil, &DateError{Expression: strings.Join(words, " "), Reason: TooManyDays}
+	}
+
+	return days, nil
+}
+
+// ParseDay reads an expression naming a single day
+func (p DateParser) ParseDay(expression string) (time.Time, error) {
+	days, err := p.ParseDates(strings.Fields(expression))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(days) != 1 {
+		return time.Time{}, &DateError{Expression: expression, Reason: NotASingleDay}
+	}
+
+	return days[0], nil
+}
+
+// parseExpression reads the expression at the start of the words, returning its days and how many words it took
+func (p DateParser) parseExpression(words []string) ([]time.Time, int, error) {
+	first := cleanDateWord(words[0])
+	second := ""
+	if len(words) > 1 {
+		second = cleanDateWord(words[1])
+	}
+
+	switch {
+	case WEEK_WORDS[first]:
+		return p.span(p.Today, 7), 1, nil
+	case THIS_WORDS[first] && WEEK_WORDS[second]:
+		return p.span(p.Today, 7), 2, nil
+	case NEXT_WORDS[first] && WEEK_WORDS[second]:
+		monday := p.weekdayAfter(time.Monday, p.Today.AddDate(0, 0, 1))
+		return p.span(monday, 7), 2, nil
+	case WEEKEND_WORDS[first]:
+		return p.weekend(p.Today), 1, nil
+	case THIS_WORDS[first] && WEEKEND_WORDS[second]:
+		return p.weekend(p.Today), 2, nil
+	case NEXT_WORDS[first] && WEEKEND_WORDS[second]:
+		thisWeekend := p.weekend(p.Today)
+		return p.weekend(thisWeekend[len(thisWeekend)-1].AddDate(0, 0, 1)), 2, nil
+	}
+
+	// A range written as a single word, e.g. "mon-fri" or "24/12-28/12"
+	if from, to, ok := splitRangeWord(first); ok {
+		start, err := p.parseSingleDay(from, p.Today, false)
+		if err != nil {
+			return nil, 0, wholeWordError(err, first)
+		}
+		end, err := p.parseSingleDay(to, start, true)
+		if err != nil {
+			return nil, 0, wholeWordError(err, first)
+		}
+		days, err := p.rangeDays(first, start, end)
+		return days, 1, err
+	}
+
+	start, consumed, err := p.parseDay(words, p.Today, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// A range written as separate words, e.g. "monday to friday" or "24/12 - 28/12"
+	if consumed < len(words)-1 && rangeWords[cleanDateWord(words[consumed])] {
+		end, endConsumed, err := p.parseDay(words[consumed+1:], start, true)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		total := consumed + 1 + endConsumed
+		days, err := p.rangeDays(strings.Join(words[:total], " "), start, end)
+		return days, total, err
+	}
+
+	return []time.Time{start}, consumed, nil
+}
+
+// parseDay reads a single day at the start of the words. Weekdays and dates without a year are the
+// first on or after ref when ending a range, and the next ones from today otherwise.
+func (p DateParser) parseDay(words []string, ref time.Time, isEnd bool) (time.Time, int, error) {
+	first := cleanDateWord(words[0])
+	second, third := "", ""
+	if len(words) > 1 {
+		second = cleanDateWord(words[1])
+	}
+	if len(words) > 2 {
+		third = cleanDateWord(words[2])
+	}
+
+	// "next saturday" is the first one after today, so a week ahead when today is a Saturday
+	if weekday, ok := WEEKDAYS[second]; ok && NEXT_WORDS[first] {
+		return p.weekdayAfter(weekday, p.Today.AddDate(0, 0, 1)), 2, nil
+	}
+	if _, ok := WEEKDAYS[second]; ok && THIS_WORDS[first] {
+		day, err := p.parseSingleDay(second, ref, isEnd)
+		return day, 2, err
+	}
+
+	// "in 3 days", "in a week", "in 2 weeks", and "za tydzień" without a number
+	if IN_WORDS[first] && second != "" {
+		if offset, ok := parseOffset(second, third); ok {
+			return p.Today.AddDate(0, 0, offset), 3, nil
+		}
+		if offset, ok := parseOffset("1", second); ok {
+			return p.Today.AddDate(0, 0, offset), 2, nil
+		}
+	}
+
+	// "24 dec", "24th december 2026", "dec 24", "december 24, 2026"
+	if day, consumed, ok, err := p.parseDayWithMonthName(words, ref, isEnd); ok || err != nil {
+		return day, consumed, err
+	}
+
+	day, err := p.parseSingleDay(first, ref, isEnd)
+	return day, 1, err
+}
+
+// parseSingleDay reads a day written as a single word
+func (p DateParser) parseSingleDay(word string, ref time.Time, isEnd bool) (time.Time, error) {
+	if offset, ok := RELATIVE_DAYS[word]; ok {
+		return p.Today.AddDate(0, 0, offset), nil
+	}
+
+	if weekday, ok := WEEKDAYS[word]; ok {
+		if isEnd {
+			return p.weekdayAfter(weekday, ref), nil
+		}
+		return p.weekdayAfter(weekday, p.Today), nil
+	}
+
+	// "+2" is the day after tomorrow
+	if offsetText, ok := strings.CutPrefix(word, "+"); ok {
+		offset, err := strconv.Atoi(offsetText)
+		if err != nil || offset < 0 {
+			return time.Time{}, &DateError{Expression: word, Reason: NotANumberOfDays}
+		}
+		return p.Today.AddDate(0, 0, offset), nil
+	}
+
+	return p.parseNumericDate(word, ref, isEnd)
+}
+
+// parseNumericDate reads 2025-12-24, 24/12, 24/12/2025, 24.12.25, 24-12-2025 or 24-dec-2025. When day
+// and month can be swapped, both readings being valid, the parser's order decides.
+func (p DateParser) parseNumericDate(word string, ref time.Time, isEnd bool) (time.Time, error) {
+	notADate := &DateError{Expression: word, Reason: NotADate}
+
+	separator := ""
+	for _, candidate := range []string{"/", ".", "-"} {
+		if strings.Contains(word, candidate) {
+			separator = candidate
+			break
+		}
+	}
+	if separator == "" {
+		return time.Time{}, notADate
+	}
+
+	parts := strings.Split(word, separator)
+	if len(parts) < 2 || len(parts) > 3 || (separator == "-" && len(parts) != 3) {
+		return time.Time{}, notADate
+	}
+
+	// Year first, e.g. 2025-12-24 or 2025/12/24
+	if len(parts) == 3 && len(parts[0]) == 4 {
+		date, ok := p.buildDate(parts[1], parts[2], parts[0], ref, isEnd)
+		if !ok {
+			return time.Time{}, &DateError{Expression: word, Reason: NoSuchDay}
+		}
+		return date, nil
+	}
+
+	yearWord := ""
+	if len(parts) == 3 {
+		yearWord = parts[2]
+	}
+	first, second := parts[0], parts[1]
+
+	// A month name in the middle, e.g. 24-dec-2025
+	if _, ok := MONTHS[second]; ok {
+		date, ok := p.buildDate(second, first, yearWord, ref, isEnd)
+		if !ok {
+			return time.Time{}, &DateError{Expression: word, Reason: NoSuchDay}
+		}
+		return date, nil
+	}
+
+	dayFirst := p.validDay(second, first)
+	monthFirst := p.validDay(first, second)
+
+	var month, day string
+	switch {
+	case dayFirst && monthFirst:
+		if p.Order == MonthFirst {
+			month, day = first, second
+		} else {
+			month, day = second, first
+		}
+	case dayFirst:
+		month, day = second, first
+	case monthFirst:
+		month, day = first, second
+	default:
+		if isNumber(first) && isNumber(second) {
+			return time.Time{}, &DateError{Expression: word, Reason: NoSuchDay}
+		}
+		return time.Time{}, notADate
+	}
+
+	date, ok := p.buildDate(month, day, yearWord, ref, isEnd)
+	if !ok {
+		return time.Time{}, &DateError{Expression: word, Reason: NoSuchDay}
+	}
+
+	return date, nil
+}
+
+// parseDayWithMonthName reads a day number and a month name in either order, optionally followed by a year.
+// Connectors between them are skipped, as in "24 de diciembre de 2026".
+func (p DateParser) parseDayWithMonthName(words []string, ref time.Time, isEnd bool) (time.Time, int, bool, error) {
+	if len(words) < 2 {
+		return time.Time{}, 0, false, nil
+	}
+
+	first := cleanDateWord(words[0])
+	secondAt := skipConnectors(words, 1)
+	if secondAt == len(words) {
+		return time.Time{}, 0, false, nil
+	}
+	second := cleanDateWord(words[secondAt])
+
+	var monthWord, dayWord string
+	if _, ok := MONTHS[second]; ok && isNumber(trimOrdinal(first)) {
+		monthWord, dayWord = second, trimOrdinal(first)
+	} else if _, ok := MONTHS[first]; ok && isNumber(trimOrdinal(second)) {
+		monthWord, dayWord = first, trimOrdinal(second)
+	} else {
+		return time.Time{}, 0, false, nil
+	}
+
+	consumed := secondAt + 1
+	yearWord := ""
+	if yearAt := skipConnectors(words, consumed); yearAt < len(words) {
+		if candidate := cleanDateWord(words[yearAt]); len(candidate) == 4 && isNumber(candidate) {
+			yearWord = candidate
+			consumed = yearAt + 1
+		}
+	}
+
+	day, ok := p.buildDate(monthWord, dayWord, yearWord, ref, isEnd)
+	if !ok {
+		return time.Time{}, 0, false, &DateError{Expression: strings.Join(words[:consumed], " "), Reason: NoSuchDay}
+	}
+
+	return day, consumed, true, nil
+}
+
+// buildDate builds the day from its parts, the month being a number or a name. Without a year, the day is the
+// first one on or after ref when ending a range, and otherwise this year's unless it is long past.
+func (p DateParser) buildDate(monthWord string, dayWord string, yearWord string, ref time.Time, isEnd bool) (time.Time, bool) {
+	month, ok := MONTHS[monthWord]
+	if !ok {
+		number, err := strconv.Atoi(monthWord)
+		if err != nil || number < 1 || number > 12 {
+			return time.Time{}, false
+		}
+		month = time.Month(number)
+	}
+
+	day, err := strconv.Atoi(dayWord)
+	if err != nil || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	if yearWord != "" {
+		year, err := strconv.Atoi(yearWord)
+		if err != nil {
+			return time.Time{}, false
+		}
+		if len(yearWord) == 2 {
+			year += 2000
+		}
+		return p.date(year, month, day)
+	}
+
+	date, ok := p.date(p.Today.Year(), month, day)
+	if !ok {
+		// 29/02 in a common year is next leap year's at best, which is too far to be meant
+		return time.Time{}, false
+	}
+
+	if isEnd && date.Before(ref) {
+		return p.date(date.Year()+1, month, day)
+	}
+	if !isEnd && date.Before(p.Today.AddDate(0, 0, -yearlessPastDays)) {
+		return p.date(date.Year()+1, month, day)
+	}
+
+	return date, true
+}
+
+// date builds the day, refusing days that overflow into the next month like 31/04
+func (p DateParser) date(year int, month time.Month, day int) (time.Time, bool) {
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.Today.Location())
+	if date.Month() != month || date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// validDay tells whether the numbers can be a month and a day of it in some year
+func (p DateParser) validDay(monthWord string, dayWord string) bool {
+	month, err := strconv.Atoi(monthWord)
+	if err != nil || month < 1 || month > 12 {
+		return false
+	}
+	day, err := strconv.Atoi(dayWord)
+	if err != nil || day < 1 {
+		return false
+	}
+
+	// The last day of the month in a leap year, so 29/02 counts
+	daysInMonth := time.Date(2024, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return day <= daysInMonth
+}
+
+func (p DateParser) rangeDays(expression string, start time.Time, end time.Time) ([]time.Time, error) {
+	if end.Before(start) {
+		return nil, &DateError{Expression: expression, Reason: RangeEndsBeforeStart}
+	}
+
+	days := DaysBetween(start, end)
+	if days > MaxParsedDays {
+		return nil, &DateError{Expression: expression, Reason: TooManyDays}
+	}
+
+	return p.span(start, days), nil
+}
+
+// span returns the days days from start
+func (p DateParser) span(start time.Time, days int) []time.Time {
+	span := make([]time.Time, days)
+	for i := range span {
+		span[i] = start.AddDate(0, 0, i)
+	}
+	return span
+}
+
+// weekend is the Saturday and Sunday on or after from, or just Sunday when from is a Sunday
+func (p DateParser) weekend(from time.Time) []time.Time {
+	if from.Weekday() == time.Sunday {
+		return []time.Time{from}
+	}
+	saturday := p.weekdayAfter(time.Saturday, from)
+	return p.span(saturday, 2)
+}
+
+// weekdayAfter returns the first day falling on the weekday, from included
+func (p DateParser) weekdayAfter(weekday time.Weekday, from time.Time) time.Time {
+	return from.AddDate(0, 0, (int(weekday)-int(from.Weekday())+7)%7)
+}
+
+// DaysBetween counts the days from from to to, both included
+func DaysBetween(from time.Time, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours()/24) + 1
+}
+
+// NextWeekday returns the next day falling on the weekday, today in the location included
+func NextWeekday(weekday time.Weekday, location *time.Location) time.Time {
+	today := TodayIn(location)
+	return NewDateParser(today, DayFirst).weekdayAfter(weekday, today)
+}
+
+// ParseDate reads a single day, e.g. "2025-12-24", "tomorrow" or "saturday", relative to today in the
+// location, reading ambiguous numeric dates day first
+func ParseDate(dateStr string, location *time.Location) (time.Time, error) {
+	date, err := NewDateParser(TodayIn(location), DayFirst).ParseDay(dateStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse date: %w", err)
+	}
+	return date, nil
+}
+
+// wholeWordError reports an error in one end of a single-word range against the whole word, which is
+// what the user typed, e.g. a misspelled "el-cotilo" rather than "el"
+func wholeWordError(err error, word string) error {
+	var dateErr *DateError
+	if errors.As(err, &dateErr) {
+		return &DateError{Expression: word, Reason: dateErr.Reason}
+	}
+	return err
+}
+
+// joinPhrases joins the words of datePhrases into single words
+func joinPhrases(words []string) []string {
+	joined := make([]string, 0, len(words))
+
+	for i := 0; i < len(words); i++ {
+		matched := false
+		for _, phrase := range datePhrases {
+			length := len(strings.Fields(phrase))
+			if i+length > len(words) {
+				continue
+			}
+
+			candidate := make([]string, length)
+			for j := range candidate {
+				candidate[j] = cleanDateWord(words[i+j])
+			}
+			if strings.Join(candidate, " ") == phrase {
+				joined = append(joined, phrase)
+				i += length - 1
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			joined = append(joined, words[i])
+		}
+	}
+
+	return joined
+}
+
+// skipConnectors returns the index of the first word from i that isn't one of monthConnectors
+func skipConnectors(words []string, i int) int {
+	for i < len(words) && monthConnectors[cleanDateWord(words[i])] {
+		i++
+	}
+	return i
+}
+
+// splitRangeWord splits "mon-fri" or "24/12-28/12" into its ends, leaving dates written with dashes alone
+func splitRangeWord(word string) (string, string, bool) {
+	for _, separator := range []string{"–", "-"} {
+		parts := strings.Split(word, separator)
+		if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+			return parts[0], parts[1], true
+		}
+	}
+	return "", "", false
+}
+
+// parseOffset reads "3 days", "a week", "2 weeks", "una semana" or "3 Tagen" as a number of days
+func parseOffset(amount string, unit string) (int, bool) {
+	count := 0
+	switch amount {
+	case "a", "an", "one", "un", "una", "uno", "einer", "einem", "eine", "ein", "jeden", "jedna":
+		count = 1
+	default:
+		parsed, err := strconv.Atoi(amount)
+		if err != nil || parsed < 0 {
+			return 0, false
+		}
+		count = parsed
+	}
+
+	switch unit {
+	case "day", "days", "día", "días", "dia", "dias", "tag", "tage", "tagen", "dzień", "dzien", "dni":
+		return count, true
+	case "week", "weeks", "semana", "semanas", "woche", "wochen", "tydzień", "tydzien", "tygodnie", "tygodni":
+		return count * 7, true
+	default:
+		return 0, false
+	}
+}
+
+func cleanDateWord(word string) string {
+	return strings.Trim(strings.ToLower(word), ",;!?")
+}
+
+func trimOrdinal(word string) string {
+	for _, suffix := range []string{"st", "nd", "rd", "th", "."} {
+		if trimmed, ok := strings.CutSuffix(word, suffix); ok {
+			return trimmed
+		}
+	}
+	return word
+}
+
+func isNumber(word string) bool {
+	_, err := strconv.Atoi(word)
+	return err == nil
+}
+
+func compactDays(days []time.Time) []time.Time {
+	var compacted []time.Time
+	for _, day := range days {
+		if len(compacted) == 0 || !compacted[len(compacted)-1].Equal(day) {
+			compacted = append(compacted, day)
+		}
+	}
+	return compacted
+}
@@ -0,0 +1,151 @@
+package common
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testToday is a Saturday close to the end of the year
+var testToday = time.Date(2025, time.December, 27, 0, 0, 0, 0, time.UTC)
+
+func day(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDays(days []time.Time) string {
+	formatted := make([]string, len(days))
+	for i, day := range days {
+		formatted[i] = day.Format("2006-01-02")
+	}
+	return strings.Join(formatted, " ")
+}
+
+func TestParseDates(t *testing.T) {
+	tests := []struct {
+		expression string
+		order      DayOrder
+		want       []time.Time
+	}{
+		{"today", DayFirst, []time.Time{day(2025, 12, 27)}},
+		{"tomorrow", DayFirst, []time.Time{day(2025, 12, 28)}},
+		{"pasado mañana", DayFirst, []time.Time{day(2025, 12, 29)}},
+		{"+2", DayFirst, []time.Time{day(2025, 12, 29)}},
+		// Weekdays are the next ones from today, today included
+		{"saturday", DayFirst, []time.Time{day(2025, 12, 27)}},
+		{"this saturday", DayFirst, []time.Time{day(2025, 12, 27)}},
+		{"next saturday", DayFirst, []time.Time{day(2026, 1, 3)}},
+		{"nächsten montag", DayFirst, []time.Time{day(2025, 12, 29)}},
+		{"in a week", DayFirst, []time.Time{day(2026, 1, 3)}},
+		{"in 3 days", DayFirst, []time.Time{day(2025, 12, 30)}},
+		{"za tydzień", DayFirst, []time.Time{day(2026, 1, 3)}},
+		{"dentro de 2 días", DayFirst, []time.Time{day(2025, 12, 29)}},
+		// Both readings of 05/06 are valid, and 2025's are long past
+		{"05/06", DayFirst, []time.Time{day(2026, 6, 5)}},
+		{"05/06", MonthFirst, []time.Time{day(2026, 5, 6)}},
+		// Only one reading is valid
+		{"13/05", MonthFirst, []time.Time{day(2026, 5, 13)}},
+		{"05/13", DayFirst, []time.Time{day(2026, 5, 13)}},
+		{"2026-01-05", MonthFirst, []time.Time{day(2026, 1, 5)}},
+		// Dates within the last month stay in this year
+		{"24/12", DayFirst, []time.Time{day(2025, 12, 24)}},
+		{"24 de diciembre de 2026", DayFirst, []time.Time{day(2026, 12, 24)}},
+		{"jan 2", DayFirst, []time.Time{day(2026, 1, 2)}},
+		{"30/12-02/01", DayFirst, []time.Time{day(2025, 12, 30), day(2025, 12, 31), day(2026, 1, 1), day(2026, 1, 2)}},
+		{"30 dec to 2 jan", DayFirst, []time.Time{day(2025, 12, 30), day(2025, 12, 31), day(2026, 1, 1), day(2026, 1, 2)}},
+		{"monday to wednesday", DayFirst, []time.Time{day(2025, 12, 29), day(2025, 12, 30), day(2025, 12, 31)}},
+		{"fri-mon", DayFirst, []time.Time{day(2026, 1, 2), day(2026, 1, 3), day(2026, 1, 4), day(2026, 1, 5)}},
+		// The weekend has started, and the next one is a week later
+		{"weekend", DayFirst, []time.Time{day(2025, 12, 27), day(2025, 12, 28)}},
+		{"next weekend", DayFirst, []time.Time{day(2026, 1, 3), day(2026, 1, 4)}},
+		{"fin de semana", DayFirst, []time.Time{day(2025, 12, 27), day(2025, 12, 28)}},
+		{"next week", DayFirst, []time.Time{day(2025, 12, 29), day(2025, 12, 30), day(2025, 12, 31), day(2026, 1, 1), day(2026, 1, 2), day(2026, 1, 3), day(2026, 1, 4)}},
+		{"today and tomorrow and today", DayFirst, []time.Time{day(2025, 12, 27), day(2025, 12, 28)}},
+		{"el sábado", DayFirst, []time.Time{day(2025, 12, 27)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			days, err := NewDateParser(testToday, tt.order).ParseDates(strings.Fields(tt.expression))
+			if err != nil {
+				t.Fatalf("ParseDates() error = %v", err)
+			}
+			if formatDays(days) != formatDays(tt.want) {
+				t.Errorf("ParseDates() = %s, want %s", formatDays(days), formatDays(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseDatesErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		reason     DateErrorReason
+		word       string
+	}{
+		{"tomorow", NotADate, "tomorow"},
+		{"el-cotilo", NotADate, "el-cotilo"},
+		{"31/04", NoSuchDay, "31/04"},
+		{"29/02", NoSuchDay, "29/02"},
+		{"31 feb", NoSuchDay, "31 feb"},
+		{"+x", NotANumberOfDays, "+x"},
+		{"30/12/2025 - 28/12/2025", RangeEndsBeforeStart, "30/12/2025 - 28/12/2025"},
+		{"1/1-31/1", TooManyDays, "1/1-31/1"},
+		// Each range is short enough, but not all of them together
+		{"mon-fri 5/1-11/1 12/1-16/1", TooManyDays, "mon-fri 5/1-11/1 12/1-16/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := NewDateParser(testToday, DayFirst).ParseDates(strings.Fields(tt.expression))
+
+			var dateErr *DateError
+			if !errors.As(err, &dateErr) {
+				t.Fatalf("ParseDates() error = %v, want a *DateError", err)
+			}
+			if dateErr.Reason != tt.reason {
+				t.Errorf("reason = %q, want %q", dateErr.Reason, tt.reason)
+			}
+			if dateErr.Expression != tt.word {
+				t.Errorf("expression = %q, want %q", dateErr.Expression, tt.word)
+			}
+		})
+	}
+}
+
+func TestParseDay(t *testing.T) {
+	parser := NewDateParser(testToday, DayFirst)
+
+	date, err := parser.ParseDay("next saturday")
+	if err != nil || !date.Equal(day(2026, 1, 3)) {
+		t.Errorf("ParseDay() = %s, %v, want 2026-01-03", date.Format("2006-01-02"), err)
+	}
+
+	_, err = parser.ParseDay("weekend")
+	var dateErr *DateError
+	if !errors.As(err, &dateErr) || dateErr.Reason != NotASingleDay {
+		t.Errorf("ParseDay() error = %v, want %q", err, NotASingleDay)
+	}
+}
+
+func TestParseDateUsesTodayInTheLocation(t *testing.T) {
+	// The day differs from UTC's for part of every day in one of these
+	for _, name := range []string{"Pacific/Kiritimati", "Pacific/Pago_Pago"} {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", name, err)
+		}
+
+		date, err := ParseDate("today", location)
+		if err != nil {
+			t.Fatalf("ParseDate() error = %v", err)
+		}
+		if today := TodayIn(location); !date.Equal(today) || date.Location() != location {
+			t.Errorf("ParseDate() = %s, want %s", date, today)
+		}
+		if weekday := NextWeekday(date.Weekday(), location); !weekday.Equal(date) {
+			t.Errorf("NextWeekday() = %s, want %s", weekday, date)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Units are the length units heights are shown in. Heights are always handled in meters internally.
+type Units string
+
+const (
+	UnitsMeters Units = "meters"
+	UnitsFeet   Units = "feet"
+
+	metersPerFoot = 0.3048
+)
+
+// ParseUnits accepts the unit names, in the languages the bot speaks, and their usual abbreviations
+func ParseUnits(name string) (Units, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "meters", "metres", "meter", "metre", "m", "metric", "metros", "metro", "metry", "metrów", "metrow":
+		return UnitsMeters, true
+	case "feet", "foot", "ft", "imperial", "pies", "pie", "fuß", "fuss", "stopy", "stóp":
+		return UnitsFeet, true
+	default:
+		return "", false
+	}
+}
+
+// FromMeters converts a height in meters to the units
+func (u Units) FromMeters(meters float64) float64 {
+	if u == UnitsFeet {
+		return meters / metersPerFoot
+	}
+	return meters
+}
+
+// ToMeters converts a height in the units to meters
+func (u Units) ToMeters(value float64) float64 {
+	if u == UnitsFeet {
+		return value * metersPerFoot
+	}
+	return value
+}
+
+func (u Units) Suffix() string {
+	if u == UnitsFeet {
+		return "ft"
+	}
+	return "m"
+}
+
+// FormatHeight formats a height in meters in the units, e.g. "1.25m" or "4.10ft"
+func (u Units) FormatHeight(meters float64, decimals int) string {
+	return fmt.Sprintf("%.*f%s", decimals, u.FromMeters(meters), u.Suffix())
+}
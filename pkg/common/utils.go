@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,46 +34,82 @@ func FormatPhoneNumber(phoneNumber string) string {
 	return phonenumbers.Format(num, phonenumbers.INTERNATIONAL)
 }
 
-var DATE_FORMATS = []string{
-	"2006-01-02",      // 2023-12-25
-	"01/02/2006",      // 12/25/2023
-	"1/2/2006",        // 12/5/2023 (no leading zeros)
-	"02/01/2006",      // 25/12/2023 (DD/MM/YYYY)
-	"2/1/2006",        // 5/12/2023 (D/M/YYYY)
-	"Jan 2, 2006",     // Dec 25, 2023
-	"January 2, 2006", // December 25, 2023
-	"2 Jan 2006",      // 25 Dec 2023
-	"2 January 2006",  // 25 December 2023
-	"2006/01/02",      // 2023/12/25
-	"02-Jan-2006",     // 25-Dec-2023
-	"2006.01.02",      // 2023.12.25
-	"02.01.2006",      // 2023.12.25
-	"2.1.2006",        // 2023.12.25
-}
-
 func Today() time.Time {
 	return time.Now().Truncate(24 * time.Hour)
 }
 
+// TodayIn returns midnight of the current day as seen in the given location
+func TodayIn(location *time.Location) time.Time {
+	now := time.Now().In(location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+}
+
 func Tomorrow() time.Time {
 	return Today().Add(24 * time.Hour)
 }
 
-func ParseDate(dateStr string) (time.Time, error) {
-	dateStrLower := strings.ToLower(dateStr)
+// ParseTimeOfDay parses times like "15:30", "7", "7:05", "7am" or "7:30pm" into an hour and minute
+func ParseTimeOfDay(timeStr string) (int, int, error) {
+	timeStrLower := strings.ToLower(strings.TrimSpace(timeStr))
 
-	switch dateStrLower {
-	case "today":
-		return Today(), nil
-	case "tomorrow":
-		return Tomorrow(), nil
-	default:
-		for _, format := range DATE_FORMATS {
-			if t, err := time.Parse(format, dateStr); err == nil {
-				return t, nil
-			}
+	meridiem := ""
+	for _, suffix := range []string{"am", "pm"} {
+		if strings.HasSuffix(timeStrLower, suffix) {
+			meridiem = suffix
+			timeStrLower = strings.TrimSpace(strings.TrimSuffix(timeStrLower, suffix))
 		}
+	}
+
+	hourStr, minuteStr, hasMinutes := strings.Cut(timeStrLower, ":")
+	if !hasMinutes {
+		minuteStr = "0"
+	}
+
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to parse time: %s", timeStr)
+	}
+
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil || minute < 0 || minute > 59 || (hasMinutes && len(minuteStr) != 2) {
+		return 0, 0, fmt.Errorf("unable to parse time: %s", timeStr)
+	}
 
-		return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("unable to parse time: %s", timeStr)
+		}
+		hour = hour % 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	default:
+		if hour < 0 || hour > 23 {
+			return 0, 0, fmt.Errorf("unable to parse time: %s", timeStr)
+		}
 	}
+
+	return hour, minute, nil
+}
+
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two coordinates with the haversine formula
+func DistanceKm(latitude1 float64, longitude1 float64, latitude2 float64, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	deltaLatitude := toRadians(latitude2 - latitude1)
+	deltaLongitude := toRadians(longitude2 - longitude1)
+
+	a := math.Pow(math.Sin(deltaLatitude/2), 2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Pow(math.Sin(deltaLongitude/2), 2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// RoundCoordinate rounds a latitude or longitude to 6 decimal places (~10cm). Caches keyed by a spot's
+// coordinates use it so float noise in the stored coordinates doesn't produce cache misses.
+func RoundCoordinate(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
 }
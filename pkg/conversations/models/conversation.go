@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Conversation is the step a phone number is at in a guided flow. It's keyed by phone number rather than user
+// so flows can run before the user is saved.
+type Conversation struct {
+	PhoneNumber string `json:"phone_number"`
+	Flow        string `json:"flow"`
+	Step        string `json:"step"`
+	// Data holds the answers given so far
+	Data      map[string]string `json:"data"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"tidebot/pkg/conversations/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ConversationRepository interface {
+	// Get returns the conversation of the phone number, or nil if there is no unexpired one
+	Get(phoneNumber string) (*models.Conversation, error)
+	// Save starts or moves on the conversation of its phone number
+	Save(conversation models.Conversation) error
+	Delete(phoneNumber string) error
+	DeleteExpired() (int64, error)
+}
+
+type conversationRepositoryImpl struct {
+	db  *sql.DB
+	log echo.Logger
+}
+
+func NewConversationRepository(db *sql.DB, log echo.Logger) ConversationRepository {
+	return &conversationRepositoryImpl{db, log}
+}
+
+func (r *conversationRepositoryImpl) Get(phoneNumber string) (*models.Conversation, error) {
+	r.log.Debugf("Attempting to get conversation of %s", phoneNumber)
+
+	query := `
+		SELECT phone_number, flow, step, data, expires_at, updated_at
+		FROM conversations
+		WHERE phone_number = ? AND expires_at > ?`
+
+	var conversation models.Conversation
+	var data string
+	err := r.db.QueryRowContext(context.Background(), query, phoneNumber, time.Now().UTC()).Scan(
+		&conversation.PhoneNumber,
+		&conversation.Flow,
+		&conversation.Step,
+		&data,
+		&conversation.ExpiresAt,
+		&conversation.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(data), &conversation.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode data of conversation: %w", err)
+	}
+	if conversation.Data == nil {
+		conversation.Data = make(map[string]string)
+	}
+
+	return &conversation, nil
+}
+
+func (r *conversationRepositoryImpl) Save(conversation models.Conversation) error {
+	r.log.Debugf("Attempting to save conversation of %s: %s/%s, expires at %s", conversation.PhoneNumber, conversation.Flow, conversation.Step, conversation.ExpiresAt)
+
+	data, err := json.Marshal(conversation.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode data of conversation: %w", err)
+	}
+
+	query := `
+		INSERT INTO conversations (phone_number, flow, step, data, expires_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(phone_number) DO UPDATE SET
+			flow = excluded.flow,
+			step = excluded.step,
+			data = excluded.data,
+			expires_at = excluded.expires_at,
+			updated_at = excluded.updated_at`
+
+	_, err = r.db.ExecContext(
+		context.Background(),
+		query,
+		conversation.PhoneNumber,
+		conversation.Flow,
+		conversation.Step,
+		string(data),
+		conversation.ExpiresAt.UTC(),
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save conversation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *conversationRepositoryImpl) Delete(phoneNumber string) error {
+	r.log.Debugf("Attempting to delete conversation of %s", phoneNumber)
+
+	_, err := r.db.ExecContext(context.Background(), `DELETE FROM conversations WHERE phone_number = ?`, phoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to delete conversation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *conversationRepositoryImpl) DeleteExpired() (int64, error) {
+	r.log.Debugf("Attempting to delete expired conversations")
+
+	result, err := r.db.ExecContext(context.Background(), `DELETE FROM conversations WHERE expires_at <= ?`, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired conversations: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	r.log.Infof("Deleted %d expired conversations", rowsAffected)
+	return rowsAffected, nil
+}
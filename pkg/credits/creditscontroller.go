@@ -0,0 +1,47 @@
+package credits
+
+import (
+	"net/http"
+	"tidebot/pkg/credits/services"
+	"tidebot/pkg/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CreditsController struct {
+	creditBudgetService services.CreditBudgetService
+	apiKey              string
+	log                 echo.Logger
+}
+
+func NewCreditsController(creditBudgetService services.CreditBudgetService, apiKey string, log echo.Logger) *CreditsController {
+	return &CreditsController{
+		creditBudgetService: creditBudgetService,
+		apiKey:              apiKey,
+		log:                 log,
+	}
+}
+
+func (cc *CreditsController) RegisterRoutes(e *echo.Echo) {
+	adminGroup := e.Group("/admin")
+	adminGroup.Use(middleware.APIKey(cc.apiKey, cc.log))
+
+	adminGroup.GET("/credits/usage", cc.GetUsage)
+}
+
+func (cc *CreditsController) GetUsage(c echo.Context) error {
+	report, err := cc.creditBudgetService.GetUsageReport()
+	if err != nil {
+		cc.log.Errorf("Failed to get credit usage report: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to get credit usage",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"usage":  report,
+	})
+}
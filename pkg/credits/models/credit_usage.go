@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type CreditUsage struct {
+	ID          int       `json:"id"`
+	Credits     int       `json:"credits"`
+	TriggerKind string    `json:"trigger_kind"`
+	TriggerRef  string    `json:"trigger_ref"`
+	SpotSlug    string    `json:"spot_slug"`
+	Date        string    `json:"date"`
+	Days        int       `json:"days"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreditUsageWriteModel struct {
+	Credits     int    `json:"credits"`
+	TriggerKind string `json:"trigger_kind"`
+	TriggerRef  string `json:"trigger_ref"`
+	SpotSlug    string `json:"spot_slug"`
+	Date        string `json:"date"`
+	Days        int    `json:"days"`
+}
+
+// CreditUsageReport summarizes the credits spent in the current budget period
+type CreditUsageReport struct {
+	PeriodStart   time.Time      `json:"period_start"`
+	Budget        int            `json:"budget"`
+	Used          int            `json:"used"`
+	Remaining     int            `json:"remaining"`
+	Reserve       int            `json:"reserve"`
+	UsedByTrigger map[string]int `json:"used_by_trigger"`
+	Recent        []CreditUsage  `json:"recent"`
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"tidebot/pkg/credits/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CreditUsageRepository interface {
+	Save(writeModel models.CreditUsageWriteModel) error
+	SumSince(since time.Time) (int, error)
+	SumByTriggerKindSince(since time.Time) (map[string]int, error)
+	ListSince(since time.Time, limit int) ([]models.CreditUsage, error)
+}
+
+type creditUsageRepositoryImpl struct {
+	db  *sql.DB
+	log echo.Logger
+}
+
+func NewCreditUsageRepository(db *sql.DB, log echo.Logger) CreditUsageRepository {
+	return &creditUsageRepositoryImpl{db, log}
+}
+
+func (r *creditUsageRepositoryImpl) Save(writeModel models.CreditUsageWriteModel) error {
+	r.log.Debugf("Attempting to save credit usage: %+v", writeModel)
+
+	query := `
+		INSERT INTO credit_usage (credits, trigger_kind, trigger_ref, spot_slug, date, days, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(
+		context.Background(),
+		query,
+		writeModel.Credits,
+		writeModel.TriggerKind,
+		writeModel.TriggerRef,
+		writeModel.SpotSlug,
+		writeModel.Date,
+		writeModel.Days,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save credit usage: %w", err)
+	}
+
+	return nil
+}
+
+func (r *creditUsageRepositoryImpl) SumSince(since time.Time) (int, error) {
+	query := `SELECT COALESCE(SUM(credits), 0) FROM credit_usage WHERE created_at >= ?`
+
+	var sum int
+	err := r.db.QueryRowContext(context.Background(), query, since.UTC()).Scan(&sum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum credit usage: %w", err)
+	}
+
+	return sum, nil
+}
+
+func (r *creditUsageRepositoryImpl) SumByTriggerKindSince(since time.Time) (map[string]int, error) {
+	query := `SELECT trigger_kind, SUM(credits) FROM credit_usage WHERE created_at >= ? GROUP BY trigger_kind`
+
+	rows, err := r.db.QueryContext(context.Background(), query, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum credit usage by trigger: %w", err)
+	}
+	defer rows.Close()
+
+	sums := make(map[string]int)
+	for rows.Next() {
+		var kind string
+		var sum int
+		if err := rows.Scan(&kind, &sum); err != nil {
+			return nil, fmt.Errorf("failed to scan credit usage sum: %w", err)
+		}
+		sums[kind] = sum
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read credit usage sums: %w", err)
+	}
+
+	return sums, nil
+}
+
+func (r *creditUsageRepositoryImpl) ListSince(since time.Time, limit int) ([]models.CreditUsage, error) {
+	query := `
+		SELECT id, credits, trigger_kind, trigger_ref, spot_slug, date, days, created_at
+		FROM credit_usage
+		WHERE created_at >= ?
+		ORDER BY created_at DESC
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(context.Background(), query, since.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credit usage: %w", err)
+	}
+	defer rows.Close()
+
+	usages := []models.CreditUsage{}
+	for rows.Next() {
+		var usage models.CreditUsage
+		err := rows.Scan(
+			&usage.ID,
+			&usage.Credits,
+			&usage.TriggerKind,
+			&usage.TriggerRef,
+			&usage.SpotSlug,
+			&usage.Date,
+			&usage.Days,
+			&usage.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credit usage row: %w", err)
+		}
+		usages = append(usages, usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read credit usage rows: %w", err)
+	}
+
+	return usages, nil
+}
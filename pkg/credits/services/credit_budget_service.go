@@ -0,0 +1,127 @@
+package services
+
+import (
+	"fmt"
+	"tidebot/pkg/credits/models"
+	"tidebot/pkg/credits/repositories"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const recentUsageLimit = 50
+
+// CreditBudgetService enforces a monthly WorldTides credit budget. The last reservePercent
+// of the budget is kept for jobs and admin actions so the daily notifications keep working.
+type CreditBudgetService interface {
+	worldtides.CreditBudget
+	GetUsageReport() (models.CreditUsageReport, error)
+}
+
+type creditBudgetServiceImpl struct {
+	creditUsageRepository repositories.CreditUsageRepository
+	monthlyBudget         int
+	reservePercent        int
+	log                   echo.Logger
+}
+
+// NewCreditBudgetService creates the budget. A monthlyBudget of 0 disables enforcement but still records usage.
+func NewCreditBudgetService(creditUsageRepository repositories.CreditUsageRepository, monthlyBudget int, reservePercent int, log echo.Logger) CreditBudgetService {
+	return &creditBudgetServiceImpl{
+		creditUsageRepository: creditUsageRepository,
+		monthlyBudget:         monthlyBudget,
+		reservePercent:        reservePercent,
+		log:                   log,
+	}
+}
+
+func (s *creditBudgetServiceImpl) Allow(trigger worldtides.Trigger) error {
+	if s.monthlyBudget <= 0 {
+		return nil
+	}
+
+	used, err := s.creditUsageRepository.SumSince(currentPeriodStart())
+	if err != nil {
+		// Don't take the bot down because usage couldn't be read
+		s.log.Errorf("Failed to read credit usage, allowing request: %v", err)
+		return nil
+	}
+
+	remaining := s.monthlyBudget - used
+
+	if remaining <= 0 {
+		return fmt.Errorf("%w: %d of %d credits used", worldtides.ErrCreditBudgetExhausted, used, s.monthlyBudget)
+	}
+
+	if trigger.Kind == worldtides.TriggerUser && remaining <= s.reserve() {
+		return fmt.Errorf("%w: %d credits left are reserved for scheduled jobs", worldtides.ErrCreditBudgetExhausted, remaining)
+	}
+
+	return nil
+}
+
+func (s *creditBudgetServiceImpl) Record(trigger worldtides.Trigger, spot spotModels.Spot, date time.Time, days int, credits int) {
+	if credits <= 0 {
+		credits = estimateCredits(days)
+	}
+
+	err := s.creditUsageRepository.Save(models.CreditUsageWriteModel{
+		Credits:     credits,
+		TriggerKind: string(trigger.Kind),
+		TriggerRef:  trigger.Ref,
+		SpotSlug:    spot.Slug,
+		Date:        date.Format("2006-01-02"),
+		Days:        days,
+	})
+	if err != nil {
+		s.log.Errorf("Failed to record usage of %d credits by %s %s: %v", credits, trigger.Kind, trigger.Ref, err)
+		return
+	}
+
+	s.log.Infof("Recorded usage of %d WorldTides credits by %s %s for %s", credits, trigger.Kind, trigger.Ref, spot.Slug)
+}
+
+func (s *creditBudgetServiceImpl) GetUsageReport() (models.CreditUsageReport, error) {
+	periodStart := currentPeriodStart()
+
+	used, err := s.creditUsageRepository.SumSince(periodStart)
+	if err != nil {
+		return models.CreditUsageReport{}, fmt.Errorf("failed to get credit usage: %w", err)
+	}
+
+	usedByTrigger, err := s.creditUsageRepository.SumByTriggerKindSince(periodStart)
+	if err != nil {
+		return models.CreditUsageReport{}, fmt.Errorf("failed to get credit usage by trigger: %w", err)
+	}
+
+	recent, err := s.creditUsageRepository.ListSince(periodStart, recentUsageLimit)
+	if err != nil {
+		return models.CreditUsageReport{}, fmt.Errorf("failed to get recent credit usage: %w", err)
+	}
+
+	return models.CreditUsageReport{
+		PeriodStart:   periodStart,
+		Budget:        s.monthlyBudget,
+		Used:          used,
+		Remaining:     max(s.monthlyBudget-used, 0),
+		Reserve:       s.reserve(),
+		UsedByTrigger: usedByTrigger,
+		Recent:        recent,
+	}, nil
+}
+
+func (s *creditBudgetServiceImpl) reserve() int {
+	return s.monthlyBudget * s.reservePercent / 100
+}
+
+func currentPeriodStart() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// estimateCredits follows the WorldTides pricing of one credit per 7 days for each of heights and extremes
+func estimateCredits(days int) int {
+	return 2 * ((days + 6) / 7)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,13 +18,27 @@ const (
 )
 
 type EnvVars struct {
-	GoEnv              Environment
-	TursoDbUrl         string
-	TursoDbAuthToken   string
-	TwilioWhatsAppFrom string
-	WorldTidesApiKey   string
-	ApiKey             string
-	ServerPort         int
+	GoEnv                 Environment
+	TursoDbUrl            string
+	TursoDbAuthToken      string
+	TwilioWhatsAppFrom    string
+	WorldTidesApiKey      string
+	ApiKey                string
+	ServerPort            int
+	TideCacheTTL          time.Duration
+	MonthlyCreditBudget   int
+	CreditReservePercent  int
+	TideProviders         []string
+	HarmonicsDataDir      string
+	TideFixturesDir       string
+	PublicBaseURL         string
+	OpenMeteoForecastURL  string
+	OpenMeteoMarineURL    string
+	WeatherCacheTTL       time.Duration
+	OpenMeteoGeocodingURL string
+	StationsCacheTTL      time.Duration
+	AdminPhoneNumbers     []string
+	ConversationTTL       time.Duration
 }
 
 func ParseEnvironment(envStr string) (Environment, error) {
@@ -82,17 +98,121 @@ func (e Environment) LoadEnv() (EnvVars, error) {
 		serverPort = 8080
 	}
 
+	TIDE_CACHE_TTL_HOURS := os.Getenv("TIDE_CACHE_TTL_HOURS")
+	tideCacheTTLHours, err := strconv.Atoi(TIDE_CACHE_TTL_HOURS)
+	if err != nil || tideCacheTTLHours <= 0 {
+		tideCacheTTLHours = 7 * 24
+	}
+
+	WORLDTIDES_MONTHLY_CREDIT_BUDGET := os.Getenv("WORLDTIDES_MONTHLY_CREDIT_BUDGET")
+	monthlyCreditBudget, err := strconv.Atoi(WORLDTIDES_MONTHLY_CREDIT_BUDGET)
+	if err != nil {
+		monthlyCreditBudget = 0
+	}
+
+	WORLDTIDES_CREDIT_RESERVE_PERCENT := os.Getenv("WORLDTIDES_CREDIT_RESERVE_PERCENT")
+	creditReservePercent, err := strconv.Atoi(WORLDTIDES_CREDIT_RESERVE_PERCENT)
+	if err != nil || creditReservePercent < 0 || creditReservePercent > 100 {
+		creditReservePercent = 10
+	}
+
+	// Comma separated, in priority order. Supported: worldtides, noaa, fixtures, harmonic
+	TIDE_PROVIDERS := os.Getenv("TIDE_PROVIDERS")
+	if len(TIDE_PROVIDERS) == 0 {
+		TIDE_PROVIDERS = "worldtides,harmonic"
+	}
+
+	tideProviders := []string{}
+	for _, provider := range strings.Split(TIDE_PROVIDERS, ",") {
+		if provider = strings.TrimSpace(provider); provider != "" {
+			tideProviders = append(tideProviders, provider)
+		}
+	}
+
+	HARMONICS_DATA_DIR := os.Getenv("HARMONICS_DATA_DIR")
+	if len(HARMONICS_DATA_DIR) == 0 {
+		HARMONICS_DATA_DIR = "data/harmonics"
+	}
+
+	TIDE_FIXTURES_DIR := os.Getenv("TIDE_FIXTURES_DIR")
+	if len(TIDE_FIXTURES_DIR) == 0 {
+		TIDE_FIXTURES_DIR = "data/fixtures"
+	}
+
+	// Where Twilio can fetch the tide charts from, e.g. https://tidebot.example.com. Charts aren't sent when empty.
+	PUBLIC_BASE_URL := strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/")
+
+	OPEN_METEO_FORECAST_URL := os.Getenv("OPEN_METEO_FORECAST_URL")
+	if len(OPEN_METEO_FORECAST_URL) == 0 {
+		OPEN_METEO_FORECAST_URL = "https://api.open-meteo.com/v1/forecast"
+	}
+
+	OPEN_METEO_MARINE_URL := os.Getenv("OPEN_METEO_MARINE_URL")
+	if len(OPEN_METEO_MARINE_URL) == 0 {
+		OPEN_METEO_MARINE_URL = "https://marine-api.open-meteo.com/v1/marine"
+	}
+
+	// Forecasts change during the day, so they are kept much shorter than tide predictions
+	WEATHER_CACHE_TTL_HOURS := os.Getenv("WEATHER_CACHE_TTL_HOURS")
+	weatherCacheTTLHours, err := strconv.Atoi(WEATHER_CACHE_TTL_HOURS)
+	if err != nil || weatherCacheTTLHours <= 0 {
+		weatherCacheTTLHours = 3
+	}
+
+	OPEN_METEO_GEOCODING_URL := os.Getenv("OPEN_METEO_GEOCODING_URL")
+	if len(OPEN_METEO_GEOCODING_URL) == 0 {
+		OPEN_METEO_GEOCODING_URL = "https://geocoding-api.open-meteo.com/v1/search"
+	}
+
+	// Stations rarely move, so searches are kept for long
+	STATIONS_CACHE_TTL_DAYS := os.Getenv("STATIONS_CACHE_TTL_DAYS")
+	stationsCacheTTLDays, err := strconv.Atoi(STATIONS_CACHE_TTL_DAYS)
+	if err != nil || stationsCacheTTLDays <= 0 {
+		stationsCacheTTLDays = 30
+	}
+
+	// Comma separated phone numbers alerted when a job holds back reports with suspect tide data
+	ADMIN_PHONE_NUMBERS := os.Getenv("ADMIN_PHONE_NUMBERS")
+
+	adminPhoneNumbers := []string{}
+	for _, phoneNumber := range strings.Split(ADMIN_PHONE_NUMBERS, ",") {
+		if phoneNumber = strings.TrimSpace(phoneNumber); phoneNumber != "" {
+			adminPhoneNumbers = append(adminPhoneNumbers, phoneNumber)
+		}
+	}
+
+	// How long a guided flow, e.g. onboarding, waits for an answer
+	CONVERSATION_TTL_MINUTES := os.Getenv("CONVERSATION_TTL_MINUTES")
+	conversationTTLMinutes, err := strconv.Atoi(CONVERSATION_TTL_MINUTES)
+	if err != nil || conversationTTLMinutes <= 0 {
+		conversationTTLMinutes = 30
+	}
+
 	if len(missingEnvs) > 0 {
 		return EnvVars{}, fmt.Errorf("Failed to load env. Missing variables: %v", missingEnvs)
 	}
 
 	return EnvVars{
-		GoEnv:              e,
-		TursoDbUrl:         TURSO_DB_URL,
-		TursoDbAuthToken:   TURSO_DB_AUTH_TOKEN,
-		TwilioWhatsAppFrom: TWILIO_WHATSAPP_FROM,
-		WorldTidesApiKey:   WORLDTIDES_API_KEY,
-		ApiKey:             API_KEY,
-		ServerPort:         serverPort,
+		GoEnv:                 e,
+		TursoDbUrl:            TURSO_DB_URL,
+		TursoDbAuthToken:      TURSO_DB_AUTH_TOKEN,
+		TwilioWhatsAppFrom:    TWILIO_WHATSAPP_FROM,
+		WorldTidesApiKey:      WORLDTIDES_API_KEY,
+		ApiKey:                API_KEY,
+		ServerPort:            serverPort,
+		TideCacheTTL:          time.Duration(tideCacheTTLHours) * time.Hour,
+		MonthlyCreditBudget:   monthlyCreditBudget,
+		CreditReservePercent:  creditReservePercent,
+		TideProviders:         tideProviders,
+		HarmonicsDataDir:      HARMONICS_DATA_DIR,
+		TideFixturesDir:       TIDE_FIXTURES_DIR,
+		PublicBaseURL:         PUBLIC_BASE_URL,
+		OpenMeteoForecastURL:  OPEN_METEO_FORECAST_URL,
+		OpenMeteoMarineURL:    OPEN_METEO_MARINE_URL,
+		WeatherCacheTTL:       time.Duration(weatherCacheTTLHours) * time.Hour,
+		OpenMeteoGeocodingURL: OPEN_METEO_GEOCODING_URL,
+		StationsCacheTTL:      time.Duration(stationsCacheTTLDays) * 24 * time.Hour,
+		AdminPhoneNumbers:     adminPhoneNumbers,
+		ConversationTTL:       time.Duration(conversationTTLMinutes) * time.Minute,
 	}, nil
 }
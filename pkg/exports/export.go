@@ -0,0 +1,197 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"tidebot/pkg/common"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+func ParseFormat(name string) (Format, bool) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case FormatCSV:
+		return FormatCSV, true
+	case FormatJSON:
+		return FormatJSON, true
+	default:
+		return "", false
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatJSON {
+		return "application/json; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+const (
+	rowTypeHigh   = "High"
+	rowTypeLow    = "Low"
+	rowTypeHeight = "Height"
+
+	heightDecimals = 3
+)
+
+type ExportedSpot struct {
+	Slug      string  `json:"slug"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type ExportedExtreme struct {
+	Time   time.Time `json:"time"`
+	Type   string    `json:"type"`
+	Height float64   `json:"height"`
+}
+
+type ExportedHeight struct {
+	Time   time.Time `json:"time"`
+	Height float64   `json:"height"`
+}
+
+// Export is the tides of a spot for a range of local days, with times in the spot's timezone
+// and heights in the export's units
+type Export struct {
+	Spot     ExportedSpot `json:"spot"`
+	Timezone string       `json:"timezone"`
+	// From and To are the first and last local days, both included
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Datum     string               `json:"datum"`
+	Units     common.Units         `json:"units"`
+	Sources   []string             `json:"sources"`
+	Extremes  []ExportedExtreme    `json:"extremes"`
+	Heights   []ExportedHeight     `json:"heights,omitempty"`
+	Anomalies []worldtides.Anomaly `json:"anomalies,omitempty"`
+}
+
+// NewExport converts the merged tides of the days from from to to, both included, to the units
+func NewExport(spot spotModels.Spot, tides *worldtides.WorldTidesResponse, sources []string, from time.Time, to time.Time, units common.Units, withHeights bool) Export {
+	location := spot.Location()
+
+	datum := tides.ResponseDatum
+	if datum == "" {
+		datum = worldtides.SpotDatum(spot)
+	}
+
+	export := Export{
+		Spot: ExportedSpot{
+			Slug:      spot.Slug,
+			Name:      spot.Name,
+			Latitude:  spot.Latitude,
+			Longitude: spot.Longitude,
+		},
+		Timezone:  location.String(),
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Datum:     datum,
+		Units:     units,
+		Sources:   sources,
+		Extremes:  []ExportedExtreme{},
+		Anomalies: tides.Anomalies,
+	}
+
+	for _, extreme := range tides.Extremes {
+		export.Extremes = append(export.Extremes, ExportedExtreme{
+			Time:   extreme.Time().In(location),
+			Type:   extreme.Type,
+			Height: roundHeight(units.FromMeters(extreme.Height)),
+		})
+	}
+
+	if withHeights {
+		for _, height := range tides.Heights {
+			export.Heights = append(export.Heights, ExportedHeight{
+				Time:   height.Time().In(location),
+				Height: roundHeight(units.FromMeters(height.Height)),
+			})
+		}
+	}
+
+	return export
+}
+
+func roundHeight(height float64) float64 {
+	scale := math.Pow(10, heightDecimals)
+	return math.Round(height*scale) / scale
+}
+
+// Write writes the export in the format
+func (e Export) Write(w io.Writer, format Format) error {
+	if format == FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(e)
+	}
+
+	return e.writeCSV(w)
+}
+
+// writeCSV writes one row per extreme and height in time order, with the local date and time in
+// separate columns so spreadsheets can filter and sort on them
+func (e Export) writeCSV(w io.Writer) error {
+	type row struct {
+		at     time.Time
+		kind   string
+		height float64
+	}
+
+	rows := make([]row, 0, len(e.Extremes)+len(e.Heights))
+	for _, extreme := range e.Extremes {
+		kind := rowTypeLow
+		if extreme.Type == rowTypeHigh {
+			kind = rowTypeHigh
+		}
+		rows = append(rows, row{at: extreme.Time, kind: kind, height: extreme.Height})
+	}
+	for _, height := range e.Heights {
+		rows = append(rows, row{at: height.Time, kind: rowTypeHeight, height: height.Height})
+	}
+
+	// Extremes come before the height at the same time
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].at.Before(rows[j].at)
+	})
+
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{"spot", "datetime", "date", "time", "type", fmt.Sprintf("height_%s", e.Units.Suffix()), "datum"})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		err = cw.Write([]string{
+			e.Spot.Slug,
+			r.at.Format(time.RFC3339),
+			r.at.Format("2006-01-02"),
+			r.at.Format("15:04"),
+			r.kind,
+			strconv.FormatFloat(r.height, 'f', heightDecimals, 64),
+			e.Datum,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
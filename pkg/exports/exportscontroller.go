@@ -0,0 +1,106 @@
+package exports
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"tidebot/pkg/common"
+	"tidebot/pkg/middleware"
+	spotRepos "tidebot/pkg/spots/repositories"
+	"tidebot/pkg/worldtides"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ExportsController struct {
+	exportsService ExportsService
+	spotRepository spotRepos.SpotRepository
+	apiKey         string
+	log            echo.Logger
+}
+
+func NewExportsController(exportsService ExportsService, spotRepository spotRepos.SpotRepository, apiKey string, log echo.Logger) *ExportsController {
+	return &ExportsController{
+		exportsService: exportsService,
+		spotRepository: spotRepository,
+		apiKey:         apiKey,
+		log:            log,
+	}
+}
+
+func (ec *ExportsController) RegisterRoutes(e *echo.Echo) {
+	adminGroup := e.Group("/admin")
+	adminGroup.Use(middleware.APIKey(ec.apiKey, ec.log))
+
+	adminGroup.GET("/exports/tides/:file", ec.ExportTides)
+}
+
+// ExportTides serves /admin/exports/tides/<spot slug>.csv or .json with ?from=YYYY-MM-DD and ?to=YYYY-MM-DD,
+// optionally with ?units=feet, ?datum=LAT and ?heights=false
+func (ec *ExportsController) ExportTides(c echo.Context) error {
+	file := c.Param("file")
+	extension := path.Ext(file)
+
+	format, ok := ParseFormat(strings.TrimPrefix(extension, "."))
+	if !ok {
+		return c.String(http.StatusNotFound, "Unsupported export format, use .csv or .json")
+	}
+
+	spot, err := ec.spotRepository.GetBySlug(strings.TrimSuffix(file, extension))
+	if err != nil {
+		return c.String(http.StatusNotFound, "Unknown spot")
+	}
+
+	units := common.UnitsMeters
+	if unitsParam := c.QueryParam("units"); unitsParam != "" {
+		parsed, ok := common.ParseUnits(unitsParam)
+		if !ok {
+			return c.String(http.StatusBadRequest, "Invalid units, use meters or feet")
+		}
+		units = parsed
+	}
+
+	if datumParam := c.QueryParam("datum"); datumParam != "" {
+		datum, ok := worldtides.ParseDatum(datumParam)
+		if !ok {
+			return c.String(http.StatusBadRequest, fmt.Sprintf("Invalid datum, use one of %s", strings.Join(worldtides.Datums, ", ")))
+		}
+		spot = worldtides.WithDatum(spot, &datum)
+	}
+
+	withHeights := true
+	if heightsParam := c.QueryParam("heights"); heightsParam != "" {
+		withHeights, err = strconv.ParseBool(heightsParam)
+		if err != nil {
+			return c.String(http.StatusBadRequest, "Invalid heights, use true or false")
+		}
+	}
+
+	from, to, err := ParseRange(spot, c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	export, err := ec.exportsService.ExportTides(c.Request().Context(), spot, from, to, units, withHeights, worldtides.AdminTrigger("export"))
+	if errors.Is(err, ErrInvalidRange) {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		ec.log.Errorf("Failed to export tides of %s: %v", spot.Slug, err)
+		return c.String(http.StatusServiceUnavailable, "Tide data is not available")
+	}
+
+	var body bytes.Buffer
+	err = export.Write(&body, format)
+	if err != nil {
+		ec.log.Errorf("Failed to write tides export of %s: %v", spot.Slug, err)
+		return c.String(http.StatusInternalServerError, "Failed to write export")
+	}
+
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, Filename(export, format)))
+	return c.Blob(http.StatusOK, format.ContentType(), body.Bytes())
+}
@@ -0,0 +1,108 @@
+package exports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"tidebot/pkg/common"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// MaxDays covers a whole season, one year ahead at most
+	MaxDays = 366
+	// DefaultDays is exported when the range has no end
+	DefaultDays = 30
+
+	// chunkDays is how many days are asked of the tides providers at once, so a long range is fetched
+	// in a few requests while the days already cached are not paid for again
+	chunkDays = 7
+)
+
+// ErrInvalidRange is returned for ranges ending before they start or longer than MaxDays
+var ErrInvalidRange = errors.New("invalid export range")
+
+type ExportsService interface {
+	// ExportTides exports the tides of the spot from from to to, both local days included,
+	// in the spot's datum, which WithDatum can override, and in the units
+	ExportTides(ctx context.Context, spot spotModels.Spot, from time.Time, to time.Time, units common.Units, withHeights bool, trigger worldtides.Trigger) (Export, error)
+}
+
+type exportsServiceImpl struct {
+	tidesClient worldtides.WorldTidesClient
+	log         echo.Logger
+}
+
+func NewExportsService(tidesClient worldtides.WorldTidesClient, log echo.Logger) ExportsService {
+	return &exportsServiceImpl{
+		tidesClient: tidesClient,
+		log:         log,
+	}
+}
+
+func (s *exportsServiceImpl) ExportTides(ctx context.Context, spot spotModels.Spot, from time.Time, to time.Time, units common.Units, withHeights bool, trigger worldtides.Trigger) (Export, error) {
+	days := common.DaysBetween(from, to)
+	if days < 1 || days > MaxDays {
+		return Export{}, fmt.Errorf("%w: %s to %s, use up to %d days", ErrInvalidRange, from.Format("2006-01-02"), to.Format("2006-01-02"), MaxDays)
+	}
+
+	s.log.Infof("Exporting tides of %s from %s to %s in %s (%s)", spot.Slug, from.Format("2006-01-02"), to.Format("2006-01-02"), worldtides.SpotDatum(spot), units)
+
+	var responses []*worldtides.WorldTidesResponse
+	var sources []string
+
+	for offset := 0; offset < days; offset += chunkDays {
+		chunk := min(chunkDays, days-offset)
+		chunkFrom := from.AddDate(0, 0, offset)
+
+		chunkResponses, err := s.tidesClient.GetTidesRange(ctx, spot, chunkFrom, chunk, trigger)
+		if err != nil {
+			return Export{}, fmt.Errorf("failed to get tides of %s from %s: %w", spot.Slug, chunkFrom.Format("2006-01-02"), err)
+		}
+
+		for _, response := range chunkResponses {
+			if response.Source != "" && !slices.Contains(sources, response.Source) {
+				sources = append(sources, response.Source)
+			}
+		}
+		responses = append(responses, chunkResponses...)
+	}
+
+	return NewExport(spot, worldtides.Merge(responses), sources, from, to, units, withHeights), nil
+}
+
+// ParseRange parses the YYYY-MM-DD days of the range in the spot's timezone. An empty from is today
+// and an empty to ends the range DefaultDays later.
+func ParseRange(spot spotModels.Spot, fromParam string, toParam string) (time.Time, time.Time, error) {
+	location := spot.Location()
+
+	from := common.TodayIn(location)
+	if fromParam != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromParam, location)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid from %q, use YYYY-MM-DD", ErrInvalidRange, fromParam)
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, DefaultDays-1)
+	if toParam != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toParam, location)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid to %q, use YYYY-MM-DD", ErrInvalidRange, toParam)
+		}
+		to = parsed
+	}
+
+	return from, to, nil
+}
+
+// Filename names the export file, e.g. el-cotillo-tides-2025-06-01-2025-09-30.csv
+func Filename(export Export, format Format) string {
+	return fmt.Sprintf("%s-tides-%s-%s.%s", export.Spot.Slug, export.From, export.To, format)
+}
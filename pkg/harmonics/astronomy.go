@@ -0,0 +1,56 @@
+package harmonics
+
+import (
+	"math"
+	"time"
+)
+
+// j2000 is the reference epoch of the polynomial expressions below (2000-01-01 12:00 TT)
+var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// astronomicalArguments holds the mean longitudes (in degrees) that drive the tide generating force
+type astronomicalArguments struct {
+	tau float64 // mean lunar time
+	s   float64 // mean longitude of the moon
+	h   float64 // mean longitude of the sun
+	p   float64 // longitude of the lunar perigee
+	n   float64 // longitude of the moon's ascending node
+	p1  float64 // longitude of the solar perigee
+}
+
+func newAstronomicalArguments(t time.Time) astronomicalArguments {
+	t = t.UTC()
+	centuries := t.Sub(j2000).Hours() / (24 * 36525)
+
+	s := 218.3164477 + 481267.88123421*centuries
+	h := 280.46646 + 36000.76983*centuries
+	p := 83.3532465 + 4069.0137287*centuries
+	n := 125.04452 - 1934.136261*centuries
+	p1 := 282.94 + 1.7192*centuries
+
+	hoursUT := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600 + float64(t.Nanosecond())/3.6e12
+
+	// Hour angle of the mean sun, zero at noon
+	meanSolarTime := 15*hoursUT + 180
+
+	return astronomicalArguments{
+		tau: normalizeDegrees(meanSolarTime + h - s),
+		s:   normalizeDegrees(s),
+		h:   normalizeDegrees(h),
+		p:   normalizeDegrees(p),
+		n:   normalizeDegrees(n),
+		p1:  normalizeDegrees(p1),
+	}
+}
+
+func normalizeDegrees(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,82 @@
+package harmonics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Reference values computed from Schureman's formulas: V0 from T, s, h and p, and f and u from
+// the exact expressions in the inclination of the lunar orbit and its auxiliary angles, rather
+// than the series used by the package
+func TestEquilibriumArgumentsAndNodalFactors(t *testing.T) {
+	tests := []struct {
+		epoch       time.Time
+		constituent string
+		v0PlusU     float64
+		f           float64
+	}{
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "M2", 134.75, 1.0217},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "S2", 0.00, 1.0000},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "N2", 6.32, 1.0217},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "K2", 184.79, 0.8540},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "K1", 2.06, 0.9436},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "O1", 136.65, 0.9079},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "P1", 350.03, 1.0000},
+		{time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), "Q1", 8.22, 0.9079},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "M2", 188.12, 0.9640},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "S2", 180.00, 1.0000},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "N2", 281.52, 0.9640},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "K2", 356.55, 1.3097},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "K1", 268.37, 1.1110},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "O1", 280.38, 1.1798},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "P1", 270.06, 1.0000},
+		{time.Date(2024, time.June, 21, 6, 0, 0, 0, time.UTC), "Q1", 13.78, 1.1798},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.epoch.Format("2006-01-02T15:04")+"/"+tt.constituent, func(t *testing.T) {
+			args := newAstronomicalArguments(tt.epoch)
+			c := constituents[tt.constituent]
+			f, u := c.nodal(args.n)
+
+			v0PlusU := normalizeDegrees(c.argument(args) + u)
+			if diff := angleDifference(v0PlusU, tt.v0PlusU); diff > 0.5 {
+				t.Errorf("V0+u = %.2f, want %.2f", v0PlusU, tt.v0PlusU)
+			}
+			if math.Abs(f-tt.f) > 0.005 {
+				t.Errorf("f = %.4f, want %.4f", f, tt.f)
+			}
+		})
+	}
+}
+
+func TestAstronomicalArgumentsAtJ2000(t *testing.T) {
+	args := newAstronomicalArguments(j2000)
+
+	tests := []struct {
+		name  string
+		value float64
+		want  float64
+	}{
+		// At noon the hour angle of the mean sun is zero, so tau = h - s
+		{"tau", args.tau, 62.15},
+		{"s", args.s, 218.32},
+		{"h", args.h, 280.47},
+		{"p", args.p, 83.35},
+		{"n", args.n, 125.04},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := angleDifference(tt.value, tt.want); diff > 0.01 {
+				t.Errorf("%s = %.4f, want %.2f", tt.name, tt.value, tt.want)
+			}
+		})
+	}
+}
+
+func angleDifference(a float64, b float64) float64 {
+	diff := math.Mod(math.Abs(a-b), 360)
+	return math.Min(diff, 360-diff)
+}
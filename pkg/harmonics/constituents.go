@@ -0,0 +1,103 @@
+package harmonics
+
+import "math"
+
+// constituent describes a tidal constituent by its Doodson numbers applied to
+// (tau, s, h, p, N', p1), an extra phase in degrees and its nodal correction.
+// Phases follow Schureman, as tau is built on his hour angle T of the mean sun.
+type constituent struct {
+	doodson [6]int
+	phase   float64
+	nodal   func(n float64) (f float64, u float64)
+}
+
+// argument returns the equilibrium argument V (in degrees) of the constituent
+func (c constituent) argument(args astronomicalArguments) float64 {
+	angles := [6]float64{args.tau, args.s, args.h, args.p, -args.n, args.p1}
+
+	value := c.phase
+	for i, multiplier := range c.doodson {
+		value += float64(multiplier) * angles[i]
+	}
+
+	return normalizeDegrees(value)
+}
+
+// Nodal corrections after Schureman, as functions of the longitude of the moon's node in degrees
+func noNodalCorrection(float64) (float64, float64) {
+	return 1, 0
+}
+
+func nodalM2(n float64) (float64, float64) {
+	N := radians(n)
+	f := 1.0004 - 0.0373*math.Cos(N) + 0.0002*math.Cos(2*N)
+	u := -2.14 * math.Sin(N)
+	return f, u
+}
+
+func nodalK1(n float64) (float64, float64) {
+	N := radians(n)
+	f := 1.0060 + 0.1150*math.Cos(N) - 0.0088*math.Cos(2*N) + 0.0006*math.Cos(3*N)
+	u := -8.86*math.Sin(N) + 0.68*math.Sin(2*N) - 0.07*math.Sin(3*N)
+	return f, u
+}
+
+func nodalO1(n float64) (float64, float64) {
+	N := radians(n)
+	f := 1.0089 + 0.1871*math.Cos(N) - 0.0147*math.Cos(2*N) + 0.0014*math.Cos(3*N)
+	u := 10.80*math.Sin(N) - 1.34*math.Sin(2*N) + 0.19*math.Sin(3*N)
+	return f, u
+}
+
+func nodalK2(n float64) (float64, float64) {
+	N := radians(n)
+	f := 1.0241 + 0.2863*math.Cos(N) + 0.0083*math.Cos(2*N) - 0.0015*math.Cos(3*N)
+	u := -17.74*math.Sin(N) + 0.68*math.Sin(2*N) - 0.04*math.Sin(3*N)
+	return f, u
+}
+
+func nodalMf(n float64) (float64, float64) {
+	N := radians(n)
+	f := 1.043 + 0.414*math.Cos(N)
+	u := -23.74*math.Sin(N) + 2.68*math.Sin(2*N) - 0.38*math.Sin(3*N)
+	return f, u
+}
+
+func nodalMm(n float64) (float64, float64) {
+	return 1.000 - 0.130*math.Cos(radians(n)), 0
+}
+
+// compoundNodal builds the correction of a shallow water constituent from its parent, e.g. M4 = M2 x M2
+func compoundNodal(parent func(float64) (float64, float64), power int) func(float64) (float64, float64) {
+	return func(n float64) (float64, float64) {
+		f, u := parent(n)
+		return math.Pow(f, float64(power)), u * float64(power)
+	}
+}
+
+var constituents = map[string]constituent{
+	// Semidiurnal
+	"M2":  {doodson: [6]int{2, 0, 0, 0, 0, 0}, nodal: nodalM2},
+	"S2":  {doodson: [6]int{2, 2, -2, 0, 0, 0}, nodal: noNodalCorrection},
+	"N2":  {doodson: [6]int{2, -1, 0, 1, 0, 0}, nodal: nodalM2},
+	"K2":  {doodson: [6]int{2, 2, 0, 0, 0, 0}, nodal: nodalK2},
+	"2N2": {doodson: [6]int{2, -2, 0, 2, 0, 0}, nodal: nodalM2},
+	"MU2": {doodson: [6]int{2, -2, 2, 0, 0, 0}, nodal: nodalM2},
+	"NU2": {doodson: [6]int{2, -1, 2, -1, 0, 0}, nodal: nodalM2},
+	"L2":  {doodson: [6]int{2, 1, 0, -1, 0, 0}, phase: 180, nodal: nodalM2},
+	"T2":  {doodson: [6]int{2, 2, -3, 0, 0, 1}, nodal: noNodalCorrection},
+	// Diurnal
+	"K1": {doodson: [6]int{1, 1, 0, 0, 0, 0}, phase: -90, nodal: nodalK1},
+	"O1": {doodson: [6]int{1, -1, 0, 0, 0, 0}, phase: 90, nodal: nodalO1},
+	"P1": {doodson: [6]int{1, 1, -2, 0, 0, 0}, phase: 90, nodal: noNodalCorrection},
+	"Q1": {doodson: [6]int{1, -2, 0, 1, 0, 0}, phase: 90, nodal: nodalO1},
+	// Shallow water
+	"M4":  {doodson: [6]int{4, 0, 0, 0, 0, 0}, nodal: compoundNodal(nodalM2, 2)},
+	"MS4": {doodson: [6]int{4, 2, -2, 0, 0, 0}, nodal: nodalM2},
+	"M6":  {doodson: [6]int{6, 0, 0, 0, 0, 0}, nodal: compoundNodal(nodalM2, 3)},
+	// Long period
+	"MF":  {doodson: [6]int{0, 2, 0, 0, 0, 0}, nodal: nodalMf},
+	"MM":  {doodson: [6]int{0, 1, 0, -1, 0, 0}, nodal: nodalMm},
+	"SSA": {doodson: [6]int{0, 0, 2, 0, 0, 0}, nodal: noNodalCorrection},
+	"SA":  {doodson: [6]int{0, 0, 1, 0, 0, 0}, nodal: noNodalCorrection},
+}
@@ -0,0 +1,95 @@
+package harmonics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// Atlas is reported in the responses so the origin of the data is visible
+	Atlas = "TideBot harmonic model"
+
+	heightsStep = 30 * time.Minute
+)
+
+type harmonicTidesClientImpl struct {
+	dataDir string
+	log     echo.Logger
+	mu      sync.Mutex
+	models  map[string]*Model
+}
+
+// NewHarmonicTidesClient predicts tides offline from the harmonic constants
+// stored in <dataDir>/<spot slug>.json
+func NewHarmonicTidesClient(dataDir string, log echo.Logger) worldtides.WorldTidesClient {
+	return &harmonicTidesClientImpl{
+		dataDir: dataDir,
+		log:     log,
+		models:  make(map[string]*Model),
+	}
+}
+
+func (c *harmonicTidesClientImpl) GetTides(ctx context.Context, spot models.Spot, date time.Time, trigger worldtides.Trigger) (*worldtides.WorldTidesResponse, error) {
+	// The constants only describe the oscillation around the mean level
+	if datum := worldtides.SpotDatum(spot); datum != worldtides.DatumMLS {
+		return nil, fmt.Errorf("harmonic model has no %s datum: %w", datum, worldtides.ErrNotSupported)
+	}
+
+	c.log.Debugf("Computing harmonic tides for spot %s and date: %s", spot.Slug, date.Format("2006-01-02"))
+
+	model, err := c.loadModel(spot)
+	if err != nil {
+		return nil, err
+	}
+
+	location := spot.Location()
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	to := from.AddDate(0, 0, 1)
+
+	return &worldtides.WorldTidesResponse{
+		Status:        200,
+		RequestDatum:  worldtides.DefaultDatum,
+		ResponseDatum: worldtides.DefaultDatum,
+		RequestLat:    spot.Latitude,
+		RequestLon:    spot.Longitude,
+		ResponseLat:   spot.Latitude,
+		ResponseLon:   spot.Longitude,
+		Atlas:         Atlas,
+		Timezone:      location.String(),
+		Heights:       model.Heights(from, to, heightsStep),
+		Extremes:      model.Extremes(from, to),
+	}, nil
+}
+
+func (c *harmonicTidesClientImpl) GetTidesRange(ctx context.Context, spot models.Spot, from time.Time, days int, trigger worldtides.Trigger) ([]*worldtides.WorldTidesResponse, error) {
+	return worldtides.GetTidesDayByDay(ctx, c, spot, from, days, trigger)
+}
+
+func (c *harmonicTidesClientImpl) loadModel(spot models.Spot) (*Model, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if model, exists := c.models[spot.Slug]; exists {
+		return model, nil
+	}
+
+	model, err := LoadModel(filepath.Join(c.dataDir, fmt.Sprintf("%s.json", spot.Slug)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no harmonic model for spot %s: %w", spot.Slug, worldtides.ErrNotSupported)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no harmonic model for spot %s: %w", spot.Slug, err)
+	}
+
+	c.models[spot.Slug] = model
+	return model, nil
+}
@@ -0,0 +1,154 @@
+package harmonics
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// referenceModel is a mixed tide station with a strong diurnal inequality, so a diurnal phase error
+// moves the high and low waters by hours
+const referenceModel = `{
+	"z0": 0,
+	"constituents": [
+		{"name": "M2", "amplitude": 0.58, "phase": 213},
+		{"name": "S2", "amplitude": 0.13, "phase": 220},
+		{"name": "N2", "amplitude": 0.12, "phase": 190},
+		{"name": "K1", "amplitude": 0.37, "phase": 106},
+		{"name": "O1", "amplitude": 0.23, "phase": 90},
+		{"name": "P1", "amplitude": 0.11, "phase": 104},
+		{"name": "Q1", "amplitude": 0.04, "phase": 84}
+	]
+}`
+
+func newReferenceClient(t *testing.T) worldtides.WorldTidesClient {
+	t.Helper()
+
+	dataDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dataDir, "reference.json"), []byte(referenceModel), 0o644)
+	if err != nil {
+		t.Fatalf("failed to write harmonics file: %v", err)
+	}
+
+	return NewHarmonicTidesClient(dataDir, echo.New().Logger)
+}
+
+// Expected values are a direct summation of Schureman's V0+u and f for the constants above
+func TestGetTidesMatchesReferencePredictions(t *testing.T) {
+	client := newReferenceClient(t)
+	spot := models.Spot{Slug: "reference", Timezone: "UTC"}
+	date := time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC)
+
+	response, err := client.GetTides(context.Background(), spot, date, worldtides.AdminTrigger("test"))
+	if err != nil {
+		t.Fatalf("GetTides() error = %v", err)
+	}
+
+	if len(response.Heights) != 48 {
+		t.Fatalf("got %d heights, want 48", len(response.Heights))
+	}
+
+	heights := []struct {
+		at   string
+		want float64
+	}{
+		{"00:00", -0.574},
+		{"03:00", -0.670},
+		{"06:00", -0.146},
+		{"09:00", -0.355},
+		{"12:00", -0.679},
+		{"15:00", 0.189},
+		{"18:00", 1.350},
+		{"21:00", 0.973},
+	}
+
+	for _, tt := range heights {
+		t.Run("height at "+tt.at, func(t *testing.T) {
+			at := mustParseTime(t, date, tt.at)
+			for _, height := range response.Heights {
+				if height.Dt != at.Unix() {
+					continue
+				}
+				if math.Abs(height.Height-tt.want) > 0.02 {
+					t.Errorf("height = %.3f, want %.3f", height.Height, tt.want)
+				}
+				return
+			}
+			t.Errorf("no height at %s", tt.at)
+		})
+	}
+
+	extremes := []struct {
+		at          string
+		extremeType string
+		height      float64
+	}{
+		{"01:38", "Low", -0.793},
+		{"06:46", "High", -0.111},
+		{"11:36", "Low", -0.691},
+		{"18:53", "High", 1.436},
+	}
+
+	if len(response.Extremes) != len(extremes) {
+		t.Fatalf("got %d extremes, want %d", len(response.Extremes), len(extremes))
+	}
+
+	for i, tt := range extremes {
+		t.Run("extreme at "+tt.at, func(t *testing.T) {
+			extreme := response.Extremes[i]
+			at := mustParseTime(t, date, tt.at)
+
+			if extreme.Type != tt.extremeType {
+				t.Errorf("type = %s, want %s", extreme.Type, tt.extremeType)
+			}
+			if diff := time.Unix(extreme.Dt, 0).Sub(at).Abs(); diff > 10*time.Minute {
+				t.Errorf("time = %s, want %s", time.Unix(extreme.Dt, 0).UTC().Format("15:04"), tt.at)
+			}
+			if math.Abs(extreme.Height-tt.height) > 0.02 {
+				t.Errorf("height = %.3f, want %.3f", extreme.Height, tt.height)
+			}
+		})
+	}
+}
+
+func TestGetTidesErrors(t *testing.T) {
+	client := newReferenceClient(t)
+	date := time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC)
+	lat := worldtides.DatumLAT
+
+	tests := []struct {
+		name string
+		spot models.Spot
+	}{
+		{"unsupported datum", models.Spot{Slug: "reference", Timezone: "UTC", Datum: &lat}},
+		{"missing model", models.Spot{Slug: "unknown", Timezone: "UTC"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetTides(context.Background(), tt.spot, date, worldtides.AdminTrigger("test"))
+			if !errors.Is(err, worldtides.ErrNotSupported) {
+				t.Errorf("GetTides() error = %v, want %v", err, worldtides.ErrNotSupported)
+			}
+		})
+	}
+}
+
+func mustParseTime(t *testing.T, date time.Time, clock string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		t.Fatalf("invalid time %s: %v", clock, err)
+	}
+
+	return date.Add(time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute)
+}
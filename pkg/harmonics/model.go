@@ -0,0 +1,153 @@
+package harmonics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+// ConstituentParams are the harmonic constants of one constituent at a location
+type ConstituentParams struct {
+	Name      string  `json:"name"`
+	Amplitude float64 `json:"amplitude"` // meters
+	Phase     float64 `json:"phase"`     // Greenwich phase lag in degrees (UTC)
+}
+
+// Model predicts the tide at a single location from its harmonic constants
+type Model struct {
+	Z0           float64             `json:"z0"` // mean water level above the datum in meters
+	Constituents []ConstituentParams `json:"constituents"`
+}
+
+// LoadModel reads a model from a JSON data file
+func LoadModel(path string) (*Model, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read harmonics file %s: %w", path, err)
+	}
+
+	var model Model
+	err = json.Unmarshal(body, &model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal harmonics file %s: %w", path, err)
+	}
+
+	err = model.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid harmonics file %s: %w", path, err)
+	}
+
+	return &model, nil
+}
+
+func (m *Model) validate() error {
+	if len(m.Constituents) == 0 {
+		return fmt.Errorf("no constituents defined")
+	}
+
+	for i := range m.Constituents {
+		name := strings.ToUpper(m.Constituents[i].Name)
+		if _, exists := constituents[name]; !exists {
+			return fmt.Errorf("unknown constituent %s", m.Constituents[i].Name)
+		}
+		m.Constituents[i].Name = name
+	}
+
+	return nil
+}
+
+// Height returns the predicted water level at t
+func (m *Model) Height(t time.Time) float64 {
+	args := newAstronomicalArguments(t)
+
+	height := m.Z0
+	for _, params := range m.Constituents {
+		c := constituents[params.Name]
+		f, u := c.nodal(args.n)
+		height += f * params.Amplitude * math.Cos(radians(c.argument(args)+u-params.Phase))
+	}
+
+	return height
+}
+
+// Heights samples the water level every step in [from, to)
+func (m *Model) Heights(from time.Time, to time.Time, step time.Duration) []worldtides.Height {
+	var heights []worldtides.Height
+
+	for t := from; t.Before(to); t = t.Add(step) {
+		heights = append(heights, worldtides.Height{
+			Dt:     t.Unix(),
+			Date:   formatDate(t),
+			Height: m.Height(t),
+		})
+	}
+
+	return heights
+}
+
+const (
+	extremeSearchStep   = 6 * time.Minute
+	derivativeHalfDelta = 30 * time.Second
+)
+
+// Extremes returns the high and low waters in [from, to)
+func (m *Model) Extremes(from time.Time, to time.Time) []worldtides.Extreme {
+	var extremes []worldtides.Extreme
+
+	previousTime := from
+	previousSlope := m.slope(previousTime)
+
+	for t := from.Add(extremeSearchStep); !t.After(to.Add(extremeSearchStep)); t = t.Add(extremeSearchStep) {
+		slope := m.slope(t)
+
+		if (previousSlope > 0) != (slope > 0) {
+			extremeTime := m.refineExtreme(previousTime, t, previousSlope > 0)
+
+			if !extremeTime.Before(from) && extremeTime.Before(to) {
+				extremeType := "Low"
+				if previousSlope > 0 {
+					extremeType = "High"
+				}
+
+				extremes = append(extremes, worldtides.Extreme{
+					Dt:     extremeTime.Unix(),
+					Date:   formatDate(extremeTime),
+					Height: m.Height(extremeTime),
+					Type:   extremeType,
+				})
+			}
+		}
+
+		previousTime = t
+		previousSlope = slope
+	}
+
+	return extremes
+}
+
+func (m *Model) slope(t time.Time) float64 {
+	return m.Height(t.Add(derivativeHalfDelta)) - m.Height(t.Add(-derivativeHalfDelta))
+}
+
+// refineExtreme bisects the interval in which the slope changes sign down to one second
+func (m *Model) refineExtreme(from time.Time, to time.Time, rising bool) time.Time {
+	for to.Sub(from) > time.Second {
+		middle := from.Add(to.Sub(from) / 2)
+
+		if (m.slope(middle) > 0) == rising {
+			from = middle
+		} else {
+			to = middle
+		}
+	}
+
+	return from.Add(to.Sub(from) / 2).Truncate(time.Second)
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02T15:04-0700")
+}
@@ -0,0 +1,275 @@
+package i18n
+
+var germanCatalog = catalog{
+	weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	shortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+
+	plurals: map[string]Plural{
+		"month.title":        {Other: "🌙 *Gezeitenzyklus der nächsten %d Tage*"},
+		"best.title":         {One: "🏄 *Beste Sessions heute*", Other: "🏄 *Beste Sessions der nächsten %d Tage*"},
+		"stations.more":      {Other: "…und %d weitere"},
+		"date.too_many_days": {One: "das ist mehr als %d Tag", Other: "das sind mehr als %d Tage"},
+	},
+
+	messages: map[string]string{
+		"language.name": "Deutsch",
+
+		"layout.date":          "02.01.2006",
+		"layout.day":           "Monday, 02.01.2006",
+		"layout.short_day":     "Mon 02.01.",
+		"layout.date_time":     "02.01.2006 15:04",
+		"layout.time_day":      "15:04, Monday",
+		"layout.time_day_date": "15:04, Monday, 02.01.2006",
+
+		"error.generic":         "❌ Entschuldigung, es ist ein Fehler aufgetreten. Bitte versuche es später noch einmal.",
+		"error.budget":          "⏳ Entschuldigung, das Gezeitendatenkontingent dieses Monats ist aufgebraucht. Bitte versuche es später noch einmal.",
+		"error.budget_day":      "⏳ Entschuldigung, das Gezeitendatenkontingent dieses Monats ist aufgebraucht, ich kann nur bereits abgefragte Tage zeigen. Für den %s gibt es noch keine Daten.",
+		"error.tides":           "❌ Entschuldigung, ich konnte keine Gezeitendaten abrufen. Bitte versuche es später noch einmal.",
+		"error.tides_day":       "❌ Entschuldigung, ich konnte die Gezeitendaten für den %s nicht abrufen. Bitte versuche es später noch einmal.",
+		"error.heights_at":      "❌ Entschuldigung, ich habe keine Wasserstände für %s.",
+		"error.heights_now":     "❌ Entschuldigung, ich habe gerade keine Wasserstände.",
+		"error.conditions":      "❌ Entschuldigung, ich konnte die Bedingungen für den %s nicht abrufen. Bitte versuche es später noch einmal.",
+		"error.stations":        "❌ Entschuldigung, ich konnte keine Gezeitenstationen suchen. Bitte versuche es später noch einmal.",
+		"error.notifications":   "❌ Entschuldigung, beim Aktivieren der Benachrichtigungen ist ein Fehler aufgetreten. Bitte versuche es später noch einmal.",
+		"error.time":            "❓ Entschuldigung, die Uhrzeit \"%s\" verstehe ich nicht. Versuche z. B. _gezeiten um 15:30_ oder _gezeiten morgen um 7_.",
+		"error.level":           "❓ Bitte nenne mir den Wasserstand in %s, z. B. _unter 0.5 morgen_ oder _über 1 samstag_.",
+		"error.best_days":       "❓ Bitte gib eine Anzahl Tage von 1 bis %d an, z. B. _beste 3_",
+		"error.date":            "❓ Entschuldigung, \"%s\" verstehe ich nicht, %s.\n\nVersuche z. B. _gezeiten samstag_, _gezeiten nächsten montag_, _gezeiten in 3 tagen_, _gezeiten wochenende_, _gezeiten montag-freitag_ oder _gezeiten 24.12.-28.12._ Sende *spots* für die Namen der Spots.",
+		"error.unknown_spot":    "🤷‍♂️ Den Spot *%s* kenne ich nicht.\n\nSende *spots*, um alle verfügbaren Spots zu sehen.",
+		"error.unknown_place":   "🤷 Ich konnte *%s* nicht finden. Versuche einen Ortsnamen, Koordinaten wie _36.01,-5.60_ oder teile einen Standort.",
+		"error.unknown_command": "🤔 Den Befehl *%s* gibt es nicht.\n%s",
+
+		"date.not_a_date":           "das ist kein Datum",
+		"date.no_such_day":          "diesen Tag gibt es nicht",
+		"date.not_a_number_of_days": "das ist keine Anzahl Tage",
+		"date.not_a_single_day":     "das ist nicht ein einzelner Tag",
+		"date.ends_before_start":    "der Zeitraum endet, bevor er beginnt",
+
+		"tide.high":      "Hochwasser",
+		"tide.low":       "Niedrigwasser",
+		"tide.next_high": "Nächstes Hochwasser",
+		"tide.next_low":  "Nächstes Niedrigwasser",
+		"tide.rising":    "💧 *Wasserstand*: %s, ⬆️ steigend",
+		"tide.falling":   "💧 *Wasserstand*: %s, ⬇️ fallend",
+		"tide.in":        "in %s",
+		"tide.source":    "Quelle: %s",
+		"tide.datum":     "Höhen bezogen auf %s",
+
+		"light.twilight":   "Dämmerung",
+		"light.dark":       "dunkel",
+		"daylight.sun":     "🌅 *Sonnenaufgang*: %s  🌇 *Sonnenuntergang*: %s",
+		"daylight.light":   "🌗 *Erstes Licht*: %s  *Letztes Licht*: %s",
+		"daylight.no_set":  "☀️ Die Sonne geht heute nicht unter",
+		"daylight.no_rise": "🌙 Die Sonne geht heute nicht auf",
+
+		"cycle.spring":       "Springtide",
+		"cycle.neap":         "Nipptide",
+		"cycle.intermediate": "mittlere Tide",
+		"cycle.coefficient":  "Koeffizient %d",
+
+		"moon.new":             "Neumond",
+		"moon.waxing_crescent": "Zunehmende Sichel",
+		"moon.first_quarter":   "Erstes Viertel",
+		"moon.waxing_gibbous":  "Zunehmender Mond",
+		"moon.full":            "Vollmond",
+		"moon.waning_gibbous":  "Abnehmender Mond",
+		"moon.last_quarter":    "Letztes Viertel",
+		"moon.waning_crescent": "Abnehmende Sichel",
+
+		"tides.title":        "🌊 *Gezeiten am %s*",
+		"tides.none":         "Für heute gibt es keine Gezeitendaten.",
+		"tides.chart":        "📈 Gezeitenkurve am %s",
+		"tides.at":           "🌊 *Gezeiten um %s*",
+		"now.title":          "🌊 *Gezeiten jetzt* (%s)",
+		"now.rate":           "%s/h",
+		"now.tomorrow":       "morgen",
+		"now.cycle":          "🔄 *Tidenzyklus*: %d%% vorbei, Niedrigwasser %s → %s",
+		"window.below":       "🌊 *Wasser unter %s am %s*",
+		"window.above":       "🌊 *Wasser über %s am %s*",
+		"window.never_below": "Das Wasser fällt an diesem Tag nicht unter %s.",
+		"window.never_above": "Das Wasser steigt an diesem Tag nicht über %s.",
+
+		"conditions.title":       "🏄 *Bedingungen am %s*",
+		"conditions.water":       "🌡️ *Wasser*: %.1f°C",
+		"conditions.gusts":       "Böen %.0fkn",
+		"conditions.no_data":     "keine Daten",
+		"conditions.no_forecast": "Keine Wind- und Dünungsvorhersage verfügbar.",
+		"conditions.no_tides":    "Keine Gezeitendaten verfügbar.",
+
+		"best.no_preferences": "🤷 Für %s gibt es noch keine Session-Vorlieben, daher kann ich nicht sagen, wann es am besten ist.",
+		"best.none":           "Nichts sieht gut aus, Gezeiten, Wind und Tageslicht passen nie zusammen.",
+		"best.without_wind":   "_Für manche Tage gibt es keine Windvorhersage, sie werden nur nach den Gezeiten bewertet._",
+		"best.today":          "🏄 Beste Session heute: %s",
+		"session.low":         "Niedrigwasser",
+		"session.mid":         "Halbtide",
+		"session.high":        "Hochwasser",
+		"session.rising":      "steigend",
+		"session.falling":     "fallend",
+
+		"spots.title":    "📍 *Verfügbare Spots:*",
+		"spots.examples": "Beispiele: _gezeiten %s morgen_, _starten %s_",
+
+		"stations.none":      "📡 Keine Gezeitenstationen im Umkreis von %dkm um *%s*. Die Gezeiten dort kommen aus dem globalen Gezeitenmodell.\n\nVersuche einen größeren Radius, z. B. _stationen bei %s 200km_",
+		"stations.title":     "📡 *Gezeitenstationen im Umkreis von %dkm um %s*",
+		"stations.footer":    "Spots, die an eine dieser Stationen gebunden sind, erhalten deren Vorhersagen statt des globalen Modells.",
+		"stations.ask_place": "📡 Teile einen Standort, oder sende den Namen oder die Koordinaten eines Ortes, um die Gezeitenstationen in der Nähe zu sehen.\n\nSende *abbrechen*, um aufzuhören.",
+
+		"calendar.unavailable": "📅 Entschuldigung, Kalender sind auf diesem Server nicht verfügbar.",
+		"calendar.message":     "📅 *Dein Gezeitenkalender*\n\nAbonniere diesen Link in Google Kalender (_Weitere Kalender_ › _Per URL_), Apple Kalender oder Outlook, um die Gezeiten deines Spots für die nächste Woche zu bekommen, in deinen Einheiten und deinem Bezugsniveau:\n%s\n\nSonnenauf- und -untergang fügst du hinzu mit:\n%s?sun=true\n\nBehalte den Link für dich, er ist persönlich.",
+
+		"start.message": "🔔 *Benachrichtigungen aktiviert!*\n\nDu bekommst ab jetzt jeden Morgen den Gezeitenbericht für *%s*.\n\n📱 Sende jederzeit *gezeiten* für die aktuellen Gezeiten\n📍 Sende *spots*, um andere Spots zu sehen\n🔕 Sende *stopp*, um die Benachrichtigungen zu deaktivieren\n\nWillkommen an Bord! 🌊",
+		"stop.none":     "🤷‍♂️ Du hast keine aktiven Benachrichtigungen.\n\nSende *starten*, um Gezeitenbenachrichtigungen zu aktivieren!",
+		"stop.message":  "🔕 *Benachrichtigungen deaktiviert*\n\nDu bekommst keine täglichen Gezeitenberichte mehr.\n\n📱 Sende jederzeit *gezeiten* für die aktuellen Gezeiten\n🔔 Sende *starten*, um sie wieder zu aktivieren\n\nDanke, dass du TideBot nutzt! 🌊",
+
+		"flow.unexpected":        "🤔 Entschuldigung, das habe ich nicht verstanden.",
+		"flow.cancelled":         "👍 Abgebrochen. Sende *hilfe*, um zu sehen, was ich kann.",
+		"flow.nothing_to_cancel": "🤷 Es gibt nichts abzubrechen.",
+
+		"setup.spot":     "🧭 *Einrichtung 1/3*\n\nFür welchen Spot möchtest du den täglichen Gezeitenbericht? Antworte mit seiner Nummer oder seinem Namen:\n\n%s\nSende jederzeit *abbrechen*, um aufzuhören.",
+		"setup.hour":     "🧭 *Einrichtung 2/3*\n\nUm wie viel Uhr soll der Bericht für *%s* kommen? Antworte mit einer Uhrzeit des Spots (%s), z. B. _7_ oder _18:00_.",
+		"setup.language": "🧭 *Einrichtung 3/3*\n\nIn welcher Sprache soll ich mit dir sprechen? Antworte mit einer von:\n\n%s\n_auto_ - die Sprache deines Landes",
+		"setup.done":     "✅ *Alles bereit!*\n\nDu bekommst den Gezeitenbericht für *%s* jeden Tag um *%02d:00* Uhr, auf %s.\n\nSende *hilfe*, um alles zu sehen, was ich kann. 🌊",
+
+		"delete.none":    "🤷 Ich habe keine Daten von dir.",
+		"delete.confirm": "⚠️ Das löscht dein Profil, deine Benachrichtigungen, Einstellungen und deinen Kalender.\n\nAntworte *JA*, um deine Daten zu löschen, alles andere behält sie.",
+		"delete.done":    "🗑️ *Deine Daten wurden gelöscht.*\n\nSende eine beliebige Nachricht, wenn du neu anfangen möchtest.",
+		"delete.kept":    "👍 Es wurde nichts gelöscht.",
+
+		"location.no_spots": "📍 Entschuldigung, es gibt noch keine Spots, mit denen ich deinen Standort vergleichen kann.",
+		"location.nearest":  "📍 Der nächste Spot ist *%s*, %.1fkm entfernt.",
+		"location.too_far":  "📍 Es gibt keine Spots im Umkreis von %dkm um deinen Standort. Sende *stationen bei* und teile ihn noch einmal, um die Gezeitenstationen in der Nähe zu finden.",
+
+		"subscribe.offer":        "🔔 Antworte *JA*, um den täglichen Gezeitenbericht für *%s* zu bekommen.",
+		"subscribe.offer_switch": "🔔 Antworte *JA*, um den täglichen Gezeitenbericht für *%s* statt für *%s* zu bekommen.",
+		"subscribe.declined":     "👍 Kein Problem. Sende _starten %s_, wenn du es dir anders überlegst.",
+
+		"welcome.hi":       "Hallo!",
+		"welcome.hi_name":  "Hallo %s!",
+		"welcome.new_user": " Willkommen bei TideBot!",
+		"welcome.any_spot": "Fuerteventura und mehr",
+		"welcome.message":  "🌊 *%s%s*\n\nGezeitenberichte für *%s*.\n\nDeine Gezeitenberichte enthalten die Zeiten von Hoch- und Niedrigwasser mit genauen Höhen 🏄‍♂️\n\n%s\n",
+
+		"daily.hi":       "Hallo %s!",
+		"daily.intro":    "Hier ist dein täglicher Gezeitenbericht:",
+		"daily.high":     "Hochwasser",
+		"daily.low":      "Niedrigwasser",
+		"daily.tide":     "%s: %s",
+		"daily.next_day": "+1 Tag",
+		"daily.location": "Ort: %s",
+		"daily.footer":   "Wenn du diese Benachrichtigungen nicht mehr bekommen möchtest, antworte mit 'stopp'. Einen schönen Tag auf dem Wasser!",
+
+		"units.meters":  "Meter",
+		"units.feet":    "Fuß",
+		"units.current": "📏 Höhen werden in *%s* angezeigt. Sende _einheiten meter_ oder _einheiten fuß_, um das zu ändern.",
+		"units.invalid": "❓ Bitte wähle _einheiten meter_ oder _einheiten fuß_.",
+		"units.updated": "✅ Höhen werden in *%s* angezeigt.",
+
+		"datum.spot":         "das Bezugsniveau des Spots",
+		"datum.current":      "📐 Höhen beziehen sich auf *%s*. Sende _bezug_ mit einem von %s, oder _bezug spot_ für das des Spots.\n\n%s",
+		"datum.invalid":      "❓ Bitte wähle eines von %s, oder _bezug spot_.",
+		"datum.updated_spot": "✅ Höhen beziehen sich auf das Bezugsniveau des jeweiligen Spots.",
+		"datum.updated":      "✅ Höhen beziehen sich auf *%s*.",
+		"datum.help":         "*MLS* - mittlerer Meeresspiegel, die Voreinstellung\n*LAT* - niedrigstes astronomisches Niedrigwasser, Höhen sind selten negativ\n*CD* - Seekartennull der örtlichen Seekarten\n*MLLW* - mittleres niedrigeres Niedrigwasser, auf US-Karten verwendet",
+
+		"lang.current":      "🌐 Ich spreche *%s* mit dir. Sende _sprache_ mit einem von %s, um das zu ändern, oder _sprache auto_ für die Sprache deines Landes.",
+		"lang.invalid":      "❓ Bitte wähle eines von %s, oder _sprache auto_.",
+		"lang.updated":      "✅ Ich spreche ab jetzt *%s* mit dir.",
+		"lang.updated_auto": "✅ Ich spreche die Sprache deines Landes mit dir, *%s*.",
+
+		"help.title":    "*Verfügbare Befehle:*",
+		"help.line":     "%s Sende *%s* - %s",
+		"help.examples": "Beispiele: %s",
+		"help.aliases":  "Auch: %s",
+		"help.more":     "❓ Sende *hilfe* und einen Befehl für mehr, z. B. _hilfe gezeiten_",
+
+		"command.tides.keyword":  "gezeiten",
+		"command.tides.aliases":  "tiden",
+		"command.tides.usage":    "[Spot] [Tag|Zeitraum...] [um <Uhrzeit>]",
+		"command.tides.summary":  "Die Gezeiten von heute, oder der Wasserstand zu einer Uhrzeit",
+		"command.tides.details":  "Tage können _heute_, _morgen_, _samstag_, _nächsten montag_, _in 3 tagen_, _+2_, _wochenende_, _nächste woche_, _24.12._ oder _24 dez_ sein, und Zeiträume wie _montag-freitag_ oder _24.12.-28.12._, bis zu %d Tage. Numerische Daten folgen der Reihenfolge von Tag und Monat deines Landes.",
+		"command.tides.examples": "gezeiten morgen|gezeiten wochenende|gezeiten nächsten montag|gezeiten montag-freitag|gezeiten risco-del-paso|gezeiten um 15:30|gezeiten morgen um 7",
+
+		"command.now.keyword":  "jetzt",
+		"command.now.usage":    "[Spot]",
+		"command.now.summary":  "Der Wasserstand gerade jetzt, wie schnell er sich ändert und das nächste Hoch- und Niedrigwasser",
+		"command.now.examples": "jetzt|gezeiten jetzt|jetzt el-cotillo",
+
+		"command.below.keyword":  "unter",
+		"command.below.usage":    "[Spot] <Stand>[m|ft] [Tag...]",
+		"command.below.summary":  "Wann das Wasser unter einem Stand ist, in deinen Einheiten, wenn nicht angegeben",
+		"command.below.examples": "unter 0.5 morgen|unter 2ft samstag",
+
+		"command.above.keyword":  "über",
+		"command.above.aliases":  "ueber, uber",
+		"command.above.usage":    "[Spot] <Stand>[m|ft] [Tag...]",
+		"command.above.summary":  "Wann das Wasser über einem Stand ist, in deinen Einheiten, wenn nicht angegeben",
+		"command.above.examples": "über 1 samstag",
+
+		"command.conditions.keyword":  "bedingungen",
+		"command.conditions.aliases":  "wetter",
+		"command.conditions.usage":    "[Spot] [Tag]",
+		"command.conditions.summary":  "Wind, Dünung und Wassertemperatur mit den Gezeiten",
+		"command.conditions.examples": "bedingungen|bedingungen morgen",
+
+		"command.best.keyword":  "beste",
+		"command.best.usage":    "[Spot] [Tage, 1 bis %d]",
+		"command.best.summary":  "Die besten Zeiten zum Rausgehen in den nächsten Tagen",
+		"command.best.examples": "beste|beste 5|beste el-cotillo",
+
+		"command.month.keyword": "monat",
+		"command.month.usage":   "[Spot]",
+		"command.month.summary": "Mondphasen, Spring- und Nipptiden der nächsten %d Tage",
+
+		"command.units.keyword":  "einheiten",
+		"command.units.usage":    "meter|fuß",
+		"command.units.summary":  "Zeigt Höhen in Meter oder Fuß",
+		"command.units.examples": "einheiten fuß|einheiten meter",
+
+		"command.datum.keyword":  "bezug",
+		"command.datum.usage":    "mls|lat|cd|mllw|spot",
+		"command.datum.summary":  "Wähle, worauf sich die Höhen beziehen",
+		"command.datum.examples": "bezug lat|bezug spot",
+
+		"command.lang.keyword":  "sprache",
+		"command.lang.aliases":  "sprachen",
+		"command.lang.usage":    "en|es|de|pl|auto",
+		"command.lang.summary":  "Wähle die Sprache, in der ich mit dir spreche",
+		"command.lang.examples": "sprache en|sprache auto",
+
+		"command.spots.keyword": "spots",
+		"command.spots.aliases": "orte",
+		"command.spots.summary": "Listet die verfügbaren Spots auf, oder teile einen Standort für den nächsten",
+
+		"command.calendar.keyword": "kalender",
+		"command.calendar.summary": "Dein persönlicher Gezeitenkalender zum Abonnieren",
+
+		"command.stations.keyword":  "stationen",
+		"command.stations.usage":    "[bei] <Ort|Spot|lat,lon> [Radius km]",
+		"command.stations.summary":  "Gezeitenstationen in der Nähe eines Ortes, oder teile einen Standort",
+		"command.stations.examples": "stationen bei tarifa|stationen bei 36.01,-5.60 100km",
+
+		"command.setup.keyword": "einrichten",
+		"command.setup.summary": "Wähle Schritt für Schritt deinen Spot, die Uhrzeit des Berichts und die Sprache",
+
+		"command.start.keyword":  "starten",
+		"command.start.usage":    "[Spot]",
+		"command.start.summary":  "Aktiviert die täglichen Benachrichtigungen",
+		"command.start.examples": "starten|starten risco-del-paso",
+
+		"command.stop.keyword": "stopp",
+		"command.stop.summary": "Deaktiviert die Benachrichtigungen",
+
+		"command.delete.keyword": "löschen",
+		"command.delete.aliases": "loeschen",
+		"command.delete.summary": "Löscht alle deine Daten",
+
+		"command.help.keyword": "hilfe",
+		"command.help.aliases": "?, befehle",
+		"command.help.usage":   "[Befehl]",
+		"command.help.summary": "Listet die Befehle auf, oder erklärt einen",
+
+		"command.cancel.keyword": "abbrechen",
+		"command.cancel.summary": "Verlässt die laufende Einrichtung oder Bestätigung",
+	},
+}
@@ -0,0 +1,272 @@
+package i18n
+
+var englishCatalog = catalog{
+	weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+
+	plurals: map[string]Plural{
+		"month.title":        {Other: "🌙 *Tide cycle for the next %d days*"},
+		"best.title":         {One: "🏄 *Best sessions today*", Other: "🏄 *Best sessions in the next %d days*"},
+		"stations.more":      {Other: "…and %d more"},
+		"date.too_many_days": {One: "that's more than %d day", Other: "that's more than %d days"},
+	},
+
+	messages: map[string]string{
+		"language.name": "English",
+
+		"layout.date":          "2006-01-02",
+		"layout.day":           "Monday, 2006-01-02",
+		"layout.short_day":     "Mon 02/01",
+		"layout.date_time":     "2006-01-02 15:04",
+		"layout.time_day":      "15:04, Monday",
+		"layout.time_day_date": "15:04, Monday, 2006-01-02",
+
+		"error.generic":         "❌ Sorry, there was an error. Please try again later.",
+		"error.budget":          "⏳ Sorry, we've used up this month's tide data allowance. Please try again later.",
+		"error.budget_day":      "⏳ Sorry, we've used up this month's tide data allowance, so I can only share days that were already looked up. No data for %s yet.",
+		"error.tides":           "❌ Sorry, I couldn't fetch tide data. Please try again later.",
+		"error.tides_day":       "❌ Sorry, I couldn't fetch tide data for %s. Please try again later.",
+		"error.heights_at":      "❌ Sorry, I don't have tide heights for %s.",
+		"error.heights_now":     "❌ Sorry, I don't have tide heights for right now.",
+		"error.conditions":      "❌ Sorry, I couldn't fetch the conditions for %s. Please try again later.",
+		"error.stations":        "❌ Sorry, I couldn't look up tide stations. Please try again later.",
+		"error.notifications":   "❌ Sorry, there was an error enabling notifications. Please try again later.",
+		"error.time":            "❓ Sorry, I couldn't understand the time \"%s\". Try e.g. _tides at 15:30_ or _tides tomorrow at 7_.",
+		"error.level":           "❓ Please tell me the water level in %s, e.g. _below 0.5 tomorrow_ or _above 1 saturday_.",
+		"error.best_days":       "❓ Please give a number of days from 1 to %d, e.g. _best 3_",
+		"error.date":            "❓ Sorry, I couldn't understand \"%s\", %s.\n\nTry e.g. _tides saturday_, _tides next monday_, _tides in 3 days_, _tides weekend_, _tides mon-fri_ or _tides 24/12-28/12_. Send *spots* for the spot names.",
+		"error.unknown_spot":    "🤷‍♂️ I don't know the spot *%s*.\n\nSend *spots* to see all available spots.",
+		"error.unknown_place":   "🤷 I couldn't find *%s*. Try a town name, coordinates like _36.01,-5.60_ or share a location pin.",
+		"error.unknown_command": "🤔 There's no *%s* command.\n%s",
+
+		"date.not_a_date":           "not a date",
+		"date.no_such_day":          "there's no such day",
+		"date.not_a_number_of_days": "not a number of days",
+		"date.not_a_single_day":     "not a single day",
+		"date.ends_before_start":    "the range ends before it starts",
+
+		"tide.high":      "High Tide",
+		"tide.low":       "Low Tide",
+		"tide.next_high": "Next High Tide",
+		"tide.next_low":  "Next Low Tide",
+		"tide.rising":    "💧 *Water level*: %s, ⬆️ rising",
+		"tide.falling":   "💧 *Water level*: %s, ⬇️ falling",
+		"tide.in":        "in %s",
+		"tide.source":    "Source: %s",
+		"tide.datum":     "Heights relative to %s",
+
+		"light.twilight":   "twilight",
+		"light.dark":       "dark",
+		"daylight.sun":     "🌅 *Sunrise*: %s  🌇 *Sunset*: %s",
+		"daylight.light":   "🌗 *First light*: %s  *Last light*: %s",
+		"daylight.no_set":  "☀️ The sun doesn't set today",
+		"daylight.no_rise": "🌙 The sun doesn't rise today",
+
+		"cycle.spring":       "spring tides",
+		"cycle.neap":         "neap tides",
+		"cycle.intermediate": "intermediate tides",
+		"cycle.coefficient":  "coefficient %d",
+
+		"moon.new":             "New moon",
+		"moon.waxing_crescent": "Waxing crescent",
+		"moon.first_quarter":   "First quarter",
+		"moon.waxing_gibbous":  "Waxing gibbous",
+		"moon.full":            "Full moon",
+		"moon.waning_gibbous":  "Waning gibbous",
+		"moon.last_quarter":    "Last quarter",
+		"moon.waning_crescent": "Waning crescent",
+
+		"tides.title":        "🌊 *Tides for %s*",
+		"tides.none":         "No tide data available for today.",
+		"tides.chart":        "📈 Tide curve for %s",
+		"tides.at":           "🌊 *Tide at %s*",
+		"now.title":          "🌊 *Tide now* (%s)",
+		"now.rate":           "%s/h",
+		"now.tomorrow":       "tomorrow",
+		"now.cycle":          "🔄 *Tidal cycle*: %d%% through, low water %s → %s",
+		"window.below":       "🌊 *Water below %s on %s*",
+		"window.above":       "🌊 *Water above %s on %s*",
+		"window.never_below": "The water doesn't go below %s that day.",
+		"window.never_above": "The water doesn't go above %s that day.",
+
+		"conditions.title":       "🏄 *Conditions for %s*",
+		"conditions.water":       "🌡️ *Water*: %.1f°C",
+		"conditions.gusts":       "gusts %.0fkn",
+		"conditions.no_data":     "no data",
+		"conditions.no_forecast": "No wind and swell forecast available.",
+		"conditions.no_tides":    "No tide data available.",
+
+		"best.no_preferences": "🤷 There are no session preferences for %s yet, so I can't tell the best time to go.",
+		"best.none":           "Nothing looks good, the tide, wind or daylight never line up.",
+		"best.without_wind":   "_No wind forecast for some days, those are ranked on the tide alone._",
+		"best.today":          "🏄 Best session today: %s",
+		"session.low":         "low tide",
+		"session.mid":         "mid tide",
+		"session.high":        "high tide",
+		"session.rising":      "rising",
+		"session.falling":     "falling",
+
+		"spots.title":    "📍 *Available spots:*",
+		"spots.examples": "Examples: _tides %s tomorrow_, _start %s_",
+
+		"stations.none":      "📡 No tide stations within %dkm of *%s*. Tides there come from the global tide model.\n\nTry a larger radius, e.g. _stations near %s 200km_",
+		"stations.title":     "📡 *Tide stations within %dkm of %s*",
+		"stations.footer":    "Spots bound to one of these stations get its predictions instead of the global model.",
+		"stations.ask_place": "📡 Share a location pin, or send the name or coordinates of a place, to list the tide stations near it.\n\nSend *cancel* to stop.",
+
+		"calendar.unavailable": "📅 Sorry, calendar feeds aren't available on this server.",
+		"calendar.message":     "📅 *Your tide calendar*\n\nSubscribe to this link in Google Calendar (_Other calendars_ › _From URL_), Apple Calendar or Outlook to get the next week's tides of your spot, in your units and datum:\n%s\n\nAdd sunrise and sunset with:\n%s?sun=true\n\nKeep the link to yourself, it's personal.",
+
+		"start.message": "🔔 *Notifications Enabled!*\n\nYou'll now receive daily tide reports for *%s* every morning.\n\n📱 Send *tides* anytime for current tide info\n📍 Send *spots* to see other spots\n🔕 Send *stop* to disable notifications\n\nWelcome aboard! 🌊",
+		"stop.none":     "🤷‍♂️ You don't have any active notifications to stop.\n\nSend *start* to enable tide notifications!",
+		"stop.message":  "🔕 *Notifications Disabled*\n\nYou'll no longer receive daily tide reports.\n\n📱 Send *tides* anytime for current tide info\n🔔 Send *start* to re-enable notifications\n\nThanks for using TideBot! 🌊",
+
+		"flow.unexpected":        "🤔 Sorry, I didn't get that.",
+		"flow.cancelled":         "👍 Cancelled. Send *help* to see what I can do.",
+		"flow.nothing_to_cancel": "🤷 There's nothing to cancel.",
+
+		"setup.spot":     "🧭 *Setup 1/3*\n\nWhich spot do you want daily tide reports for? Reply with its number or name:\n\n%s\nSend *cancel* to stop at any time.",
+		"setup.hour":     "🧭 *Setup 2/3*\n\nAt what time should the report for *%s* arrive? Reply with an hour in the spot's time (%s), e.g. _7_ or _18:00_.",
+		"setup.language": "🧭 *Setup 3/3*\n\nWhich language should I talk to you in? Reply with one of:\n\n%s\n_auto_ - your country's language",
+		"setup.done":     "✅ *All set!*\n\nYou'll receive the tide report for *%s* every day at *%02d:00*, in %s.\n\nSend *help* to see everything I can do. 🌊",
+
+		"delete.none":    "🤷 I don't have any data about you.",
+		"delete.confirm": "⚠️ This deletes your profile, notifications, settings and calendar feed.\n\nReply *YES* to delete your data, anything else keeps it.",
+		"delete.done":    "🗑️ *Your data was deleted.*\n\nSend any message if you want to start again.",
+		"delete.kept":    "👍 Nothing was deleted.",
+
+		"location.no_spots": "📍 Sorry, there are no spots to compare your location with yet.",
+		"location.nearest":  "📍 The nearest spot is *%s*, %.1fkm away.",
+		"location.too_far":  "📍 There are no spots within %dkm of your location. Send *stations near* and share it again to find the tide stations around it.",
+
+		"subscribe.offer":        "🔔 Reply *YES* to get the daily tide report for *%s*.",
+		"subscribe.offer_switch": "🔔 Reply *YES* to get the daily tide report for *%s* instead of *%s*.",
+		"subscribe.declined":     "👍 No problem. Send _start %s_ whenever you change your mind.",
+
+		"welcome.hi":       "Hi!",
+		"welcome.hi_name":  "Hi %s!",
+		"welcome.new_user": " Welcome to TideBot!",
+		"welcome.any_spot": "Fuerteventura and beyond",
+		"welcome.message":  "🌊 *%s%s*\n\nTide reports for *%s*.\n\nYour tide reports include high and low tide times with precise heights 🏄‍♂️\n\n%s\n",
+
+		"daily.hi":       "Hi %s!",
+		"daily.intro":    "Here is your daily tide report:",
+		"daily.high":     "High",
+		"daily.low":      "Low",
+		"daily.tide":     "%s tide: %s",
+		"daily.next_day": "+1 day",
+		"daily.location": "Location: %s",
+		"daily.footer":   "If you don't want to receive those notifications anymore, reply 'stop' to this message. Have a great day on the water!",
+
+		"units.meters":  "meters",
+		"units.feet":    "feet",
+		"units.current": "📏 Heights are shown in *%s*. Send _units meters_ or _units feet_ to change it.",
+		"units.invalid": "❓ Please pick _units meters_ or _units feet_.",
+		"units.updated": "✅ Heights will be shown in *%s*.",
+
+		"datum.spot":         "the spot's datum",
+		"datum.current":      "📐 Heights are relative to *%s*. Send _datum_ with one of %s, or _datum spot_ to use the spot's.\n\n%s",
+		"datum.invalid":      "❓ Please pick one of %s, or _datum spot_.",
+		"datum.updated_spot": "✅ Heights will be relative to each spot's datum.",
+		"datum.updated":      "✅ Heights will be relative to *%s*.",
+		"datum.help":         "*MLS* - mean sea level, the default\n*LAT* - lowest astronomical tide, heights are rarely negative\n*CD* - chart datum of the local nautical charts\n*MLLW* - mean lower low water, used on US charts",
+
+		"lang.current":      "🌐 I'm talking to you in *%s*. Send _lang_ with one of %s to change it, or _lang auto_ to go back to your country's language.",
+		"lang.invalid":      "❓ Please pick one of %s, or _lang auto_.",
+		"lang.updated":      "✅ I'll talk to you in *%s* from now on.",
+		"lang.updated_auto": "✅ I'll talk to you in your country's language, *%s*.",
+
+		"help.title":    "*Available commands:*",
+		"help.line":     "%s Send *%s* - %s",
+		"help.examples": "Examples: %s",
+		"help.aliases":  "Also: %s",
+		"help.more":     "❓ Send *help* and a command for more, e.g. _help tides_",
+
+		"command.tides.keyword":  "tides",
+		"command.tides.aliases":  "tide",
+		"command.tides.usage":    "[spot] [day|range...] [at <time>]",
+		"command.tides.summary":  "Get today's tide info, or the water level at a time",
+		"command.tides.details":  "Days can be _today_, _tomorrow_, _saturday_, _next monday_, _in 3 days_, _+2_, _weekend_, _next week_, _24/12_ or _24 dec_, and ranges like _mon-fri_ or _24/12-28/12_, up to %d days. Numeric dates follow your country's day and month order.",
+		"command.tides.examples": "tides tomorrow|tides weekend|tides next monday|tides mon-fri|tides risco-del-paso|tides at 15:30|tides tomorrow at 7",
+
+		"command.now.keyword":  "now",
+		"command.now.usage":    "[spot]",
+		"command.now.summary":  "The water level right now, how fast it moves and the next high and low",
+		"command.now.examples": "now|tides now|now el-cotillo",
+
+		"command.below.keyword":  "below",
+		"command.below.usage":    "[spot] <level>[m|ft] [day...]",
+		"command.below.summary":  "When the water is below a level, in your units unless given",
+		"command.below.examples": "below 0.5 tomorrow|below 2ft saturday",
+
+		"command.above.keyword":  "above",
+		"command.above.usage":    "[spot] <level>[m|ft] [day...]",
+		"command.above.summary":  "When the water is above a level, in your units unless given",
+		"command.above.examples": "above 1 saturday",
+
+		"command.conditions.keyword":  "conditions",
+		"command.conditions.usage":    "[spot] [day]",
+		"command.conditions.summary":  "Wind, swell and water temperature with the tides",
+		"command.conditions.examples": "conditions|conditions tomorrow",
+
+		"command.best.keyword":  "best",
+		"command.best.usage":    "[spot] [days, 1 to %d]",
+		"command.best.summary":  "The best times to go out in the next days",
+		"command.best.examples": "best|best 5|best el-cotillo",
+
+		"command.month.keyword": "month",
+		"command.month.usage":   "[spot]",
+		"command.month.summary": "Moon phases, spring and neap tides for the next %d days",
+
+		"command.units.keyword":  "units",
+		"command.units.usage":    "meters|feet",
+		"command.units.summary":  "Show heights in meters or feet",
+		"command.units.examples": "units feet|units meters",
+
+		"command.datum.keyword":  "datum",
+		"command.datum.usage":    "mls|lat|cd|mllw|spot",
+		"command.datum.summary":  "Pick what heights are relative to",
+		"command.datum.examples": "datum lat|datum spot",
+
+		"command.lang.keyword":  "lang",
+		"command.lang.aliases":  "language",
+		"command.lang.usage":    "en|es|de|pl|auto",
+		"command.lang.summary":  "Pick the language I talk to you in",
+		"command.lang.examples": "lang es|lang auto",
+
+		"command.spots.keyword": "spots",
+		"command.spots.summary": "List available spots, or share a location pin for the nearest one",
+
+		"command.calendar.keyword": "calendar",
+		"command.calendar.summary": "Your personal tide calendar to subscribe to",
+
+		"command.stations.keyword":  "stations",
+		"command.stations.usage":    "[near] <place|spot|lat,lon> [radius km]",
+		"command.stations.summary":  "Tide stations near a place, or share a location pin",
+		"command.stations.examples": "stations near tarifa|stations near 36.01,-5.60 100km",
+
+		"command.setup.keyword": "setup",
+		"command.setup.summary": "Pick your spot, report time and language step by step",
+
+		"command.start.keyword":  "start",
+		"command.start.usage":    "[spot]",
+		"command.start.summary":  "Enable daily notifications",
+		"command.start.examples": "start|start risco-del-paso",
+
+		"command.stop.keyword": "stop",
+		"command.stop.summary": "Disable notifications",
+
+		"command.delete.keyword": "delete",
+		"command.delete.aliases": "forget",
+		"command.delete.summary": "Delete all your data",
+
+		"command.help.keyword": "help",
+		"command.help.aliases": "?, commands",
+		"command.help.usage":   "[command]",
+		"command.help.summary": "List the commands, or explain one",
+
+		"command.cancel.keyword": "cancel",
+		"command.cancel.summary": "Leave the current setup or confirmation",
+	},
+}
@@ -0,0 +1,276 @@
+package i18n
+
+var spanishCatalog = catalog{
+	weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	shortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+
+	plurals: map[string]Plural{
+		"month.title":        {Other: "🌙 *Ciclo de mareas de los próximos %d días*"},
+		"best.title":         {One: "🏄 *Mejores sesiones de hoy*", Other: "🏄 *Mejores sesiones de los próximos %d días*"},
+		"stations.more":      {Other: "…y %d más"},
+		"date.too_many_days": {One: "es más de %d día", Other: "son más de %d días"},
+	},
+
+	messages: map[string]string{
+		"language.name": "Español",
+
+		"layout.date":          "02/01/2006",
+		"layout.day":           "Monday, 02/01/2006",
+		"layout.short_day":     "Mon 02/01",
+		"layout.date_time":     "02/01/2006 15:04",
+		"layout.time_day":      "15:04, Monday",
+		"layout.time_day_date": "15:04, Monday, 02/01/2006",
+
+		"error.generic":         "❌ Lo siento, ha habido un error. Inténtalo de nuevo más tarde.",
+		"error.budget":          "⏳ Lo siento, hemos agotado los datos de mareas de este mes. Inténtalo de nuevo más tarde.",
+		"error.budget_day":      "⏳ Lo siento, hemos agotado los datos de mareas de este mes, así que solo puedo compartir los días ya consultados. Aún no hay datos del %s.",
+		"error.tides":           "❌ Lo siento, no he podido obtener los datos de mareas. Inténtalo de nuevo más tarde.",
+		"error.tides_day":       "❌ Lo siento, no he podido obtener los datos de mareas del %s. Inténtalo de nuevo más tarde.",
+		"error.heights_at":      "❌ Lo siento, no tengo alturas de marea para el %s.",
+		"error.heights_now":     "❌ Lo siento, no tengo alturas de marea para este momento.",
+		"error.conditions":      "❌ Lo siento, no he podido obtener las condiciones del %s. Inténtalo de nuevo más tarde.",
+		"error.stations":        "❌ Lo siento, no he podido buscar estaciones de mareas. Inténtalo de nuevo más tarde.",
+		"error.notifications":   "❌ Lo siento, ha habido un error al activar las notificaciones. Inténtalo de nuevo más tarde.",
+		"error.time":            "❓ Lo siento, no entiendo la hora \"%s\". Prueba p. ej. _mareas a las 15:30_ o _mareas mañana a las 7_.",
+		"error.level":           "❓ Dime el nivel del agua en %s, p. ej. _debajo 0.5 mañana_ o _encima 1 sábado_.",
+		"error.best_days":       "❓ Indica un número de días del 1 al %d, p. ej. _mejor 3_",
+		"error.date":            "❓ Lo siento, no entiendo \"%s\", %s.\n\nPrueba p. ej. _mareas sábado_, _mareas próximo lunes_, _mareas en 3 días_, _mareas finde_, _mareas lunes-viernes_ o _mareas 24/12-28/12_. Envía *playas* para ver los nombres de las playas.",
+		"error.unknown_spot":    "🤷‍♂️ No conozco la playa *%s*.\n\nEnvía *playas* para ver todas las playas disponibles.",
+		"error.unknown_place":   "🤷 No he encontrado *%s*. Prueba con el nombre de un pueblo, coordenadas como _36.01,-5.60_ o comparte una ubicación.",
+		"error.unknown_command": "🤔 No existe el comando *%s*.\n%s",
+
+		"date.not_a_date":           "no es una fecha",
+		"date.no_such_day":          "ese día no existe",
+		"date.not_a_number_of_days": "no es un número de días",
+		"date.not_a_single_day":     "no es un solo día",
+		"date.ends_before_start":    "el rango termina antes de empezar",
+
+		"tide.high":      "Pleamar",
+		"tide.low":       "Bajamar",
+		"tide.next_high": "Próxima pleamar",
+		"tide.next_low":  "Próxima bajamar",
+		"tide.rising":    "💧 *Nivel del agua*: %s, ⬆️ subiendo",
+		"tide.falling":   "💧 *Nivel del agua*: %s, ⬇️ bajando",
+		"tide.in":        "en %s",
+		"tide.source":    "Fuente: %s",
+		"tide.datum":     "Alturas respecto a %s",
+
+		"light.twilight":   "crepúsculo",
+		"light.dark":       "de noche",
+		"daylight.sun":     "🌅 *Amanecer*: %s  🌇 *Atardecer*: %s",
+		"daylight.light":   "🌗 *Primera luz*: %s  *Última luz*: %s",
+		"daylight.no_set":  "☀️ Hoy el sol no se pone",
+		"daylight.no_rise": "🌙 Hoy el sol no sale",
+
+		"cycle.spring":       "mareas vivas",
+		"cycle.neap":         "mareas muertas",
+		"cycle.intermediate": "mareas intermedias",
+		"cycle.coefficient":  "coeficiente %d",
+
+		"moon.new":             "Luna nueva",
+		"moon.waxing_crescent": "Luna creciente",
+		"moon.first_quarter":   "Cuarto creciente",
+		"moon.waxing_gibbous":  "Creciente gibosa",
+		"moon.full":            "Luna llena",
+		"moon.waning_gibbous":  "Menguante gibosa",
+		"moon.last_quarter":    "Cuarto menguante",
+		"moon.waning_crescent": "Luna menguante",
+
+		"tides.title":        "🌊 *Mareas del %s*",
+		"tides.none":         "No hay datos de mareas para hoy.",
+		"tides.chart":        "📈 Curva de marea del %s",
+		"tides.at":           "🌊 *Marea a las %s*",
+		"now.title":          "🌊 *Marea ahora* (%s)",
+		"now.rate":           "%s/h",
+		"now.tomorrow":       "mañana",
+		"now.cycle":          "🔄 *Ciclo de marea*: al %d%%, bajamar %s → %s",
+		"window.below":       "🌊 *Agua por debajo de %s el %s*",
+		"window.above":       "🌊 *Agua por encima de %s el %s*",
+		"window.never_below": "Ese día el agua no baja de %s.",
+		"window.never_above": "Ese día el agua no sube de %s.",
+
+		"conditions.title":       "🏄 *Condiciones del %s*",
+		"conditions.water":       "🌡️ *Agua*: %.1f°C",
+		"conditions.gusts":       "rachas %.0fkn",
+		"conditions.no_data":     "sin datos",
+		"conditions.no_forecast": "No hay previsión de viento ni de mar de fondo.",
+		"conditions.no_tides":    "No hay datos de mareas.",
+
+		"best.no_preferences": "🤷 Aún no hay preferencias de sesión para %s, así que no sé cuál es el mejor momento para salir.",
+		"best.none":           "Nada pinta bien, la marea, el viento y la luz nunca coinciden.",
+		"best.without_wind":   "_Algunos días no tienen previsión de viento, se valoran solo por la marea._",
+		"best.today":          "🏄 Mejor sesión de hoy: %s",
+		"session.low":         "marea baja",
+		"session.mid":         "media marea",
+		"session.high":        "marea alta",
+		"session.rising":      "subiendo",
+		"session.falling":     "bajando",
+
+		"spots.title":    "📍 *Playas disponibles:*",
+		"spots.examples": "Ejemplos: _mareas %s mañana_, _empezar %s_",
+
+		"stations.none":      "📡 No hay estaciones de mareas a menos de %dkm de *%s*. Allí las mareas vienen del modelo global.\n\nPrueba un radio mayor, p. ej. _estaciones cerca %s 200km_",
+		"stations.title":     "📡 *Estaciones de mareas a menos de %dkm de %s*",
+		"stations.footer":    "Las playas vinculadas a una de estas estaciones usan sus predicciones en lugar del modelo global.",
+		"stations.ask_place": "📡 Comparte una ubicación, o envía el nombre o las coordenadas de un lugar, para ver las estaciones de mareas cercanas.\n\nEnvía *cancelar* para dejarlo.",
+
+		"calendar.unavailable": "📅 Lo siento, los calendarios no están disponibles en este servidor.",
+		"calendar.message":     "📅 *Tu calendario de mareas*\n\nSuscríbete a este enlace en Google Calendar (_Otros calendarios_ › _Desde URL_), Apple Calendar u Outlook para tener las mareas de la próxima semana de tu playa, en tus unidades y referencia:\n%s\n\nAñade el amanecer y el atardecer con:\n%s?sun=true\n\nNo compartas el enlace, es personal.",
+
+		"start.message": "🔔 *¡Notificaciones activadas!*\n\nA partir de ahora recibirás cada mañana el parte de mareas de *%s*.\n\n📱 Envía *mareas* cuando quieras para ver las mareas\n📍 Envía *playas* para ver otras playas\n🔕 Envía *parar* para desactivar las notificaciones\n\n¡Bienvenido a bordo! 🌊",
+		"stop.none":     "🤷‍♂️ No tienes notificaciones activas.\n\n¡Envía *empezar* para activar las notificaciones de mareas!",
+		"stop.message":  "🔕 *Notificaciones desactivadas*\n\nYa no recibirás el parte diario de mareas.\n\n📱 Envía *mareas* cuando quieras para ver las mareas\n🔔 Envía *empezar* para volver a activarlas\n\n¡Gracias por usar TideBot! 🌊",
+
+		"flow.unexpected":        "🤔 Lo siento, no te he entendido.",
+		"flow.cancelled":         "👍 Cancelado. Envía *ayuda* para ver lo que puedo hacer.",
+		"flow.nothing_to_cancel": "🤷 No hay nada que cancelar.",
+
+		"setup.spot":     "🧭 *Configuración 1/3*\n\n¿De qué spot quieres el parte diario de mareas? Responde con su número o nombre:\n\n%s\nEnvía *cancelar* para dejarlo en cualquier momento.",
+		"setup.hour":     "🧭 *Configuración 2/3*\n\n¿A qué hora quieres recibir el parte de *%s*? Responde con una hora del spot (%s), p. ej. _7_ o _18:00_.",
+		"setup.language": "🧭 *Configuración 3/3*\n\n¿En qué idioma te hablo? Responde con uno de:\n\n%s\n_auto_ - el idioma de tu país",
+		"setup.done":     "✅ *¡Todo listo!*\n\nRecibirás el parte de mareas de *%s* todos los días a las *%02d:00*, en %s.\n\nEnvía *ayuda* para ver todo lo que puedo hacer. 🌊",
+
+		"delete.none":    "🤷 No tengo ningún dato tuyo.",
+		"delete.confirm": "⚠️ Esto borra tu perfil, notificaciones, ajustes y calendario.\n\nResponde *SÍ* para borrar tus datos, cualquier otra cosa los conserva.",
+		"delete.done":    "🗑️ *Tus datos se han borrado.*\n\nEnvía cualquier mensaje si quieres volver a empezar.",
+		"delete.kept":    "👍 No se ha borrado nada.",
+
+		"location.no_spots": "📍 Lo siento, todavía no hay playas con las que comparar tu ubicación.",
+		"location.nearest":  "📍 La playa más cercana es *%s*, a %.1fkm.",
+		"location.too_far":  "📍 No hay playas a menos de %dkm de tu ubicación. Envía *estaciones cerca* y vuelve a compartirla para buscar las estaciones de mareas de la zona.",
+
+		"subscribe.offer":        "🔔 Responde *SÍ* para recibir el parte diario de mareas de *%s*.",
+		"subscribe.offer_switch": "🔔 Responde *SÍ* para recibir el parte diario de mareas de *%s* en lugar de *%s*.",
+		"subscribe.declined":     "👍 Sin problema. Envía _empezar %s_ cuando cambies de idea.",
+
+		"welcome.hi":       "¡Hola!",
+		"welcome.hi_name":  "¡Hola, %s!",
+		"welcome.new_user": " Bienvenido a TideBot.",
+		"welcome.any_spot": "Fuerteventura y más allá",
+		"welcome.message":  "🌊 *%s%s*\n\nPartes de mareas de *%s*.\n\nTus partes incluyen las horas de pleamar y bajamar con alturas precisas 🏄‍♂️\n\n%s\n",
+
+		"daily.hi":       "¡Hola, %s!",
+		"daily.intro":    "Este es tu parte diario de mareas:",
+		"daily.high":     "Pleamar",
+		"daily.low":      "Bajamar",
+		"daily.tide":     "%s: %s",
+		"daily.next_day": "+1 día",
+		"daily.location": "Lugar: %s",
+		"daily.footer":   "Si ya no quieres recibir estas notificaciones, responde 'parar' a este mensaje. ¡Que tengas un gran día en el agua!",
+
+		"units.meters":  "metros",
+		"units.feet":    "pies",
+		"units.current": "📏 Las alturas se muestran en *%s*. Envía _unidades metros_ o _unidades pies_ para cambiarlo.",
+		"units.invalid": "❓ Elige _unidades metros_ o _unidades pies_.",
+		"units.updated": "✅ Las alturas se mostrarán en *%s*.",
+
+		"datum.spot":         "la referencia de la playa",
+		"datum.current":      "📐 Las alturas son respecto a *%s*. Envía _referencia_ con una de %s, o _referencia spot_ para usar la de la playa.\n\n%s",
+		"datum.invalid":      "❓ Elige una de %s, o _referencia spot_.",
+		"datum.updated_spot": "✅ Las alturas serán respecto a la referencia de cada playa.",
+		"datum.updated":      "✅ Las alturas serán respecto a *%s*.",
+		"datum.help":         "*MLS* - nivel medio del mar, por defecto\n*LAT* - marea astronómica más baja, las alturas casi nunca son negativas\n*CD* - cero hidrográfico de las cartas náuticas locales\n*MLLW* - media de las bajamares más bajas, usada en las cartas de EE. UU.",
+
+		"lang.current":      "🌐 Te hablo en *%s*. Envía _idioma_ con uno de %s para cambiarlo, o _idioma auto_ para volver al idioma de tu país.",
+		"lang.invalid":      "❓ Elige uno de %s, o _idioma auto_.",
+		"lang.updated":      "✅ A partir de ahora te hablaré en *%s*.",
+		"lang.updated_auto": "✅ Te hablaré en el idioma de tu país, *%s*.",
+
+		"help.title":    "*Comandos disponibles:*",
+		"help.line":     "%s Envía *%s* - %s",
+		"help.examples": "Ejemplos: %s",
+		"help.aliases":  "También: %s",
+		"help.more":     "❓ Envía *ayuda* y un comando para saber más, p. ej. _ayuda mareas_",
+
+		"command.tides.keyword":  "mareas",
+		"command.tides.aliases":  "marea",
+		"command.tides.usage":    "[playa] [día|rango...] [a las <hora>]",
+		"command.tides.summary":  "Las mareas de hoy, o el nivel del agua a una hora",
+		"command.tides.details":  "Los días pueden ser _hoy_, _mañana_, _sábado_, _próximo lunes_, _en 3 días_, _+2_, _finde_, _próxima semana_, _24/12_ o _24 dic_, y rangos como _lunes-viernes_ o _24/12-28/12_, de hasta %d días. Las fechas numéricas siguen el orden de día y mes de tu país.",
+		"command.tides.examples": "mareas mañana|mareas finde|mareas próximo lunes|mareas lunes-viernes|mareas risco-del-paso|mareas a las 15:30|mareas mañana a las 7",
+
+		"command.now.keyword":  "ahora",
+		"command.now.usage":    "[playa]",
+		"command.now.summary":  "El nivel del agua ahora mismo, lo rápido que cambia y la próxima pleamar y bajamar",
+		"command.now.examples": "ahora|mareas ahora|ahora el-cotillo",
+
+		"command.below.keyword":  "debajo",
+		"command.below.aliases":  "bajo",
+		"command.below.usage":    "[playa] <nivel>[m|ft] [día...]",
+		"command.below.summary":  "Cuándo el agua está por debajo de un nivel, en tus unidades salvo que indiques otras",
+		"command.below.examples": "debajo 0.5 mañana|debajo 2ft sábado",
+
+		"command.above.keyword":  "encima",
+		"command.above.aliases":  "sobre",
+		"command.above.usage":    "[playa] <nivel>[m|ft] [día...]",
+		"command.above.summary":  "Cuándo el agua está por encima de un nivel, en tus unidades salvo que indiques otras",
+		"command.above.examples": "encima 1 sábado",
+
+		"command.conditions.keyword":  "condiciones",
+		"command.conditions.usage":    "[playa] [día]",
+		"command.conditions.summary":  "Viento, mar de fondo y temperatura del agua junto a las mareas",
+		"command.conditions.examples": "condiciones|condiciones mañana",
+
+		"command.best.keyword":  "mejor",
+		"command.best.aliases":  "mejores",
+		"command.best.usage":    "[playa] [días, del 1 al %d]",
+		"command.best.summary":  "Los mejores momentos para salir en los próximos días",
+		"command.best.examples": "mejor|mejor 5|mejor el-cotillo",
+
+		"command.month.keyword": "mes",
+		"command.month.usage":   "[playa]",
+		"command.month.summary": "Fases lunares, mareas vivas y muertas de los próximos %d días",
+
+		"command.units.keyword":  "unidades",
+		"command.units.usage":    "metros|pies",
+		"command.units.summary":  "Muestra las alturas en metros o en pies",
+		"command.units.examples": "unidades pies|unidades metros",
+
+		"command.datum.keyword":  "referencia",
+		"command.datum.usage":    "mls|lat|cd|mllw|spot",
+		"command.datum.summary":  "Elige respecto a qué se miden las alturas",
+		"command.datum.examples": "referencia lat|referencia spot",
+
+		"command.lang.keyword":  "idioma",
+		"command.lang.aliases":  "lengua",
+		"command.lang.usage":    "en|es|de|pl|auto",
+		"command.lang.summary":  "Elige el idioma en el que te hablo",
+		"command.lang.examples": "idioma en|idioma auto",
+
+		"command.spots.keyword": "playas",
+		"command.spots.summary": "Lista las playas disponibles, o comparte una ubicación para la más cercana",
+
+		"command.calendar.keyword": "calendario",
+		"command.calendar.summary": "Tu calendario de mareas personal para suscribirte",
+
+		"command.stations.keyword":  "estaciones",
+		"command.stations.usage":    "[cerca] <lugar|playa|lat,lon> [radio km]",
+		"command.stations.summary":  "Estaciones de mareas cerca de un lugar, o comparte una ubicación",
+		"command.stations.examples": "estaciones cerca tarifa|estaciones cerca 36.01,-5.60 100km",
+
+		"command.setup.keyword": "configurar",
+		"command.setup.summary": "Elige tu spot, la hora del parte y el idioma paso a paso",
+
+		"command.start.keyword":  "empezar",
+		"command.start.aliases":  "iniciar",
+		"command.start.usage":    "[playa]",
+		"command.start.summary":  "Activa las notificaciones diarias",
+		"command.start.examples": "empezar|empezar risco-del-paso",
+
+		"command.stop.keyword": "parar",
+		"command.stop.summary": "Desactiva las notificaciones",
+
+		"command.delete.keyword": "borrar",
+		"command.delete.aliases": "eliminar",
+		"command.delete.summary": "Borra todos tus datos",
+
+		"command.help.keyword": "ayuda",
+		"command.help.aliases": "?, comandos",
+		"command.help.usage":   "[comando]",
+		"command.help.summary": "Lista los comandos, o explica uno",
+
+		"command.cancel.keyword": "cancelar",
+		"command.cancel.summary": "Deja la configuración o confirmación en curso",
+	},
+}
@@ -0,0 +1,278 @@
+package i18n
+
+var polishCatalog = catalog{
+	weekdays:      [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+	shortWeekdays: [7]string{"niedz", "pon", "wt", "śr", "czw", "pt", "sob"},
+	months:        [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+
+	plurals: map[string]Plural{
+		"month.title":        {Other: "🌙 *Cykl pływów na najbliższe %d dni*"},
+		"best.title":         {One: "🏄 *Najlepsze sesje dzisiaj*", Other: "🏄 *Najlepsze sesje w ciągu najbliższych %d dni*"},
+		"stations.more":      {One: "…i jeszcze %d stacja", Few: "…i jeszcze %d stacje", Many: "…i jeszcze %d stacji"},
+		"date.too_many_days": {One: "to więcej niż %d dzień", Other: "to więcej niż %d dni"},
+	},
+
+	messages: map[string]string{
+		"language.name": "Polski",
+
+		"layout.date":          "02.01.2006",
+		"layout.day":           "Monday, 02.01.2006",
+		"layout.short_day":     "Mon 02.01",
+		"layout.date_time":     "02.01.2006 15:04",
+		"layout.time_day":      "15:04, Monday",
+		"layout.time_day_date": "15:04, Monday, 02.01.2006",
+
+		"error.generic":         "❌ Przepraszam, wystąpił błąd. Spróbuj ponownie później.",
+		"error.budget":          "⏳ Przepraszam, wykorzystaliśmy limit danych o pływach na ten miesiąc. Spróbuj ponownie później.",
+		"error.budget_day":      "⏳ Przepraszam, wykorzystaliśmy limit danych o pływach na ten miesiąc, więc mogę pokazać tylko dni już sprawdzone. Brak jeszcze danych na %s.",
+		"error.tides":           "❌ Przepraszam, nie udało się pobrać danych o pływach. Spróbuj ponownie później.",
+		"error.tides_day":       "❌ Przepraszam, nie udało się pobrać danych o pływach na %s. Spróbuj ponownie później.",
+		"error.heights_at":      "❌ Przepraszam, nie mam poziomów wody na %s.",
+		"error.heights_now":     "❌ Przepraszam, nie mam teraz poziomów wody.",
+		"error.conditions":      "❌ Przepraszam, nie udało się pobrać warunków na %s. Spróbuj ponownie później.",
+		"error.stations":        "❌ Przepraszam, nie udało się wyszukać stacji pływowych. Spróbuj ponownie później.",
+		"error.notifications":   "❌ Przepraszam, wystąpił błąd przy włączaniu powiadomień. Spróbuj ponownie później.",
+		"error.time":            "❓ Przepraszam, nie rozumiem godziny \"%s\". Spróbuj np. _pływy o 15:30_ albo _pływy jutro o 7_.",
+		"error.level":           "❓ Podaj poziom wody w %s, np. _poniżej 0.5 jutro_ albo _powyżej 1 sobota_.",
+		"error.best_days":       "❓ Podaj liczbę dni od 1 do %d, np. _najlepsze 3_",
+		"error.date":            "❓ Przepraszam, nie rozumiem \"%s\", %s.\n\nSpróbuj np. _pływy sobota_, _pływy następny poniedziałek_, _pływy za 3 dni_, _pływy weekend_, _pływy pon-pt_ albo _pływy 24.12-28.12_. Wyślij *miejsca*, aby zobaczyć nazwy miejsc.",
+		"error.unknown_spot":    "🤷‍♂️ Nie znam miejsca *%s*.\n\nWyślij *miejsca*, aby zobaczyć wszystkie dostępne miejsca.",
+		"error.unknown_place":   "🤷 Nie mogę znaleźć *%s*. Spróbuj nazwy miejscowości, współrzędnych jak _36.01,-5.60_ albo udostępnij lokalizację.",
+		"error.unknown_command": "🤔 Nie ma polecenia *%s*.\n%s",
+
+		"date.not_a_date":           "to nie jest data",
+		"date.no_such_day":          "nie ma takiego dnia",
+		"date.not_a_number_of_days": "to nie jest liczba dni",
+		"date.not_a_single_day":     "to nie jest jeden dzień",
+		"date.ends_before_start":    "zakres kończy się, zanim się zaczyna",
+
+		"tide.high":      "Przypływ",
+		"tide.low":       "Odpływ",
+		"tide.next_high": "Następny przypływ",
+		"tide.next_low":  "Następny odpływ",
+		"tide.rising":    "💧 *Poziom wody*: %s, ⬆️ rośnie",
+		"tide.falling":   "💧 *Poziom wody*: %s, ⬇️ opada",
+		"tide.in":        "za %s",
+		"tide.source":    "Źródło: %s",
+		"tide.datum":     "Wysokości względem %s",
+
+		"light.twilight":   "zmierzch",
+		"light.dark":       "ciemno",
+		"daylight.sun":     "🌅 *Wschód słońca*: %s  🌇 *Zachód słońca*: %s",
+		"daylight.light":   "🌗 *Pierwsze światło*: %s  *Ostatnie światło*: %s",
+		"daylight.no_set":  "☀️ Słońce dziś nie zachodzi",
+		"daylight.no_rise": "🌙 Słońce dziś nie wschodzi",
+
+		"cycle.spring":       "pływy syzygijne",
+		"cycle.neap":         "pływy kwadraturowe",
+		"cycle.intermediate": "pływy pośrednie",
+		"cycle.coefficient":  "współczynnik %d",
+
+		"moon.new":             "Nów",
+		"moon.waxing_crescent": "Przybywający sierp",
+		"moon.first_quarter":   "Pierwsza kwadra",
+		"moon.waxing_gibbous":  "Przybywający garb",
+		"moon.full":            "Pełnia",
+		"moon.waning_gibbous":  "Ubywający garb",
+		"moon.last_quarter":    "Ostatnia kwadra",
+		"moon.waning_crescent": "Ubywający sierp",
+
+		"tides.title":        "🌊 *Pływy na %s*",
+		"tides.none":         "Brak danych o pływach na dziś.",
+		"tides.chart":        "📈 Krzywa pływów na %s",
+		"tides.at":           "🌊 *Pływy o %s*",
+		"now.title":          "🌊 *Pływy teraz* (%s)",
+		"now.rate":           "%s/h",
+		"now.tomorrow":       "jutro",
+		"now.cycle":          "🔄 *Cykl pływowy*: %d%% za nami, odpływ %s → %s",
+		"window.below":       "🌊 *Woda poniżej %s, %s*",
+		"window.above":       "🌊 *Woda powyżej %s, %s*",
+		"window.never_below": "Tego dnia woda nie opada poniżej %s.",
+		"window.never_above": "Tego dnia woda nie podnosi się powyżej %s.",
+
+		"conditions.title":       "🏄 *Warunki na %s*",
+		"conditions.water":       "🌡️ *Woda*: %.1f°C",
+		"conditions.gusts":       "porywy %.0fkn",
+		"conditions.no_data":     "brak danych",
+		"conditions.no_forecast": "Brak prognozy wiatru i fali.",
+		"conditions.no_tides":    "Brak danych o pływach.",
+
+		"best.no_preferences": "🤷 Nie ma jeszcze preferencji sesji dla %s, więc nie wiem, kiedy najlepiej wyjść na wodę.",
+		"best.none":           "Nic nie wygląda dobrze, pływy, wiatr i światło dzienne nigdy się nie zgrywają.",
+		"best.without_wind":   "_Dla niektórych dni brak prognozy wiatru, te są oceniane tylko według pływów._",
+		"best.today":          "🏄 Najlepsza sesja dzisiaj: %s",
+		"session.low":         "niska woda",
+		"session.mid":         "średnia woda",
+		"session.high":        "wysoka woda",
+		"session.rising":      "rośnie",
+		"session.falling":     "opada",
+
+		"spots.title":    "📍 *Dostępne miejsca:*",
+		"spots.examples": "Przykłady: _pływy %s jutro_, _start %s_",
+
+		"stations.none":      "📡 Brak stacji pływowych w promieniu %dkm od *%s*. Pływy pochodzą tam z globalnego modelu.\n\nSpróbuj większego promienia, np. _stacje blisko %s 200km_",
+		"stations.title":     "📡 *Stacje pływowe w promieniu %dkm od %s*",
+		"stations.footer":    "Miejsca przypisane do jednej z tych stacji dostają jej prognozy zamiast globalnego modelu.",
+		"stations.ask_place": "📡 Udostępnij lokalizację albo wyślij nazwę lub współrzędne miejscowości, aby zobaczyć pobliskie stacje pływowe.\n\nWyślij *anuluj*, aby przerwać.",
+
+		"calendar.unavailable": "📅 Przepraszam, kalendarze nie są dostępne na tym serwerze.",
+		"calendar.message":     "📅 *Twój kalendarz pływów*\n\nZasubskrybuj ten link w Kalendarzu Google (_Inne kalendarze_ › _Z adresu URL_), Kalendarzu Apple albo Outlooku, aby mieć pływy swojego miejsca na najbliższy tydzień, w swoich jednostkach i poziomie odniesienia:\n%s\n\nDodaj wschody i zachody słońca:\n%s?sun=true\n\nZachowaj link dla siebie, jest osobisty.",
+
+		"start.message": "🔔 *Powiadomienia włączone!*\n\nOd teraz każdego ranka dostaniesz raport pływów dla *%s*.\n\n📱 Wyślij *pływy*, aby w każdej chwili sprawdzić pływy\n📍 Wyślij *miejsca*, aby zobaczyć inne miejsca\n🔕 Wyślij *stop*, aby wyłączyć powiadomienia\n\nWitaj na pokładzie! 🌊",
+		"stop.none":     "🤷‍♂️ Nie masz aktywnych powiadomień.\n\nWyślij *start*, aby włączyć powiadomienia o pływach!",
+		"stop.message":  "🔕 *Powiadomienia wyłączone*\n\nNie będziesz już dostawać codziennych raportów pływów.\n\n📱 Wyślij *pływy*, aby w każdej chwili sprawdzić pływy\n🔔 Wyślij *start*, aby znów je włączyć\n\nDzięki za korzystanie z TideBota! 🌊",
+
+		"flow.unexpected":        "🤔 Przepraszam, nie rozumiem.",
+		"flow.cancelled":         "👍 Anulowano. Wyślij *pomoc*, aby zobaczyć, co potrafię.",
+		"flow.nothing_to_cancel": "🤷 Nie ma czego anulować.",
+
+		"setup.spot":     "🧭 *Konfiguracja 1/3*\n\nDla którego miejsca chcesz dostawać codzienny raport pływów? Odpowiedz jego numerem lub nazwą:\n\n%s\nWyślij *anuluj*, aby w każdej chwili przerwać.",
+		"setup.hour":     "🧭 *Konfiguracja 2/3*\n\nO której godzinie ma przychodzić raport dla *%s*? Odpowiedz godziną w czasie miejsca (%s), np. _7_ albo _18:00_.",
+		"setup.language": "🧭 *Konfiguracja 3/3*\n\nW jakim języku mam z tobą rozmawiać? Odpowiedz jednym z:\n\n%s\n_auto_ - język twojego kraju",
+		"setup.done":     "✅ *Gotowe!*\n\nRaport pływów dla *%s* będziesz dostawać codziennie o *%02d:00*, w języku: %s.\n\nWyślij *pomoc*, aby zobaczyć wszystko, co potrafię. 🌊",
+
+		"delete.none":    "🤷 Nie mam żadnych twoich danych.",
+		"delete.confirm": "⚠️ To usunie twój profil, powiadomienia, ustawienia i kalendarz.\n\nOdpowiedz *TAK*, aby usunąć swoje dane, cokolwiek innego je zachowa.",
+		"delete.done":    "🗑️ *Twoje dane zostały usunięte.*\n\nWyślij dowolną wiadomość, jeśli chcesz zacząć od nowa.",
+		"delete.kept":    "👍 Nic nie zostało usunięte.",
+
+		"location.no_spots": "📍 Przepraszam, nie ma jeszcze miejsc, z którymi mógłbym porównać twoją lokalizację.",
+		"location.nearest":  "📍 Najbliższe miejsce to *%s*, %.1fkm stąd.",
+		"location.too_far":  "📍 Nie ma miejsc w promieniu %dkm od twojej lokalizacji. Wyślij *stacje blisko* i udostępnij ją ponownie, aby znaleźć pobliskie stacje pływowe.",
+
+		"subscribe.offer":        "🔔 Odpowiedz *TAK*, aby dostawać codzienny raport pływów dla *%s*.",
+		"subscribe.offer_switch": "🔔 Odpowiedz *TAK*, aby dostawać codzienny raport pływów dla *%s* zamiast *%s*.",
+		"subscribe.declined":     "👍 Nie ma sprawy. Wyślij _start %s_, jeśli zmienisz zdanie.",
+
+		"welcome.hi":       "Cześć!",
+		"welcome.hi_name":  "Cześć %s!",
+		"welcome.new_user": " Witaj w TideBocie!",
+		"welcome.any_spot": "Fuerteventura i nie tylko",
+		"welcome.message":  "🌊 *%s%s*\n\nRaporty pływów dla *%s*.\n\nTwoje raporty zawierają godziny przypływów i odpływów z dokładnymi wysokościami 🏄‍♂️\n\n%s\n",
+
+		"daily.hi":       "Cześć %s!",
+		"daily.intro":    "Oto twój codzienny raport pływów:",
+		"daily.high":     "Przypływ",
+		"daily.low":      "Odpływ",
+		"daily.tide":     "%s: %s",
+		"daily.next_day": "+1 dzień",
+		"daily.location": "Miejsce: %s",
+		"daily.footer":   "Jeśli nie chcesz już dostawać tych powiadomień, odpowiedz 'stop' na tę wiadomość. Udanego dnia na wodzie!",
+
+		"units.meters":  "metry",
+		"units.feet":    "stopy",
+		"units.current": "📏 Wysokości są pokazywane w jednostce *%s*. Wyślij _jednostki metry_ albo _jednostki stopy_, aby to zmienić.",
+		"units.invalid": "❓ Wybierz _jednostki metry_ albo _jednostki stopy_.",
+		"units.updated": "✅ Wysokości będą pokazywane w jednostce *%s*.",
+
+		"datum.spot":         "poziom odniesienia miejsca",
+		"datum.current":      "📐 Wysokości są podawane względem *%s*. Wyślij _odniesienie_ z jednym z %s, albo _odniesienie spot_, aby użyć poziomu miejsca.\n\n%s",
+		"datum.invalid":      "❓ Wybierz jeden z %s, albo _odniesienie spot_.",
+		"datum.updated_spot": "✅ Wysokości będą podawane względem poziomu odniesienia każdego miejsca.",
+		"datum.updated":      "✅ Wysokości będą podawane względem *%s*.",
+		"datum.help":         "*MLS* - średni poziom morza, domyślny\n*LAT* - najniższy pływ astronomiczny, wysokości rzadko są ujemne\n*CD* - zero map lokalnych map morskich\n*MLLW* - średnia niższa niska woda, używana na mapach USA",
+
+		"lang.current":      "🌐 Rozmawiam z tobą po polsku (*%s*). Wyślij _język_ z jednym z %s, aby to zmienić, albo _język auto_, aby wrócić do języka twojego kraju.",
+		"lang.invalid":      "❓ Wybierz jeden z %s, albo _język auto_.",
+		"lang.updated":      "✅ Od teraz rozmawiam z tobą w języku: *%s*.",
+		"lang.updated_auto": "✅ Rozmawiam z tobą w języku twojego kraju: *%s*.",
+
+		"help.title":    "*Dostępne polecenia:*",
+		"help.line":     "%s Wyślij *%s* - %s",
+		"help.examples": "Przykłady: %s",
+		"help.aliases":  "Także: %s",
+		"help.more":     "❓ Wyślij *pomoc* i polecenie, aby dowiedzieć się więcej, np. _pomoc pływy_",
+
+		"command.tides.keyword":  "pływy",
+		"command.tides.aliases":  "plywy, pływ",
+		"command.tides.usage":    "[miejsce] [dzień|zakres...] [o <godzina>]",
+		"command.tides.summary":  "Dzisiejsze pływy albo poziom wody o danej godzinie",
+		"command.tides.details":  "Dni mogą być podane jako _dziś_, _jutro_, _sobota_, _następny poniedziałek_, _za 3 dni_, _+2_, _weekend_, _następny tydzień_, _24.12_ albo _24 gru_, a zakresy jak _pon-pt_ albo _24.12-28.12_, do %d dni. Daty liczbowe są czytane w kolejności dnia i miesiąca twojego kraju.",
+		"command.tides.examples": "pływy jutro|pływy weekend|pływy następny poniedziałek|pływy pon-pt|pływy risco-del-paso|pływy o 15:30|pływy jutro o 7",
+
+		"command.now.keyword":  "teraz",
+		"command.now.usage":    "[miejsce]",
+		"command.now.summary":  "Poziom wody w tej chwili, jak szybko się zmienia i następny przypływ i odpływ",
+		"command.now.examples": "teraz|pływy teraz|teraz el-cotillo",
+
+		"command.below.keyword":  "poniżej",
+		"command.below.aliases":  "ponizej",
+		"command.below.usage":    "[miejsce] <poziom>[m|ft] [dzień...]",
+		"command.below.summary":  "Kiedy woda jest poniżej poziomu, w twoich jednostkach, chyba że podasz inne",
+		"command.below.examples": "poniżej 0.5 jutro|poniżej 2ft sobota",
+
+		"command.above.keyword":  "powyżej",
+		"command.above.aliases":  "powyzej",
+		"command.above.usage":    "[miejsce] <poziom>[m|ft] [dzień...]",
+		"command.above.summary":  "Kiedy woda jest powyżej poziomu, w twoich jednostkach, chyba że podasz inne",
+		"command.above.examples": "powyżej 1 sobota",
+
+		"command.conditions.keyword":  "warunki",
+		"command.conditions.usage":    "[miejsce] [dzień]",
+		"command.conditions.summary":  "Wiatr, fala i temperatura wody razem z pływami",
+		"command.conditions.examples": "warunki|warunki jutro",
+
+		"command.best.keyword":  "najlepsze",
+		"command.best.aliases":  "najlepiej",
+		"command.best.usage":    "[miejsce] [dni, od 1 do %d]",
+		"command.best.summary":  "Najlepsze pory na wyjście na wodę w najbliższych dniach",
+		"command.best.examples": "najlepsze|najlepsze 5|najlepsze el-cotillo",
+
+		"command.month.keyword": "miesiąc",
+		"command.month.aliases": "miesiac",
+		"command.month.usage":   "[miejsce]",
+		"command.month.summary": "Fazy księżyca, pływy syzygijne i kwadraturowe na najbliższe %d dni",
+
+		"command.units.keyword":  "jednostki",
+		"command.units.usage":    "metry|stopy",
+		"command.units.summary":  "Pokazuje wysokości w metrach albo stopach",
+		"command.units.examples": "jednostki stopy|jednostki metry",
+
+		"command.datum.keyword":  "odniesienie",
+		"command.datum.usage":    "mls|lat|cd|mllw|spot",
+		"command.datum.summary":  "Wybierz, względem czego podawane są wysokości",
+		"command.datum.examples": "odniesienie lat|odniesienie spot",
+
+		"command.lang.keyword":  "język",
+		"command.lang.aliases":  "jezyk",
+		"command.lang.usage":    "en|es|de|pl|auto",
+		"command.lang.summary":  "Wybierz język, w którym z tobą rozmawiam",
+		"command.lang.examples": "język en|język auto",
+
+		"command.spots.keyword": "miejsca",
+		"command.spots.aliases": "spoty",
+		"command.spots.summary": "Lista dostępnych miejsc, albo udostępnij lokalizację, aby znaleźć najbliższe",
+
+		"command.calendar.keyword": "kalendarz",
+		"command.calendar.summary": "Twój osobisty kalendarz pływów do subskrypcji",
+
+		"command.stations.keyword":  "stacje",
+		"command.stations.usage":    "[blisko] <miejscowość|miejsce|lat,lon> [promień km]",
+		"command.stations.summary":  "Stacje pływowe w pobliżu miejscowości, albo udostępnij lokalizację",
+		"command.stations.examples": "stacje blisko tarifa|stacje blisko 36.01,-5.60 100km",
+
+		"command.setup.keyword": "konfiguruj",
+		"command.setup.aliases": "konfiguracja",
+		"command.setup.summary": "Wybierz krok po kroku miejsce, godzinę raportu i język",
+
+		"command.start.keyword":  "start",
+		"command.start.usage":    "[miejsce]",
+		"command.start.summary":  "Włącza codzienne powiadomienia",
+		"command.start.examples": "start|start risco-del-paso",
+
+		"command.stop.keyword": "stop",
+		"command.stop.summary": "Wyłącza powiadomienia",
+
+		"command.delete.keyword": "usuń",
+		"command.delete.aliases": "usun",
+		"command.delete.summary": "Usuwa wszystkie twoje dane",
+
+		"command.help.keyword": "pomoc",
+		"command.help.aliases": "?, polecenia",
+		"command.help.usage":   "[polecenie]",
+		"command.help.summary": "Lista poleceń albo wyjaśnienie jednego z nich",
+
+		"command.cancel.keyword": "anuluj",
+		"command.cancel.summary": "Przerywa trwającą konfigurację lub potwierdzenie",
+	},
+}
@@ -0,0 +1,262 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+type Language string
+
+const (
+	English Language = "en"
+	Spanish Language = "es"
+	German  Language = "de"
+	Polish  Language = "pl"
+)
+
+// Languages are the languages the bot speaks, in the order they are offered
+var Languages = []Language{English, Spanish, German, Polish}
+
+// DefaultLanguage is spoken to numbers from countries without a language of their own, and fills in missing translations
+const DefaultLanguage = English
+
+// LANGUAGE_NAMES are the names and codes a language can be picked with, in any of the languages
+var LANGUAGE_NAMES = map[string]Language{
+	"en": English, "english": English, "inglés": English, "ingles": English, "englisch": English, "angielski": English,
+	"es": Spanish, "spanish": Spanish, "español": Spanish, "espanol": Spanish, "castellano": Spanish, "spanisch": Spanish, "hiszpański": Spanish, "hiszpanski": Spanish,
+	"de": German, "german": German, "deutsch": German, "alemán": German, "aleman": German, "niemiecki": German,
+	"pl": Polish, "polish": Polish, "polski": Polish, "polaco": Polish, "polnisch": Polish,
+}
+
+// LANGUAGE_REGIONS are the countries whose phone numbers are spoken to in a language other than the default
+var LANGUAGE_REGIONS = map[string]Language{
+	"ES": Spanish, "MX": Spanish, "AR": Spanish, "CO": Spanish, "CL": Spanish, "PE": Spanish, "VE": Spanish, "EC": Spanish,
+	"GT": Spanish, "CU": Spanish, "BO": Spanish, "DO": Spanish, "HN": Spanish, "PY": Spanish, "SV": Spanish, "NI": Spanish,
+	"CR": Spanish, "PA": Spanish, "UY": Spanish, "PR": Spanish, "GQ": Spanish,
+	"DE": German, "AT": German, "CH": German, "LI": German,
+	"PL": Polish,
+}
+
+// ParseLanguage reads a language code, e.g. "es", or name, e.g. "español" or "spanish"
+func ParseLanguage(name string) (Language, bool) {
+	language, ok := LANGUAGE_NAMES[strings.ToLower(strings.TrimSpace(name))]
+	return language, ok
+}
+
+// LanguageForPhoneNumber guesses the language of the country of an international phone number
+func LanguageForPhoneNumber(phoneNumber string) Language {
+	number, err := phonenumbers.Parse(phoneNumber, "")
+	if err != nil {
+		return DefaultLanguage
+	}
+
+	if language, ok := LANGUAGE_REGIONS[phonenumbers.GetRegionCodeForNumber(number)]; ok {
+		return language
+	}
+
+	return DefaultLanguage
+}
+
+// Name is the language's name in itself, e.g. "Español"
+func (l Language) Name() string {
+	return NewLocalizer(l).T("language.name")
+}
+
+// Plural is a message with a form per plural category. Few and Many are only used by languages that have them,
+// such as Polish, and fall back to Other when empty.
+type Plural struct {
+	One   string
+	Few   string
+	Many  string
+	Other string
+}
+
+// catalog holds the messages of a language, keyed by message IDs shared by all languages
+type catalog struct {
+	messages      map[string]string
+	plurals       map[string]Plural
+	weekdays      [7]string
+	shortWeekdays [7]string
+	months        [12]string
+}
+
+var catalogs = map[Language]*catalog{
+	English: &englishCatalog,
+	Spanish: &spanishCatalog,
+	German:  &germanCatalog,
+	Polish:  &polishCatalog,
+}
+
+// Localizer formats the messages, numbers of things and dates of a language
+type Localizer struct {
+	Language Language
+	catalog  *catalog
+}
+
+// NewLocalizer returns the localizer of the language, or of the default language when it isn't spoken
+func NewLocalizer(language Language) Localizer {
+	c, ok := catalogs[language]
+	if !ok {
+		language, c = DefaultLanguage, catalogs[DefaultLanguage]
+	}
+	return Localizer{Language: language, catalog: c}
+}
+
+// T formats the message with the args, falling back to the default language's message when it isn't translated.
+// Messages without verbs are returned as they are, so args they don't use are ignored.
+func (l Localizer) T(key string, args ...any) string {
+	message, ok := l.catalog.messages[key]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage].messages[key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 || !strings.Contains(message, "%") {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Has tells whether there's a message for the key, in the language or the default one
+func (l Localizer) Has(key string) bool {
+	if _, ok := l.catalog.messages[key]; ok {
+		return true
+	}
+	_, ok := catalogs[DefaultLanguage].messages[key]
+	return ok
+}
+
+// N formats the form of the message for the count n, which is the first argument, followed by the args.
+// Like in T, forms without verbs are returned as they are, so a singular form can leave the count out.
+func (l Localizer) N(key string, n int, args ...any) string {
+	plural, ok := l.catalog.plurals[key]
+	if !ok {
+		plural, ok = catalogs[DefaultLanguage].plurals[key]
+	}
+	if !ok {
+		return key
+	}
+
+	form := plural.form(pluralCategory(l.Language, n))
+	if !strings.Contains(form, "%") {
+		return form
+	}
+	return fmt.Sprintf(form, append([]any{n}, args...)...)
+}
+
+type category int
+
+const (
+	one category = iota
+	few
+	many
+	other
+)
+
+// pluralCategory picks the CLDR plural category of a whole number
+func pluralCategory(language Language, n int) category {
+	if n < 0 {
+		n = -n
+	}
+
+	switch language {
+	case Polish:
+		switch {
+		case n == 1:
+			return one
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return few
+		default:
+			return many
+		}
+	default:
+		if n == 1 {
+			return one
+		}
+		return other
+	}
+}
+
+func (p Plural) form(c category) string {
+	switch {
+	case c == one && p.One != "":
+		return p.One
+	case c == few && p.Few != "":
+		return p.Few
+	case c == many && p.Many != "":
+		return p.Many
+	default:
+		return p.Other
+	}
+}
+
+// Weekday is the name of the weekday, e.g. "sábado"
+func (l Localizer) Weekday(weekday time.Weekday) string {
+	return l.catalog.weekdays[weekday]
+}
+
+// Month is the name of the month, e.g. "diciembre"
+func (l Localizer) Month(month time.Month) string {
+	return l.catalog.months[month-1]
+}
+
+// The names in a layout are swapped for placeholders that time.Format leaves alone, and then for the
+// language's names. Longer names go first, "Mon" being the start of "Monday".
+var layoutNames = []struct {
+	name        string
+	placeholder string
+}{
+	{"Monday", "\x00W\x00"},
+	{"Mon", "\x00w\x00"},
+	{"January", "\x00B\x00"},
+	{"Jan", "\x00b\x00"},
+}
+
+// Format formats the time with the language's layout of the key, e.g. "layout.day", whose weekday and month
+// names are localized
+func (l Localizer) Format(t time.Time, layoutKey string) string {
+	layout := l.T(layoutKey)
+	for _, name := range layoutNames {
+		layout = strings.ReplaceAll(layout, name.name, name.placeholder)
+	}
+
+	month := l.Month(t.Month())
+	formatted := t.Format(layout)
+
+	return strings.NewReplacer(
+		"\x00W\x00", l.Weekday(t.Weekday()),
+		"\x00w\x00", l.catalog.shortWeekdays[t.Weekday()],
+		"\x00B\x00", month,
+		"\x00b\x00", string([]rune(month)[:min(3, len([]rune(month)))]),
+	).Replace(formatted)
+}
+
+// MissingTranslations lists the messages of the default language each other language lacks, which are then
+// shown in the default language
+func MissingTranslations() map[Language][]string {
+	missing := make(map[Language][]string)
+	defaultCatalog := catalogs[DefaultLanguage]
+
+	for _, language := range Languages {
+		c := catalogs[language]
+		for key := range defaultCatalog.messages {
+			if _, ok := c.messages[key]; !ok {
+				missing[language] = append(missing[language], key)
+			}
+		}
+		for key := range defaultCatalog.plurals {
+			if _, ok := c.plurals[key]; !ok {
+				missing[language] = append(missing[language], key)
+			}
+		}
+		sort.Strings(missing[language])
+	}
+
+	return missing
+}
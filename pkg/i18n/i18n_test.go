@@ -0,0 +1,50 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPluralsFormatEveryCount(t *testing.T) {
+	for _, language := range Languages {
+		tr := NewLocalizer(language)
+
+		for key := range catalogs[DefaultLanguage].plurals {
+			for _, n := range []int{0, 1, 2, 5, 22} {
+				t.Run(fmt.Sprintf("%s/%s/%d", language, key, n), func(t *testing.T) {
+					message := tr.N(key, n)
+					if message == "" || message == key || strings.Contains(message, "%!") {
+						t.Errorf("N(%q, %d) = %q", key, n, message)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestPluralCategories(t *testing.T) {
+	tests := []struct {
+		language Language
+		n        int
+		want     category
+	}{
+		{English, 0, other},
+		{English, 1, one},
+		{English, 2, other},
+		{Polish, 1, one},
+		{Polish, 2, few},
+		{Polish, 5, many},
+		{Polish, 12, many},
+		{Polish, 22, few},
+		{Polish, 25, many},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.language, tt.n), func(t *testing.T) {
+			if got := pluralCategory(tt.language, tt.n); got != tt.want {
+				t.Errorf("pluralCategory() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,61 +1,61 @@
 package jobs
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
-	"strings"
+	"tidebot/pkg/common"
+	"tidebot/pkg/middleware"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/tides"
 
 	"github.com/labstack/echo/v4"
 )
 
 type JobsController struct {
-	jobsService JobsService
-	apiKey      string
-	log         echo.Logger
+	jobsService   JobsService
+	providerChain tides.ProviderChain
+	apiKey        string
+	log           echo.Logger
 }
 
-func NewJobsController(jobsService JobsService, apiKey string, log echo.Logger) *JobsController {
+func NewJobsController(jobsService JobsService, providerChain tides.ProviderChain, apiKey string, log echo.Logger) *JobsController {
 	return &JobsController{
-		jobsService: jobsService,
-		apiKey:      apiKey,
-		log:         log,
+		jobsService:   jobsService,
+		providerChain: providerChain,
+		apiKey:        apiKey,
+		log:           log,
 	}
 }
 
 func (jc *JobsController) RegisterRoutes(e *echo.Echo) {
 	jobsGroup := e.Group("/jobs")
-	jobsGroup.Use(jc.apiKeyMiddleware)
+	jobsGroup.Use(middleware.APIKey(jc.apiKey, jc.log))
 
 	jobsGroup.POST("/send-tide-extremes", jc.SendTideExtremesToAllUsers)
 	jobsGroup.POST("/v2/send-daily-notifications", jc.SendDailyNotifications)
+	jobsGroup.POST("/tides/evict-expired", jc.EvictExpiredTidePredictions)
+	jobsGroup.POST("/tides/refresh", jc.RefreshTides)
+	jobsGroup.GET("/tides/providers", jc.GetTidesProvidersHealth)
+	jobsGroup.POST("/weather/evict-expired", jc.EvictExpiredWeatherForecasts)
+	jobsGroup.POST("/stations/evict-expired", jc.EvictExpiredStationSearches)
+	jobsGroup.POST("/conversations/evict-expired", jc.EvictExpiredConversations)
 }
 
-func (jc *JobsController) apiKeyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		apiKey := c.Request().Header.Get("X-API-Key")
-		if apiKey == "" {
-			// Also check Authorization header with Bearer format
-			auth := c.Request().Header.Get("Authorization")
-			if after, ok := strings.CutPrefix(auth, "Bearer "); ok {
-				apiKey = after
-			}
-		}
-
-		if apiKey != jc.apiKey {
-			jc.log.Warnf("Invalid API key attempt from %s", c.RealIP())
-			return c.JSON(http.StatusUnauthorized, map[string]string{
-				"error": "Invalid API key",
-			})
-		}
-
-		return next(c)
+// SendTideExtremesToAllUsers sends the reports, with ?force=true also those of spots with suspect tides
+func (jc *JobsController) SendTideExtremesToAllUsers(c echo.Context) error {
+	force, err := parseForce(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  "error",
+			"message": "Invalid force, use true or false",
+			"error":   err.Error(),
+		})
 	}
-}
 
-func (jc *JobsController) SendTideExtremesToAllUsers(c echo.Context) error {
-	jc.log.Info("Received request to send tide extremes to all users")
+	jc.log.Infof("Received request to send tide extremes to all users (force=%t)", force)
 
-	err := jc.jobsService.SendTideExtremesToAllUsers()
+	err = jc.jobsService.SendTideExtremesToAllUsers(c.Request().Context(), force)
 	if err != nil {
 		jc.log.Errorf("Failed to send tide extremes to all users: %v", err)
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
@@ -72,10 +72,33 @@ func (jc *JobsController) SendTideExtremesToAllUsers(c echo.Context) error {
 	})
 }
 
+// SendDailyNotifications sends the notifications, with ?force=true also those of spots with suspect tides. With
+// ?hourly=true it only sends those whose notify hour is the current one, for the job to be scheduled every hour.
 func (jc *JobsController) SendDailyNotifications(c echo.Context) error {
-	jc.log.Info("Received request to send daily tide notifications (v2)")
+	force, err := parseForce(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  "error",
+			"message": "Invalid force, use true or false",
+			"error":   err.Error(),
+		})
+	}
+
+	hourly := false
+	if hourlyParam := c.QueryParam("hourly"); hourlyParam != "" {
+		hourly, err = strconv.ParseBool(hourlyParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"status":  "error",
+				"message": "Invalid hourly, use true or false",
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	jc.log.Infof("Received request to send daily tide notifications (v2, force=%t, hourly=%t)", force, hourly)
 
-	successCount, err := jc.jobsService.SendDailyNotificationsV2()
+	successCount, err := jc.jobsService.SendDailyNotificationsV2(c.Request().Context(), force, hourly)
 	if err != nil {
 		jc.log.Errorf("Failed to send daily notifications: %v", err)
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
@@ -93,3 +116,132 @@ func (jc *JobsController) SendDailyNotifications(c echo.Context) error {
 	})
 }
 
+func (jc *JobsController) EvictExpiredTidePredictions(c echo.Context) error {
+	jc.log.Info("Received request to evict expired tide predictions")
+
+	deletedCount, err := jc.jobsService.EvictExpiredTidePredictions()
+	if err != nil {
+		jc.log.Errorf("Failed to evict expired tide predictions: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to evict expired tide predictions",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":       "success",
+		"deletedCount": strconv.FormatInt(deletedCount, 10),
+		"message":      "Expired tide predictions evicted successfully",
+	})
+}
+
+func (jc *JobsController) EvictExpiredWeatherForecasts(c echo.Context) error {
+	jc.log.Info("Received request to evict expired weather forecasts")
+
+	deletedCount, err := jc.jobsService.EvictExpiredWeatherForecasts()
+	if err != nil {
+		jc.log.Errorf("Failed to evict expired weather forecasts: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to evict expired weather forecasts",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":       "success",
+		"deletedCount": strconv.FormatInt(deletedCount, 10),
+		"message":      "Expired weather forecasts evicted successfully",
+	})
+}
+
+func (jc *JobsController) EvictExpiredStationSearches(c echo.Context) error {
+	jc.log.Info("Received request to evict expired station searches")
+
+	deletedCount, err := jc.jobsService.EvictExpiredStationSearches()
+	if err != nil {
+		jc.log.Errorf("Failed to evict expired station searches: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to evict expired station searches",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":       "success",
+		"deletedCount": strconv.FormatInt(deletedCount, 10),
+		"message":      "Expired station searches evicted successfully",
+	})
+}
+
+func (jc *JobsController) EvictExpiredConversations(c echo.Context) error {
+	jc.log.Info("Received request to evict expired conversations")
+
+	deletedCount, err := jc.jobsService.EvictExpiredConversations()
+	if err != nil {
+		jc.log.Errorf("Failed to evict expired conversations: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to evict expired conversations",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":       "success",
+		"deletedCount": strconv.FormatInt(deletedCount, 10),
+		"message":      "Expired conversations evicted successfully",
+	})
+}
+
+// RefreshTides forces a refetch of the tides for the `spot` (slug, defaults to the default spot)
+// and `date` (defaults to today in the spot's timezone) query parameters
+func (jc *JobsController) RefreshTides(c echo.Context) error {
+	spotSlug := c.QueryParam("spot")
+	if spotSlug == "" {
+		spotSlug = spotModels.DefaultSpotSlug
+	}
+
+	jc.log.Infof("Received request to refresh tides for spot %s", spotSlug)
+
+	err := jc.jobsService.RefreshTides(c.Request().Context(), spotSlug, c.QueryParam("date"))
+	var dateErr *common.DateError
+	if errors.As(err, &dateErr) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  "error",
+			"message": "Invalid date",
+			"error":   err.Error(),
+		})
+	}
+	if err != nil {
+		jc.log.Errorf("Failed to refresh tides: %v", err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to refresh tides",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  "success",
+		"message": "Tides refreshed successfully",
+	})
+}
+
+func (jc *JobsController) GetTidesProvidersHealth(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":    "success",
+		"providers": jc.providerChain.Health(),
+	})
+}
+
+// parseForce reads the ?force= override of the suspect tides check
+func parseForce(c echo.Context) (bool, error) {
+	forceParam := c.QueryParam("force")
+	if forceParam == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(forceParam)
+}
@@ -1,66 +1,83 @@
 package jobs
 
 import (
+	"context"
 	"fmt"
 	"tidebot/pkg/common"
+	conversationRepos "tidebot/pkg/conversations/repositories"
+	notificationModels "tidebot/pkg/notifications/models"
 	"tidebot/pkg/notifications/repositories"
+	spotModels "tidebot/pkg/spots/models"
+	spotRepos "tidebot/pkg/spots/repositories"
+	stationSearchRepos "tidebot/pkg/stationsearches/repositories"
+	tidePredictionRepos "tidebot/pkg/tidepredictions/repositories"
 	"tidebot/pkg/users/services"
+	weatherForecastRepos "tidebot/pkg/weatherforecasts/repositories"
 	"tidebot/pkg/whatsapp"
 	"tidebot/pkg/worldtides"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type JobsService interface {
-	SendTideExtremesToAllUsers() error
-	SendDailyNotificationsV2() (int, error)
+	// SendTideExtremesToAllUsers holds back the reports of spots with suspect tides unless forced
+	SendTideExtremesToAllUsers(ctx context.Context, force bool) error
+	// SendDailyNotificationsV2 holds back the notifications of spots with suspect tides unless forced. Hourly runs
+	// only send the notifications due in the current hour of their spot's time zone.
+	SendDailyNotificationsV2(ctx context.Context, force bool, hourly bool) (int, error)
+	EvictExpiredTidePredictions() (int64, error)
+	EvictExpiredWeatherForecasts() (int64, error)
+	EvictExpiredStationSearches() (int64, error)
+	EvictExpiredConversations() (int64, error)
+	RefreshTides(ctx context.Context, spotSlug string, date string) error
 }
 
 type jobsServiceImpl struct {
 	userService                        services.UserService
 	notificationSubscriptionRepository repositories.NotificationSubscriptionRepository
+	spotRepository                     spotRepos.SpotRepository
+	tidePredictionRepository           tidePredictionRepos.TidePredictionRepository
+	weatherForecastRepository          weatherForecastRepos.WeatherForecastRepository
+	stationSearchRepository            stationSearchRepos.StationSearchRepository
+	conversationRepository             conversationRepos.ConversationRepository
 	whatsappService                    whatsapp.WhatsAppService
-	worldTidesClient                   worldtides.WorldTidesClient
+	tidesClient                        worldtides.WorldTidesClient
+	adminPhoneNumbers                  []string
 	log                                echo.Logger
 }
 
 func NewJobsService(
 	userService services.UserService,
 	notificationSubscriptionRepository repositories.NotificationSubscriptionRepository,
+	spotRepository spotRepos.SpotRepository,
+	tidePredictionRepository tidePredictionRepos.TidePredictionRepository,
+	weatherForecastRepository weatherForecastRepos.WeatherForecastRepository,
+	stationSearchRepository stationSearchRepos.StationSearchRepository,
+	conversationRepository conversationRepos.ConversationRepository,
 	whatsappService whatsapp.WhatsAppService,
-	worldTidesClient worldtides.WorldTidesClient,
+	tidesClient worldtides.WorldTidesClient,
+	adminPhoneNumbers []string,
 	log echo.Logger,
 ) JobsService {
 	return &jobsServiceImpl{
 		userService:                        userService,
 		notificationSubscriptionRepository: notificationSubscriptionRepository,
+		spotRepository:                     spotRepository,
+		tidePredictionRepository:           tidePredictionRepository,
+		weatherForecastRepository:          weatherForecastRepository,
+		stationSearchRepository:            stationSearchRepository,
+		conversationRepository:             conversationRepository,
 		whatsappService:                    whatsappService,
-		worldTidesClient:                   worldTidesClient,
+		tidesClient:                        tidesClient,
+		adminPhoneNumbers:                  adminPhoneNumbers,
 		log:                                log,
 	}
 }
 
-func (j *jobsServiceImpl) SendTideExtremesToAllUsers() error {
+func (j *jobsServiceImpl) SendTideExtremesToAllUsers(ctx context.Context, force bool) error {
 	j.log.Info("Starting job: Send tide extremes to all users")
 
-	today := common.Today()
-	j.log.Debugf("Fetching tide extremes for date: %s", today)
-
-	// Fetch tide extremes from WorldTides API
-	tidesResponse, err := j.worldTidesClient.GetTides(today)
-	if err != nil {
-		return fmt.Errorf("failed to fetch tide extremes: %w", err)
-	}
-
-	j.log.Debugf("Received %d tide extremes for %s", len(tidesResponse.Extremes), today)
-
-	// Debug log all extremes received
-	for i, extreme := range tidesResponse.Extremes {
-		localTime := extreme.Time().Format("2006-01-02 15:04:05 MST")
-		j.log.Debugf("Extreme %d: %s tide at %s (%.4fm) - Unix: %d, Date field: %s",
-			i+1, extreme.Type, localTime, extreme.Height, extreme.Dt, extreme.Date)
-	}
-
 	// Get all users with enabled subscriptions
 	subscriptions, err := j.notificationSubscriptionRepository.GetEnabledSubscriptions()
 	if err != nil {
@@ -72,6 +89,8 @@ func (j *jobsServiceImpl) SendTideExtremesToAllUsers() error {
 	// Send tide extremes to each subscribed user
 	successCount := 0
 	errorCount := 0
+	heldBackCount := 0
+	spotTides := newSpotTidesLoader(ctx, j, worldtides.JobTrigger("send-tide-extremes"), force)
 
 	for _, subscription := range subscriptions {
 		// Get user details to get phone number
@@ -82,9 +101,22 @@ func (j *jobsServiceImpl) SendTideExtremesToAllUsers() error {
 			continue
 		}
 
+		spot, tidesResponse, today, err := spotTides.load(subscription.SpotID, user.Datum)
+		if err != nil {
+			j.log.Errorf("Failed to load tides for subscription ID=%d, SpotID=%d: %v", subscription.ID, subscription.SpotID, err)
+			errorCount++
+			continue
+		}
+
+		if spotTides.heldBack(subscription.SpotID, user.Datum) {
+			j.log.Warnf("Holding back the report of user ID=%d, the tides of spot %s look wrong", subscription.UserID, spot.Slug)
+			heldBackCount++
+			continue
+		}
+
 		j.log.Debugf("Sending tide extremes to subscribed user ID=%d, phone=%s", subscription.UserID, user.PhoneNumber)
 
-		err = j.whatsappService.SendTideExtremesMessage(user.PhoneNumber, tidesResponse.Extremes, today)
+		err = j.whatsappService.SendTideExtremesMessage(user.PhoneNumber, spot, tidesResponse, today)
 		if err != nil {
 			j.log.Errorf("Failed to send tide extremes to user ID=%d: %v", subscription.UserID, err)
 			errorCount++
@@ -94,28 +126,17 @@ func (j *jobsServiceImpl) SendTideExtremesToAllUsers() error {
 		}
 	}
 
-	j.log.Infof("Job completed: %d successful, %d errors out of %d subscribed users", successCount, errorCount, len(subscriptions))
+	j.log.Infof("Job completed: %d successful, %d errors, %d held back out of %d subscribed users", successCount, errorCount, heldBackCount, len(subscriptions))
 
-	if errorCount > 0 {
-		return fmt.Errorf("job completed with %d errors out of %d subscribed users", errorCount, len(subscriptions))
+	if errorCount > 0 || heldBackCount > 0 {
+		return fmt.Errorf("job completed with %d errors and %d reports held back for suspect tides out of %d subscribed users", errorCount, heldBackCount, len(subscriptions))
 	}
 
 	return nil
 }
 
-func (j *jobsServiceImpl) SendDailyNotificationsV2() (int, error) {
-	j.log.Info("Starting job: Send daily tide notifications (v2)")
-
-	today := common.Today()
-	j.log.Debugf("Fetching tide extremes for date: %s", today)
-
-	// Fetch tide extremes from WorldTides API
-	tidesResponse, err := j.worldTidesClient.GetTides(today)
-	if err != nil {
-		return 0, fmt.Errorf("failed to fetch tide extremes: %w", err)
-	}
-
-	j.log.Debugf("Received %d tide extremes for %s", len(tidesResponse.Extremes), today)
+func (j *jobsServiceImpl) SendDailyNotificationsV2(ctx context.Context, force bool, hourly bool) (int, error) {
+	j.log.Infof("Starting job: Send daily tide notifications (v2, hourly=%t)", hourly)
 
 	// Get all users with enabled subscriptions
 	subscriptions, err := j.notificationSubscriptionRepository.GetEnabledSubscriptions()
@@ -128,8 +149,26 @@ func (j *jobsServiceImpl) SendDailyNotificationsV2() (int, error) {
 	// Send daily notifications to each subscribed user
 	successCount := 0
 	errorCount := 0
+	heldBackCount := 0
+	notDueCount := 0
+	spotTides := newSpotTidesLoader(ctx, j, worldtides.JobTrigger("daily-notifications"), force)
+	spotLocations := make(map[int]*time.Location)
+	now := time.Now()
 
 	for _, subscription := range subscriptions {
+		if hourly {
+			due, err := j.isDue(subscription, spotLocations, now)
+			if err != nil {
+				j.log.Errorf("Failed to check the notify hour of subscription ID=%d, SpotID=%d: %v", subscription.ID, subscription.SpotID, err)
+				errorCount++
+				continue
+			}
+			if !due {
+				notDueCount++
+				continue
+			}
+		}
+
 		// Get user details to get phone number and name
 		user, err := j.userService.GetUserByID(subscription.UserID)
 		if err != nil {
@@ -138,6 +177,19 @@ func (j *jobsServiceImpl) SendDailyNotificationsV2() (int, error) {
 			continue
 		}
 
+		spot, tidesResponse, _, err := spotTides.load(subscription.SpotID, user.Datum)
+		if err != nil {
+			j.log.Errorf("Failed to load tides for subscription ID=%d, SpotID=%d: %v", subscription.ID, subscription.SpotID, err)
+			errorCount++
+			continue
+		}
+
+		if spotTides.heldBack(subscription.SpotID, user.Datum) {
+			j.log.Warnf("Holding back the report of user ID=%d, the tides of spot %s look wrong", subscription.UserID, spot.Slug)
+			heldBackCount++
+			continue
+		}
+
 		// Use name if available, otherwise use phone number
 		userName := user.PhoneNumber
 		if user.Name != nil && *user.Name != "" {
@@ -146,7 +198,7 @@ func (j *jobsServiceImpl) SendDailyNotificationsV2() (int, error) {
 
 		j.log.Debugf("Sending daily notification to subscribed user ID=%d, phone=%s, name=%s", subscription.UserID, user.PhoneNumber, userName)
 
-		err = j.whatsappService.SendDailyTideNotification(user.PhoneNumber, userName, tidesResponse.Extremes)
+		err = j.whatsappService.SendDailyTideNotification(ctx, user.PhoneNumber, userName, spot, tidesResponse)
 		if err != nil {
 			j.log.Errorf("Failed to send daily notification to user ID=%d: %v", subscription.UserID, err)
 			errorCount++
@@ -156,11 +208,234 @@ func (j *jobsServiceImpl) SendDailyNotificationsV2() (int, error) {
 		}
 	}
 
-	j.log.Infof("Daily notifications job completed: %d successful, %d errors out of %d subscribed users", successCount, errorCount, len(subscriptions))
+	j.log.Infof("Daily notifications job completed: %d successful, %d errors, %d held back, %d not due out of %d subscribed users", successCount, errorCount, heldBackCount, notDueCount, len(subscriptions))
 
-	if errorCount > 0 {
-		return successCount, fmt.Errorf("daily notifications job completed with %d errors out of %d subscribed users", errorCount, len(subscriptions))
+	if errorCount > 0 || heldBackCount > 0 {
+		return successCount, fmt.Errorf("daily notifications job completed with %d errors and %d notifications held back for suspect tides out of %d subscribed users", errorCount, heldBackCount, len(subscriptions))
 	}
 
 	return successCount, nil
 }
+
+// isDue tells whether now is the subscription's notify hour in its spot's time zone, the locations of the spots
+// being kept in spotLocations for the rest of the run
+func (j *jobsServiceImpl) isDue(subscription notificationModels.NotificationSubscription, spotLocations map[int]*time.Location, now time.Time) (bool, error) {
+	location, exists := spotLocations[subscription.SpotID]
+	if !exists {
+		spot, err := j.spotRepository.GetByID(subscription.SpotID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get spot: %w", err)
+		}
+		location = spot.Location()
+		spotLocations[subscription.SpotID] = location
+	}
+
+	notifyHour := notificationModels.DefaultNotifyHour
+	if subscription.NotifyHour != nil {
+		notifyHour = *subscription.NotifyHour
+	}
+
+	return now.In(location).Hour() == notifyHour, nil
+}
+
+func (j *jobsServiceImpl) EvictExpiredTidePredictions() (int64, error) {
+	j.log.Info("Starting job: Evict expired tide predictions")
+
+	deletedCount, err := j.tidePredictionRepository.DeleteExpired()
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict expired tide predictions: %w", err)
+	}
+
+	j.log.Infof("Evicted %d expired tide predictions", deletedCount)
+	return deletedCount, nil
+}
+
+func (j *jobsServiceImpl) EvictExpiredWeatherForecasts() (int64, error) {
+	j.log.Info("Starting job: Evict expired weather forecasts")
+
+	deletedCount, err := j.weatherForecastRepository.DeleteExpired()
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict expired weather forecasts: %w", err)
+	}
+
+	j.log.Infof("Evicted %d expired weather forecasts", deletedCount)
+	return deletedCount, nil
+}
+
+func (j *jobsServiceImpl) EvictExpiredStationSearches() (int64, error) {
+	j.log.Info("Starting job: Evict expired station searches")
+
+	deletedCount, err := j.stationSearchRepository.DeleteExpired()
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict expired station searches: %w", err)
+	}
+
+	j.log.Infof("Evicted %d expired station searches", deletedCount)
+	return deletedCount, nil
+}
+
+func (j *jobsServiceImpl) EvictExpiredConversations() (int64, error) {
+	j.log.Info("Starting job: Evict expired conversations")
+
+	deletedCount, err := j.conversationRepository.DeleteExpired()
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict expired conversations: %w", err)
+	}
+
+	j.log.Infof("Evicted %d expired conversations", deletedCount)
+	return deletedCount, nil
+}
+
+// RefreshTides drops the cached prediction for the spot and date and fetches it again. The date, such as
+// "2025-12-24" or "tomorrow", is read relative to today in the spot's timezone, and an empty one means today.
+// Dates that can't be read are a *common.DateError.
+func (j *jobsServiceImpl) RefreshTides(ctx context.Context, spotSlug string, dateParam string) error {
+	spot, err := j.spotRepository.GetBySlug(spotSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get spot: %w", err)
+	}
+
+	date := common.TodayIn(spot.Location())
+	if dateParam != "" {
+		date, err = common.ParseDate(dateParam, spot.Location())
+		if err != nil {
+			return err
+		}
+	}
+
+	j.log.Infof("Starting job: Refresh tides for spot %s on %s", spotSlug, date.Format("2006-01-02"))
+
+	// Users can pick any datum, so all of them are invalidated
+	for _, datum := range worldtides.Datums {
+		err = j.tidePredictionRepository.Delete(worldtides.CacheKey(worldtides.WithDatum(spot, &datum), date))
+		if err != nil {
+			return fmt.Errorf("failed to invalidate cached tides in %s: %w", datum, err)
+		}
+	}
+
+	_, err = j.tidesClient.GetTides(ctx, spot, date, worldtides.AdminTrigger("refresh-tides"))
+	if err != nil {
+		return fmt.Errorf("failed to fetch tides: %w", err)
+	}
+
+	j.log.Infof("Refreshed tides for spot %s on %s", spotSlug, date.Format("2006-01-02"))
+	return nil
+}
+
+type spotTides struct {
+	spot          spotModels.Spot
+	tidesResponse *worldtides.WorldTidesResponse
+	today         time.Time
+	err           error
+}
+
+// spotTidesKey identifies the tides of a spot in a datum, an empty datum being the spot's own
+type spotTidesKey struct {
+	spotID int
+	datum  string
+}
+
+func newSpotTidesKey(spotID int, datum *string) spotTidesKey {
+	key := spotTidesKey{spotID: spotID}
+	if datum != nil {
+		key.datum = *datum
+	}
+	return key
+}
+
+// spotTidesLoader fetches today's tides once per spot and datum during a single job run
+type spotTidesLoader struct {
+	ctx     context.Context
+	jobs    *jobsServiceImpl
+	trigger worldtides.Trigger
+	// force sends the reports even when the tides are suspect
+	force   bool
+	loaded  map[spotTidesKey]spotTides
+	alerted map[spotTidesKey]bool
+}
+
+func newSpotTidesLoader(ctx context.Context, jobs *jobsServiceImpl, trigger worldtides.Trigger, force bool) *spotTidesLoader {
+	return &spotTidesLoader{
+		ctx:     ctx,
+		jobs:    jobs,
+		trigger: trigger,
+		force:   force,
+		loaded:  make(map[spotTidesKey]spotTides),
+		alerted: make(map[spotTidesKey]bool),
+	}
+}
+
+// load returns the spot's tides in the given datum, or in the spot's own if datum is nil
+func (l *spotTidesLoader) load(spotID int, datum *string) (spotModels.Spot, *worldtides.WorldTidesResponse, time.Time, error) {
+	key := newSpotTidesKey(spotID, datum)
+
+	if cached, exists := l.loaded[key]; exists {
+		return cached.spot, cached.tidesResponse, cached.today, cached.err
+	}
+
+	result := l.fetch(spotID, datum)
+	l.loaded[key] = result
+
+	return result.spot, result.tidesResponse, result.today, result.err
+}
+
+// heldBack reports whether the loaded tides were flagged as suspect and their reports must not be sent
+func (l *spotTidesLoader) heldBack(spotID int, datum *string) bool {
+	key := newSpotTidesKey(spotID, datum)
+	loaded := l.loaded[key]
+	if loaded.tidesResponse == nil || !loaded.tidesResponse.IsSuspect() {
+		return false
+	}
+
+	if !l.alerted[key] {
+		l.alerted[key] = true
+		l.alert(loaded)
+	}
+
+	return !l.force
+}
+
+// alert logs the anomalies once per spot and datum and, unless the job was forced, tells the admins
+func (l *spotTidesLoader) alert(loaded spotTides) {
+	worldtides.LogAnomalies(l.jobs.log, loaded.tidesResponse.Source, loaded.spot, loaded.today, loaded.tidesResponse.Anomalies)
+
+	if l.force {
+		l.jobs.log.Warnf("Sending the reports of spot %s despite suspect tides, the job was forced", loaded.spot.Slug)
+		return
+	}
+
+	for _, phoneNumber := range l.jobs.adminPhoneNumbers {
+		err := l.jobs.whatsappService.SendSuspectTidesAlert(phoneNumber, l.trigger.Ref, loaded.spot, loaded.tidesResponse, loaded.today)
+		if err != nil {
+			l.jobs.log.Errorf("Failed to alert admin %s about suspect tides of spot %s: %v", phoneNumber, loaded.spot.Slug, err)
+		}
+	}
+}
+
+func (l *spotTidesLoader) fetch(spotID int, datum *string) spotTides {
+	spot, err := l.jobs.spotRepository.GetByID(spotID)
+	if err != nil {
+		return spotTides{err: fmt.Errorf("failed to get spot: %w", err)}
+	}
+	spot = worldtides.WithDatum(spot, datum)
+
+	today := common.TodayIn(spot.Location())
+	l.jobs.log.Debugf("Fetching tide extremes for spot %s and date: %s", spot.Slug, today)
+
+	// Fetch tide extremes from the tides providers
+	tidesResponse, err := l.jobs.tidesClient.GetTides(l.ctx, spot, today, l.trigger)
+	if err != nil {
+		return spotTides{spot: spot, today: today, err: fmt.Errorf("failed to fetch tide extremes: %w", err)}
+	}
+
+	l.jobs.log.Debugf("Received %d tide extremes for %s on %s from %s", len(tidesResponse.Extremes), spot.Slug, today, tidesResponse.Source)
+
+	// Debug log all extremes received
+	for i, extreme := range tidesResponse.Extremes {
+		localTime := extreme.Time().In(spot.Location()).Format("2006-01-02 15:04:05 MST")
+		l.jobs.log.Debugf("Extreme %d: %s tide at %s (%.4fm) - Unix: %d, Date field: %s",
+			i+1, extreme.Type, localTime, extreme.Height, extreme.Dt, extreme.Date)
+	}
+
+	return spotTides{spot: spot, tidesResponse: tidesResponse, today: today}
+}
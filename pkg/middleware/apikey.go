@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIKey only lets through requests carrying the API key in the X-API-Key header
+// or as a bearer token in the Authorization header
+func APIKey(apiKey string, log echo.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestApiKey := c.Request().Header.Get("X-API-Key")
+			if requestApiKey == "" {
+				// Also check Authorization header with Bearer format
+				auth := c.Request().Header.Get("Authorization")
+				if after, ok := strings.CutPrefix(auth, "Bearer "); ok {
+					requestApiKey = after
+				}
+			}
+
+			if requestApiKey != apiKey {
+				log.Warnf("Invalid API key attempt from %s", c.RealIP())
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "Invalid API key",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
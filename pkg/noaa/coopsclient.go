@@ -0,0 +1,192 @@
+package noaa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const CoopsAPIURL = "https://api.tidesandcurrents.noaa.gov/api/prod/datagetter"
+
+// coopsDatums maps the supported datums to NOAA's names. US charts are referenced to MLLW,
+// and NOAA doesn't publish the lowest astronomical tide.
+var coopsDatums = map[string]string{
+	worldtides.DatumMLS:  "MSL",
+	worldtides.DatumCD:   "MLLW",
+	worldtides.DatumMLLW: "MLLW",
+}
+
+type coopsPrediction struct {
+	Time  string `json:"t"`
+	Value string `json:"v"`
+	Type  string `json:"type,omitempty"`
+}
+
+type coopsResponse struct {
+	Predictions []coopsPrediction `json:"predictions"`
+	Error       *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type coopsClientImpl struct {
+	baseURL    string
+	httpClient *http.Client
+	log        echo.Logger
+}
+
+// NewCoopsClient fetches predictions from NOAA CO-OPS for spots that have a NOAA station assigned
+func NewCoopsClient(baseURL string, log echo.Logger) worldtides.WorldTidesClient {
+	return &coopsClientImpl{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		log: log,
+	}
+}
+
+func (c *coopsClientImpl) GetTides(ctx context.Context, spot models.Spot, date time.Time, trigger worldtides.Trigger) (*worldtides.WorldTidesResponse, error) {
+	if spot.NoaaStationID == nil || *spot.NoaaStationID == "" {
+		return nil, fmt.Errorf("spot %s has no NOAA station: %w", spot.Slug, worldtides.ErrNotSupported)
+	}
+
+	datum, exists := coopsDatums[worldtides.SpotDatum(spot)]
+	if !exists {
+		return nil, fmt.Errorf("NOAA CO-OPS has no %s datum: %w", worldtides.SpotDatum(spot), worldtides.ErrNotSupported)
+	}
+
+	c.log.Debugf("Getting NOAA CO-OPS tides for spot %s (station %s) and date: %s", spot.Slug, *spot.NoaaStationID, date.Format("2006-01-02"))
+
+	location := spot.Location()
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	to := from.AddDate(0, 0, 1)
+
+	heights, err := c.getPredictions(ctx, *spot.NoaaStationID, datum, from, to, "30")
+	if err != nil {
+		return nil, err
+	}
+
+	extremes, err := c.getPredictions(ctx, *spot.NoaaStationID, datum, from, to, "hilo")
+	if err != nil {
+		return nil, err
+	}
+
+	response := &worldtides.WorldTidesResponse{
+		Status:        200,
+		RequestDatum:  worldtides.SpotDatum(spot),
+		ResponseDatum: datum,
+		RequestLat:    spot.Latitude,
+		RequestLon:    spot.Longitude,
+		Station:       *spot.NoaaStationID,
+		Copyright:     "NOAA CO-OPS",
+		Timezone:      location.String(),
+	}
+
+	for _, prediction := range heights {
+		height, t, err := parsePrediction(prediction)
+		if err != nil {
+			return nil, err
+		}
+		if t.Before(from) || !t.Before(to) {
+			continue
+		}
+		response.Heights = append(response.Heights, worldtides.Height{Dt: t.Unix(), Date: t.In(location).Format("2006-01-02T15:04-0700"), Height: height})
+	}
+
+	for _, prediction := range extremes {
+		height, t, err := parsePrediction(prediction)
+		if err != nil {
+			return nil, err
+		}
+		if t.Before(from) || !t.Before(to) {
+			continue
+		}
+
+		extremeType := "Low"
+		if prediction.Type == "H" {
+			extremeType = "High"
+		}
+
+		response.Extremes = append(response.Extremes, worldtides.Extreme{Dt: t.Unix(), Date: t.In(location).Format("2006-01-02T15:04-0700"), Height: height, Type: extremeType})
+	}
+
+	return response, nil
+}
+
+func (c *coopsClientImpl) GetTidesRange(ctx context.Context, spot models.Spot, from time.Time, days int, trigger worldtides.Trigger) ([]*worldtides.WorldTidesResponse, error) {
+	return worldtides.GetTidesDayByDay(ctx, c, spot, from, days, trigger)
+}
+
+func (c *coopsClientImpl) getPredictions(ctx context.Context, station string, datum string, from time.Time, to time.Time, interval string) ([]coopsPrediction, error) {
+	params := url.Values{}
+	params.Set("product", "predictions")
+	params.Set("application", "tidebot")
+	params.Set("station", station)
+	params.Set("begin_date", from.UTC().Format("20060102 15:04"))
+	params.Set("end_date", to.UTC().Format("20060102 15:04"))
+	params.Set("datum", datum)
+	params.Set("time_zone", "gmt")
+	params.Set("units", "metric")
+	params.Set("interval", interval)
+	params.Set("format", "json")
+
+	requestURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
+
+	c.log.Debugf("Making NOAA CO-OPS API request to: %s", requestURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("NOAA CO-OPS API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var coopsResp coopsResponse
+	err = json.Unmarshal(body, &coopsResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if coopsResp.Error != nil {
+		return nil, fmt.Errorf("NOAA CO-OPS API error: %s", coopsResp.Error.Message)
+	}
+
+	return coopsResp.Predictions, nil
+}
+
+func parsePrediction(prediction coopsPrediction) (float64, time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02 15:04", prediction.Time, time.UTC)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to parse prediction time %s: %w", prediction.Time, err)
+	}
+
+	height, err := strconv.ParseFloat(prediction.Value, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to parse prediction height %s: %w", prediction.Value, err)
+	}
+
+	return height, t, nil
+}
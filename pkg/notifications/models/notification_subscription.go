@@ -2,10 +2,16 @@ package models
 
 import "time"
 
+// DefaultNotifyHour is the hour daily notifications are sent at when the job runs hourly and the user hasn't picked one,
+// close to the 9:30 the job ran at once a day before
+const DefaultNotifyHour = 9
+
 type NotificationSubscription struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	Enabled   bool      `json:"enabled"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	SpotID     int       `json:"spot_id"`
+	Enabled    bool      `json:"enabled"`
+	NotifyHour *int      `json:"notify_hour"` // in the spot's time zone when the job runs hourly, nil for the default hour
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
\ No newline at end of file
@@ -9,11 +9,13 @@ import (
 )
 
 type NotificationSubscriptionRepository interface {
-	CreateSubscription(userID int) error
+	CreateSubscription(userID int, spotID int) error
 	GetSubscriptionByUserID(userID int) (*models.NotificationSubscription, error)
 	EnableSubscription(userID int) error
 	DisableSubscription(userID int) error
 	GetEnabledSubscriptions() ([]models.NotificationSubscription, error)
+	// UpdateNotifyHour sets the hour the daily notification is sent at, nil for the default hour
+	UpdateNotifyHour(userID int, hour *int) error
 }
 
 type notificationSubscriptionRepositoryImpl struct {
@@ -28,26 +30,26 @@ func NewNotificationSubscriptionRepository(db *sql.DB, log echo.Logger) Notifica
 	}
 }
 
-func (r *notificationSubscriptionRepositoryImpl) CreateSubscription(userID int) error {
+func (r *notificationSubscriptionRepositoryImpl) CreateSubscription(userID int, spotID int) error {
 	query := `
-		INSERT INTO notification_subscriptions (user_id, enabled) 
-		VALUES (?, ?) 
-		ON CONFLICT(user_id) DO UPDATE SET enabled = ?, updated_at = CURRENT_TIMESTAMP
+		INSERT INTO notification_subscriptions (user_id, spot_id, enabled) 
+		VALUES (?, ?, ?) 
+		ON CONFLICT(user_id) DO UPDATE SET spot_id = ?, enabled = ?, updated_at = CURRENT_TIMESTAMP
 	`
 	
-	_, err := r.db.Exec(query, userID, true, true)
+	_, err := r.db.Exec(query, userID, spotID, true, spotID, true)
 	if err != nil {
 		r.log.Errorf("Failed to create subscription for user %d: %v", userID, err)
 		return fmt.Errorf("failed to create subscription: %w", err)
 	}
 	
-	r.log.Infof("Created/enabled subscription for user %d", userID)
+	r.log.Infof("Created/enabled subscription for user %d and spot %d", userID, spotID)
 	return nil
 }
 
 func (r *notificationSubscriptionRepositoryImpl) GetSubscriptionByUserID(userID int) (*models.NotificationSubscription, error) {
 	query := `
-		SELECT id, user_id, enabled, created_at, updated_at 
+		SELECT id, user_id, spot_id, enabled, notify_hour, created_at, updated_at 
 		FROM notification_subscriptions 
 		WHERE user_id = ?
 	`
@@ -56,7 +58,9 @@ func (r *notificationSubscriptionRepositoryImpl) GetSubscriptionByUserID(userID
 	err := r.db.QueryRow(query, userID).Scan(
 		&subscription.ID,
 		&subscription.UserID,
+		&subscription.SpotID,
 		&subscription.Enabled,
+		&subscription.NotifyHour,
 		&subscription.CreatedAt,
 		&subscription.UpdatedAt,
 	)
@@ -118,7 +122,7 @@ func (r *notificationSubscriptionRepositoryImpl) DisableSubscription(userID int)
 
 func (r *notificationSubscriptionRepositoryImpl) GetEnabledSubscriptions() ([]models.NotificationSubscription, error) {
 	query := `
-		SELECT id, user_id, enabled, created_at, updated_at 
+		SELECT id, user_id, spot_id, enabled, notify_hour, created_at, updated_at 
 		FROM notification_subscriptions 
 		WHERE enabled = ?
 	`
@@ -136,7 +140,9 @@ func (r *notificationSubscriptionRepositoryImpl) GetEnabledSubscriptions() ([]mo
 		err := rows.Scan(
 			&subscription.ID,
 			&subscription.UserID,
+			&subscription.SpotID,
 			&subscription.Enabled,
+			&subscription.NotifyHour,
 			&subscription.CreatedAt,
 			&subscription.UpdatedAt,
 		)
@@ -148,4 +154,26 @@ func (r *notificationSubscriptionRepositoryImpl) GetEnabledSubscriptions() ([]mo
 	}
 	
 	return subscriptions, nil
-}
\ No newline at end of file
+}
+
+func (r *notificationSubscriptionRepositoryImpl) UpdateNotifyHour(userID int, hour *int) error {
+	query := `
+		UPDATE notification_subscriptions
+		SET notify_hour = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = ?
+	`
+
+	result, err := r.db.Exec(query, hour, userID)
+	if err != nil {
+		r.log.Errorf("Failed to update notify hour of user %d: %v", userID, err)
+		return fmt.Errorf("failed to update notify hour: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("no subscription found for user %d", userID)
+	}
+
+	r.log.Infof("Updated notify hour of user %d: %v", userID, hour)
+	return nil
+}
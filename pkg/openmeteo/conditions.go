@@ -0,0 +1,61 @@
+package openmeteo
+
+import (
+	"strings"
+	"time"
+)
+
+// DayConditions holds the hourly marine weather of a spot's local day
+type DayConditions struct {
+	Date   string             `json:"date"`
+	Hourly []HourlyConditions `json:"hourly"`
+	// Source names the API that produced the forecast
+	Source string `json:"source,omitempty"`
+}
+
+// HourlyConditions is the weather at a full hour. Values are nil when the API has no data,
+// as happens with marine data close to the shore.
+type HourlyConditions struct {
+	Time time.Time `json:"time"`
+	// Wind in knots, direction in degrees the wind comes from
+	WindSpeed     *float64 `json:"wind_speed,omitempty"`
+	WindGusts     *float64 `json:"wind_gusts,omitempty"`
+	WindDirection *float64 `json:"wind_direction,omitempty"`
+	// Swell height in meters, period in seconds, direction in degrees the swell comes from
+	SwellHeight    *float64 `json:"swell_height,omitempty"`
+	SwellPeriod    *float64 `json:"swell_period,omitempty"`
+	SwellDirection *float64 `json:"swell_direction,omitempty"`
+	// WaterTemperature in °C
+	WaterTemperature *float64 `json:"water_temperature,omitempty"`
+}
+
+// At returns the conditions of the hour containing t, or nil if the day doesn't cover it
+func (d *DayConditions) At(t time.Time) *HourlyConditions {
+	for i := range d.Hourly {
+		if !t.Before(d.Hourly[i].Time) && t.Before(d.Hourly[i].Time.Add(time.Hour)) {
+			return &d.Hourly[i]
+		}
+	}
+	return nil
+}
+
+var compassPoints = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// CompassDegrees converts one of the 16 compass points, in any case, to degrees
+func CompassDegrees(point string) (float64, bool) {
+	for i, compassPoint := range compassPoints {
+		if strings.EqualFold(point, compassPoint) {
+			return float64(i) * 22.5, true
+		}
+	}
+	return 0, false
+}
+
+// CompassPoint converts a direction in degrees to one of the 16 compass points
+func CompassPoint(degrees float64) string {
+	index := int((degrees+11.25)/22.5) % len(compassPoints)
+	if index < 0 {
+		index += len(compassPoints)
+	}
+	return compassPoints[index]
+}
@@ -0,0 +1,124 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const GeocodingAPIURL = "https://geocoding-api.open-meteo.com/v1/search"
+
+// ErrPlaceNotFound is returned when no place matches the name
+var ErrPlaceNotFound = errors.New("place not found")
+
+// Place is a named position
+type Place struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type GeocodingClient interface {
+	// FindPlace returns the best match for a place name such as "Tarifa" or "Tarifa, Spain"
+	FindPlace(ctx context.Context, name string) (Place, error)
+}
+
+type geocodingResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Admin1    string  `json:"admin1"`
+		Country   string  `json:"country"`
+	} `json:"results"`
+	Error  bool   `json:"error"`
+	Reason string `json:"reason"`
+}
+
+type geocodingClientImpl struct {
+	geocodingURL string
+	httpClient   *http.Client
+	log          echo.Logger
+}
+
+func NewGeocodingClient(geocodingURL string, log echo.Logger) GeocodingClient {
+	return &geocodingClientImpl{
+		geocodingURL: geocodingURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		log: log,
+	}
+}
+
+func (c *geocodingClientImpl) FindPlace(ctx context.Context, name string) (Place, error) {
+	// The API only matches the place name, anything after a comma is left out
+	query, _, _ := strings.Cut(name, ",")
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return Place{}, ErrPlaceNotFound
+	}
+
+	params := url.Values{}
+	params.Set("name", query)
+	params.Set("count", "1")
+	params.Set("format", "json")
+
+	requestURL := fmt.Sprintf("%s?%s", c.geocodingURL, params.Encode())
+
+	c.log.Debugf("Making Open-Meteo geocoding request to: %s", requestURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return Place{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Place{}, fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Place{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var response geocodingResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return Place{}, fmt.Errorf("failed to unmarshal response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if response.Error || resp.StatusCode != http.StatusOK {
+		return Place{}, fmt.Errorf("Open-Meteo geocoding API error (status %d): %s", resp.StatusCode, response.Reason)
+	}
+
+	if len(response.Results) == 0 {
+		return Place{}, fmt.Errorf("%w: %s", ErrPlaceNotFound, name)
+	}
+
+	result := response.Results[0]
+
+	var label []string
+	for _, part := range []string{result.Name, result.Admin1, result.Country} {
+		if part != "" && !slices.Contains(label, part) {
+			label = append(label, part)
+		}
+	}
+
+	return Place{
+		Name:      strings.Join(label, ", "),
+		Latitude:  result.Latitude,
+		Longitude: result.Longitude,
+	}, nil
+}
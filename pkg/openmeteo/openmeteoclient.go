@@ -0,0 +1,229 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"tidebot/pkg/spots/models"
+	weatherForecastModels "tidebot/pkg/weatherforecasts/models"
+	"tidebot/pkg/weatherforecasts/repositories"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	ForecastAPIURL = "https://api.open-meteo.com/v1/forecast"
+	MarineAPIURL   = "https://marine-api.open-meteo.com/v1/marine"
+
+	// Source is reported in the conditions so the origin of the data is visible
+	Source = "Open-Meteo"
+
+	forecastVariables = "wind_speed_10m,wind_gusts_10m,wind_direction_10m"
+	marineVariables   = "swell_wave_height,swell_wave_period,swell_wave_direction,sea_surface_temperature"
+)
+
+type MarineWeatherClient interface {
+	GetConditions(ctx context.Context, spot models.Spot, date time.Time) (*DayConditions, error)
+}
+
+// hourlyResponse is the subset of the Open-Meteo forecast and marine responses used here
+type hourlyResponse struct {
+	Error  bool                  `json:"error"`
+	Reason string                `json:"reason"`
+	Hourly map[string]jsonValues `json:"hourly"`
+}
+
+// jsonValues holds one hourly series, either the times as strings or the values as numbers or nulls
+type jsonValues []json.RawMessage
+
+type marineWeatherClientImpl struct {
+	forecastURL               string
+	marineURL                 string
+	httpClient                *http.Client
+	weatherForecastRepository repositories.WeatherForecastRepository
+	cacheTTL                  time.Duration
+	log                       echo.Logger
+}
+
+// NewMarineWeatherClient fetches wind from the Open-Meteo forecast API and swell and water temperature
+// from its marine API. The URLs are configurable so a local stand-in can be used.
+func NewMarineWeatherClient(forecastURL string, marineURL string, weatherForecastRepository repositories.WeatherForecastRepository, cacheTTL time.Duration, log echo.Logger) MarineWeatherClient {
+	return &marineWeatherClientImpl{
+		forecastURL: forecastURL,
+		marineURL:   marineURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		weatherForecastRepository: weatherForecastRepository,
+		cacheTTL:                  cacheTTL,
+		log:                       log,
+	}
+}
+
+func (c *marineWeatherClientImpl) GetConditions(ctx context.Context, spot models.Spot, date time.Time) (*DayConditions, error) {
+	key := weatherForecastModels.NewWeatherForecastKey(spot.Latitude, spot.Longitude, date)
+
+	cached, exists := c.readCache(key)
+	if exists {
+		c.log.Debugf("Cache hit for conditions of %s on %s", spot.Slug, key.Date)
+		return cached, nil
+	}
+
+	c.log.Debugf("Getting conditions for spot %s and date: %s", spot.Slug, key.Date)
+
+	forecast, err := c.getHourly(ctx, c.forecastURL, spot, key.Date, forecastVariables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wind forecast: %w", err)
+	}
+
+	marine, err := c.getHourly(ctx, c.marineURL, spot, key.Date, marineVariables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get marine forecast: %w", err)
+	}
+
+	conditions, err := mergeHourly(spot, key.Date, forecast, marine)
+	if err != nil {
+		return nil, err
+	}
+
+	c.writeCache(key, conditions)
+
+	return conditions, nil
+}
+
+// readCache treats any cache failure as a miss
+func (c *marineWeatherClientImpl) readCache(key weatherForecastModels.WeatherForecastKey) (*DayConditions, bool) {
+	forecast, err := c.weatherForecastRepository.Get(key)
+	if err != nil {
+		c.log.Errorf("Failed to read weather forecast cache for %+v: %v", key, err)
+		return nil, false
+	}
+
+	if forecast == nil {
+		return nil, false
+	}
+
+	var conditions DayConditions
+	err = json.Unmarshal([]byte(forecast.Response), &conditions)
+	if err != nil {
+		c.log.Errorf("Failed to unmarshal cached weather forecast for %+v: %v", key, err)
+		return nil, false
+	}
+
+	return &conditions, true
+}
+
+func (c *marineWeatherClientImpl) writeCache(key weatherForecastModels.WeatherForecastKey, conditions *DayConditions) {
+	body, err := json.Marshal(conditions)
+	if err != nil {
+		c.log.Errorf("Failed to marshal weather forecast for %+v: %v", key, err)
+		return
+	}
+
+	err = c.weatherForecastRepository.Save(key, string(body), time.Now().Add(c.cacheTTL))
+	if err != nil {
+		c.log.Errorf("Failed to write weather forecast cache for %+v: %v", key, err)
+	}
+}
+
+func (c *marineWeatherClientImpl) getHourly(ctx context.Context, baseURL string, spot models.Spot, date string, variables string) (*hourlyResponse, error) {
+	params := url.Values{}
+	params.Set("latitude", strconv.FormatFloat(spot.Latitude, 'f', -1, 64))
+	params.Set("longitude", strconv.FormatFloat(spot.Longitude, 'f', -1, 64))
+	params.Set("hourly", variables)
+	params.Set("start_date", date)
+	params.Set("end_date", date)
+	params.Set("timezone", spot.Location().String())
+	params.Set("wind_speed_unit", "kn")
+
+	requestURL := fmt.Sprintf("%s?%s", baseURL, params.Encode())
+
+	c.log.Debugf("Making Open-Meteo API request to: %s", requestURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var response hourlyResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if response.Error || resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Open-Meteo API error (status %d): %s", resp.StatusCode, response.Reason)
+	}
+
+	return &response, nil
+}
+
+// mergeHourly joins the wind and marine series, both in the spot's local time, into the day's conditions
+func mergeHourly(spot models.Spot, date string, forecast *hourlyResponse, marine *hourlyResponse) (*DayConditions, error) {
+	location := spot.Location()
+	times := forecast.Hourly["time"]
+
+	conditions := &DayConditions{
+		Date:   date,
+		Hourly: make([]HourlyConditions, len(times)),
+		Source: Source,
+	}
+
+	for i, rawTime := range times {
+		var timeStr string
+		err := json.Unmarshal(rawTime, &timeStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal time %s: %w", string(rawTime), err)
+		}
+
+		t, err := time.ParseInLocation("2006-01-02T15:04", timeStr, location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse time %s: %w", timeStr, err)
+		}
+
+		conditions.Hourly[i] = HourlyConditions{
+			Time:             t,
+			WindSpeed:        forecast.value("wind_speed_10m", i),
+			WindGusts:        forecast.value("wind_gusts_10m", i),
+			WindDirection:    forecast.value("wind_direction_10m", i),
+			SwellHeight:      marine.value("swell_wave_height", i),
+			SwellPeriod:      marine.value("swell_wave_period", i),
+			SwellDirection:   marine.value("swell_wave_direction", i),
+			WaterTemperature: marine.value("sea_surface_temperature", i),
+		}
+	}
+
+	return conditions, nil
+}
+
+// value returns the i-th value of the series, nil when it's missing or null
+func (r *hourlyResponse) value(variable string, i int) *float64 {
+	values := r.Hourly[variable]
+	if i >= len(values) {
+		return nil
+	}
+
+	var value *float64
+	err := json.Unmarshal(values[i], &value)
+	if err != nil {
+		return nil
+	}
+
+	return value
+}
@@ -0,0 +1,212 @@
+package openmeteo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"tidebot/pkg/spots/models"
+	weatherForecastModels "tidebot/pkg/weatherforecasts/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	forecastResponse = `{
+		"hourly": {
+			"time": ["2024-06-21T00:00", "2024-06-21T01:00"],
+			"wind_speed_10m": [12.3, 14.1],
+			"wind_gusts_10m": [18.0, null],
+			"wind_direction_10m": [45, 50]
+		}
+	}`
+	marineResponse = `{
+		"hourly": {
+			"time": ["2024-06-21T00:00", "2024-06-21T01:00"],
+			"swell_wave_height": [1.2, null],
+			"swell_wave_period": [9.5, null],
+			"swell_wave_direction": [315, null],
+			"sea_surface_temperature": [19.4, null]
+		}
+	}`
+)
+
+// memoryWeatherForecastRepository keeps the cache in memory and ignores expiry
+type memoryWeatherForecastRepository struct {
+	forecasts map[weatherForecastModels.WeatherForecastKey]weatherForecastModels.WeatherForecast
+}
+
+func newMemoryWeatherForecastRepository() *memoryWeatherForecastRepository {
+	return &memoryWeatherForecastRepository{forecasts: make(map[weatherForecastModels.WeatherForecastKey]weatherForecastModels.WeatherForecast)}
+}
+
+func (r *memoryWeatherForecastRepository) Get(key weatherForecastModels.WeatherForecastKey) (*weatherForecastModels.WeatherForecast, error) {
+	forecast, exists := r.forecasts[key]
+	if !exists {
+		return nil, nil
+	}
+	return &forecast, nil
+}
+
+func (r *memoryWeatherForecastRepository) Save(key weatherForecastModels.WeatherForecastKey, response string, expiresAt time.Time) error {
+	r.forecasts[key] = weatherForecastModels.WeatherForecast{WeatherForecastKey: key, Response: response, ExpiresAt: expiresAt}
+	return nil
+}
+
+func (r *memoryWeatherForecastRepository) DeleteExpired() (int64, error) {
+	return 0, nil
+}
+
+// newOpenMeteoServer serves the forecast and marine responses, counting the requests it gets
+func newOpenMeteoServer(t *testing.T, forecast string, marine string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		query := r.URL.Query()
+		if query.Get("start_date") != "2024-06-21" || query.Get("end_date") != "2024-06-21" {
+			t.Errorf("unexpected dates %s to %s", query.Get("start_date"), query.Get("end_date"))
+		}
+		if query.Get("timezone") != "Atlantic/Canary" {
+			t.Errorf("timezone = %s, want Atlantic/Canary", query.Get("timezone"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/forecast":
+			if query.Get("wind_speed_unit") != "kn" {
+				t.Errorf("wind_speed_unit = %s, want kn", query.Get("wind_speed_unit"))
+			}
+			w.Write([]byte(forecast))
+		case "/marine":
+			w.Write([]byte(marine))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func newTestSpot() models.Spot {
+	return models.Spot{Slug: "el-cotillo", Latitude: 28.6833, Longitude: -14.0167, Timezone: "Atlantic/Canary"}
+}
+
+func TestGetConditionsParsesHourlySeries(t *testing.T) {
+	server, _ := newOpenMeteoServer(t, forecastResponse, marineResponse)
+	client := NewMarineWeatherClient(server.URL+"/forecast", server.URL+"/marine", newMemoryWeatherForecastRepository(), time.Hour, echo.New().Logger)
+	spot := newTestSpot()
+
+	conditions, err := client.GetConditions(context.Background(), spot, time.Date(2024, time.June, 21, 0, 0, 0, 0, spot.Location()))
+	if err != nil {
+		t.Fatalf("GetConditions() error = %v", err)
+	}
+
+	if conditions.Date != "2024-06-21" || conditions.Source != Source {
+		t.Errorf("got date %s and source %s, want 2024-06-21 and %s", conditions.Date, conditions.Source, Source)
+	}
+	if len(conditions.Hourly) != 2 {
+		t.Fatalf("got %d hours, want 2", len(conditions.Hourly))
+	}
+
+	wantTime := time.Date(2024, time.June, 21, 1, 0, 0, 0, spot.Location())
+	if !conditions.Hourly[1].Time.Equal(wantTime) {
+		t.Errorf("time = %s, want %s", conditions.Hourly[1].Time, wantTime)
+	}
+
+	tests := []struct {
+		name  string
+		value *float64
+		want  *float64
+	}{
+		{"wind speed", conditions.Hourly[0].WindSpeed, floatPointer(12.3)},
+		{"wind gusts", conditions.Hourly[0].WindGusts, floatPointer(18.0)},
+		{"wind direction", conditions.Hourly[0].WindDirection, floatPointer(45)},
+		{"swell height", conditions.Hourly[0].SwellHeight, floatPointer(1.2)},
+		{"swell period", conditions.Hourly[0].SwellPeriod, floatPointer(9.5)},
+		{"swell direction", conditions.Hourly[0].SwellDirection, floatPointer(315)},
+		{"water temperature", conditions.Hourly[0].WaterTemperature, floatPointer(19.4)},
+		{"null wind gusts", conditions.Hourly[1].WindGusts, nil},
+		{"null swell height", conditions.Hourly[1].SwellHeight, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (tt.value == nil) != (tt.want == nil) || (tt.value != nil && *tt.value != *tt.want) {
+				t.Errorf("got %v, want %v", formatPointer(tt.value), formatPointer(tt.want))
+			}
+		})
+	}
+}
+
+func TestGetConditionsUsesCache(t *testing.T) {
+	server, requests := newOpenMeteoServer(t, forecastResponse, marineResponse)
+	repository := newMemoryWeatherForecastRepository()
+	client := NewMarineWeatherClient(server.URL+"/forecast", server.URL+"/marine", repository, time.Hour, echo.New().Logger)
+	spot := newTestSpot()
+	date := time.Date(2024, time.June, 21, 0, 0, 0, 0, spot.Location())
+
+	first, err := client.GetConditions(context.Background(), spot, date)
+	if err != nil {
+		t.Fatalf("GetConditions() error = %v", err)
+	}
+
+	second, err := client.GetConditions(context.Background(), spot, date)
+	if err != nil {
+		t.Fatalf("GetConditions() error = %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests, want 2 for the first call and none for the second", got)
+	}
+	if len(repository.forecasts) != 1 {
+		t.Errorf("got %d cached forecasts, want 1", len(repository.forecasts))
+	}
+	if *second.Hourly[0].WindSpeed != *first.Hourly[0].WindSpeed || !second.Hourly[1].Time.Equal(first.Hourly[1].Time) {
+		t.Errorf("cached conditions %+v differ from fetched %+v", second.Hourly[0], first.Hourly[0])
+	}
+
+	for _, forecast := range repository.forecasts {
+		if ttl := time.Until(forecast.ExpiresAt); ttl <= 0 || ttl > time.Hour {
+			t.Errorf("cache expires in %s, want within an hour", ttl)
+		}
+	}
+}
+
+func TestGetConditionsDoesNotCacheErrors(t *testing.T) {
+	server, requests := newOpenMeteoServer(t, forecastResponse, `{"error": true, "reason": "No data is available for this location"}`)
+	repository := newMemoryWeatherForecastRepository()
+	client := NewMarineWeatherClient(server.URL+"/forecast", server.URL+"/marine", repository, time.Hour, echo.New().Logger)
+	spot := newTestSpot()
+	date := time.Date(2024, time.June, 21, 0, 0, 0, 0, spot.Location())
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetConditions(context.Background(), spot, date)
+		if err == nil {
+			t.Fatal("GetConditions() error = nil, want the API error")
+		}
+	}
+
+	if got := requests.Load(); got != 4 {
+		t.Errorf("got %d requests, want 4", got)
+	}
+	if len(repository.forecasts) != 0 {
+		t.Errorf("got %d cached forecasts, want none", len(repository.forecasts))
+	}
+}
+
+func floatPointer(value float64) *float64 {
+	return &value
+}
+
+func formatPointer(value *float64) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
@@ -0,0 +1,113 @@
+package sessions
+
+import (
+	"fmt"
+	"strings"
+	"tidebot/pkg/openmeteo"
+	spotModels "tidebot/pkg/spots/models"
+)
+
+type Level string
+
+const (
+	Low  Level = "low"
+	Mid  Level = "mid"
+	High Level = "high"
+)
+
+type Movement string
+
+const (
+	// AnyMovement matches both the rising and the falling tide
+	AnyMovement Movement = ""
+	Rising      Movement = "rising"
+	Falling     Movement = "falling"
+)
+
+// TideRule is a tide state a spot works in, such as "mid-rising" or just "high"
+type TideRule struct {
+	Level    Level
+	Movement Movement
+}
+
+func (r TideRule) String() string {
+	if r.Movement == AnyMovement {
+		return fmt.Sprintf("%s tide", r.Level)
+	}
+	return fmt.Sprintf("%s tide %s", r.Level, r.Movement)
+}
+
+// Preferences are the conditions a spot works best in. Empty fields don't constrain sessions.
+type Preferences struct {
+	Tides []TideRule
+	// WindDirections are the directions, in degrees, the wind should come from
+	WindDirections []float64
+	// MinWindSpeed and MaxWindSpeed are in knots
+	MinWindSpeed *float64
+	MaxWindSpeed *float64
+}
+
+// IsZero reports whether the spot has no preferences at all, in which case every daylight hour is as good as another
+func (p Preferences) IsZero() bool {
+	return len(p.Tides) == 0 && len(p.WindDirections) == 0 && p.MinWindSpeed == nil && p.MaxWindSpeed == nil
+}
+
+// NewPreferences parses the session preferences stored with the spot
+func NewPreferences(spot spotModels.Spot) (Preferences, error) {
+	preferences := Preferences{
+		MinWindSpeed: spot.MinWindSpeed,
+		MaxWindSpeed: spot.MaxWindSpeed,
+	}
+
+	if spot.BestTides != nil {
+		for _, token := range splitList(*spot.BestTides) {
+			rule, err := parseTideRule(token)
+			if err != nil {
+				return Preferences{}, fmt.Errorf("invalid best tides of spot %s: %w", spot.Slug, err)
+			}
+			preferences.Tides = append(preferences.Tides, rule)
+		}
+	}
+
+	if spot.BestWindDirections != nil {
+		for _, token := range splitList(*spot.BestWindDirections) {
+			degrees, ok := openmeteo.CompassDegrees(token)
+			if !ok {
+				return Preferences{}, fmt.Errorf("invalid best wind direction of spot %s: %q", spot.Slug, token)
+			}
+			preferences.WindDirections = append(preferences.WindDirections, degrees)
+		}
+	}
+
+	return preferences, nil
+}
+
+func parseTideRule(token string) (TideRule, error) {
+	level, movement, _ := strings.Cut(strings.ToLower(token), "-")
+
+	rule := TideRule{Level: Level(level), Movement: Movement(movement)}
+
+	switch rule.Level {
+	case Low, Mid, High:
+	default:
+		return TideRule{}, fmt.Errorf("unknown tide level %q", level)
+	}
+
+	switch rule.Movement {
+	case AnyMovement, Rising, Falling:
+	default:
+		return TideRule{}, fmt.Errorf("unknown tide movement %q", movement)
+	}
+
+	return rule, nil
+}
+
+func splitList(list string) []string {
+	var tokens []string
+	for _, token := range strings.Split(list, ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
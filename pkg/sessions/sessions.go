@@ -0,0 +1,245 @@
+package sessions
+
+import (
+	"math"
+	"sort"
+	"tidebot/pkg/astronomy"
+	"tidebot/pkg/openmeteo"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+const (
+	// MinScore is the score, out of 100, an hour needs to be part of a session
+	MinScore = 60
+
+	// Bands of the water level between the surrounding low and high water
+	lowBandTop  = 1.0 / 3
+	highBandTop = 2.0 / 3
+
+	// Wind within this angle of a preferred direction scores fully, and none at all from rightAngle on
+	windDirectionTolerance = 22.5
+	rightAngle             = 90
+
+	// A movement other than the preferred one halves the tide score
+	wrongMovementFactor = 0.5
+	twilightFactor      = 0.5
+)
+
+// Session is a run of consecutive hours worth going out in
+type Session struct {
+	From time.Time
+	To   time.Time
+	// Score is the average score of the hours out of 100
+	Score int
+	// Peak is the best scoring hour, with the tide and weather at its middle
+	Peak       time.Time
+	Tide       TideRule
+	Conditions *openmeteo.HourlyConditions
+}
+
+// Recommend scores every full hour between from and to against the spot's preferences and returns the
+// sessions, best first. Conditions are optional: hours without wind data are scored on tide and daylight alone.
+func Recommend(spot spotModels.Spot, preferences Preferences, tides *worldtides.WorldTidesResponse, conditions []*openmeteo.DayConditions, from time.Time, to time.Time) []Session {
+	location := spot.Location()
+	daylights := make(map[string]astronomy.Daylight)
+
+	var sessions []Session
+	var current *Session
+	var total float64
+	var hours int
+	var peakScore float64
+
+	closeSession := func() {
+		if current != nil {
+			current.Score = int(math.Round(total / float64(hours)))
+			sessions = append(sessions, *current)
+			current = nil
+		}
+	}
+
+	for hour := from.In(location).Truncate(time.Hour); hour.Before(to); hour = hour.Add(time.Hour) {
+		if hour.Before(from) {
+			continue
+		}
+		middle := hour.Add(30 * time.Minute)
+
+		day := middle.Format("2006-01-02")
+		daylight, exists := daylights[day]
+		if !exists {
+			daylight = astronomy.NewDaylight(spot.Latitude, spot.Longitude, middle)
+			daylights[day] = daylight
+		}
+
+		rule, ok := tideAt(tides, middle)
+		if !ok {
+			closeSession()
+			continue
+		}
+
+		hourly := conditionsAt(conditions, middle)
+		score := 100 * tideScore(preferences, rule) * windScore(preferences, hourly) * lightScore(daylight.LightAt(middle))
+
+		if score < MinScore {
+			closeSession()
+			continue
+		}
+
+		if current == nil {
+			current = &Session{From: hour}
+			total, hours, peakScore = 0, 0, 0
+		}
+
+		current.To = hour.Add(time.Hour)
+		total += score
+		hours++
+
+		if score > peakScore {
+			peakScore = score
+			current.Peak = middle
+			current.Tide = rule
+			current.Conditions = hourly
+		}
+	}
+	closeSession()
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Score > sessions[j].Score
+	})
+
+	return sessions
+}
+
+// tideAt classifies the water level at t by where it sits between the extremes around t
+func tideAt(tides *worldtides.WorldTidesResponse, t time.Time) (TideRule, bool) {
+	state, err := tides.StateAt(t)
+	if err != nil || state.NextExtreme == nil {
+		return TideRule{}, false
+	}
+
+	var previous *worldtides.Extreme
+	for i := range tides.Extremes {
+		if tides.Extremes[i].Dt > t.Unix() {
+			break
+		}
+		previous = &tides.Extremes[i]
+	}
+	if previous == nil {
+		return TideRule{}, false
+	}
+
+	low := math.Min(previous.Height, state.NextExtreme.Height)
+	high := math.Max(previous.Height, state.NextExtreme.Height)
+	if high == low {
+		return TideRule{}, false
+	}
+
+	rule := TideRule{Level: levelOf((state.Height - low) / (high - low)), Movement: Falling}
+	if state.Rising {
+		rule.Movement = Rising
+	}
+
+	return rule, true
+}
+
+func levelOf(fraction float64) Level {
+	switch {
+	case fraction < lowBandTop:
+		return Low
+	case fraction > highBandTop:
+		return High
+	default:
+		return Mid
+	}
+}
+
+// tideScore is 1 when the tide matches a rule, less when the level is a band off or the tide moves the other way
+func tideScore(preferences Preferences, rule TideRule) float64 {
+	if len(preferences.Tides) == 0 {
+		return 1
+	}
+
+	best := 0.0
+	for _, preferred := range preferences.Tides {
+		score := 1.0
+		if preferred.Level != rule.Level {
+			// Mid is one band off low and high, low and high are two bands off each other
+			if preferred.Level != Mid && rule.Level != Mid {
+				continue
+			}
+			score = 0.5
+		}
+
+		if preferred.Movement != AnyMovement && preferred.Movement != rule.Movement {
+			score *= wrongMovementFactor
+		}
+
+		best = math.Max(best, score)
+	}
+
+	return best
+}
+
+// windScore is 1 without wind data, as the tide and daylight are all there is to go by
+func windScore(preferences Preferences, hourly *openmeteo.HourlyConditions) float64 {
+	if hourly == nil || hourly.WindSpeed == nil {
+		return 1
+	}
+
+	speed := *hourly.WindSpeed
+	score := 1.0
+
+	if preferences.MinWindSpeed != nil && speed < *preferences.MinWindSpeed {
+		// Nothing left at half the minimum
+		score *= clamp(2*speed / *preferences.MinWindSpeed - 1)
+	}
+
+	if preferences.MaxWindSpeed != nil && speed > *preferences.MaxWindSpeed {
+		// Nothing left at one and a half times the maximum
+		score *= clamp(1 - 2*(speed-*preferences.MaxWindSpeed) / *preferences.MaxWindSpeed)
+	}
+
+	if len(preferences.WindDirections) > 0 && hourly.WindDirection != nil {
+		offset := 180.0
+		for _, direction := range preferences.WindDirections {
+			offset = math.Min(offset, angleBetween(direction, *hourly.WindDirection))
+		}
+		score *= clamp(1 - (offset-windDirectionTolerance)/(rightAngle-windDirectionTolerance))
+	}
+
+	return score
+}
+
+func lightScore(light astronomy.Light) float64 {
+	switch light {
+	case astronomy.LightDay:
+		return 1
+	case astronomy.LightTwilight:
+		return twilightFactor
+	default:
+		return 0
+	}
+}
+
+func conditionsAt(conditions []*openmeteo.DayConditions, t time.Time) *openmeteo.HourlyConditions {
+	for _, day := range conditions {
+		if day == nil {
+			continue
+		}
+		if hourly := day.At(t); hourly != nil {
+			return hourly
+		}
+	}
+	return nil
+}
+
+// angleBetween returns the smallest angle between two directions in degrees
+func angleBetween(a float64, b float64) float64 {
+	angle := math.Mod(math.Abs(a-b), 360)
+	return math.Min(angle, 360-angle)
+}
+
+func clamp(value float64) float64 {
+	return math.Max(0, math.Min(value, 1))
+}
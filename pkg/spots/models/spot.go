@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// DefaultSpotSlug is the spot used for users that haven't picked one yet
+const DefaultSpotSlug = "risco-del-paso"
+
+type Spot struct {
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Slug          string  `json:"slug"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	Timezone      string  `json:"timezone"`
+	DisplayLabel  string  `json:"display_label"`
+	NoaaStationID *string `json:"noaa_station_id"` // NOAA CO-OPS station, only available for US waters
+	// WorldTidesStationID is the reference station for the spot's tides, nil to use its coordinates
+	WorldTidesStationID *string `json:"worldtides_station_id"`
+	// MeanTidalRange in meters is the reference for tidal coefficients, nil when unknown
+	MeanTidalRange *float64 `json:"mean_tidal_range"`
+	// Datum heights are relative to, nil for mean sea level
+	Datum *string `json:"datum"`
+	// BestTides and BestWindDirections are comma separated session preferences, see the sessions package
+	BestTides          *string   `json:"best_tides"`
+	BestWindDirections *string   `json:"best_wind_directions"`
+	MinWindSpeed       *float64  `json:"min_wind_speed"` // knots
+	MaxWindSpeed       *float64  `json:"max_wind_speed"` // knots
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type SpotWriteModel struct {
+	Name                string   `json:"name"`
+	Slug                string   `json:"slug"`
+	Latitude            float64  `json:"latitude"`
+	Longitude           float64  `json:"longitude"`
+	Timezone            string   `json:"timezone"`
+	DisplayLabel        string   `json:"display_label"`
+	NoaaStationID       *string  `json:"noaa_station_id,omitempty"`
+	WorldTidesStationID *string  `json:"worldtides_station_id,omitempty"`
+	MeanTidalRange      *float64 `json:"mean_tidal_range,omitempty"`
+	Datum               *string  `json:"datum,omitempty"`
+	BestTides           *string  `json:"best_tides,omitempty"`
+	BestWindDirections  *string  `json:"best_wind_directions,omitempty"`
+	MinWindSpeed        *float64 `json:"min_wind_speed,omitempty"`
+	MaxWindSpeed        *float64 `json:"max_wind_speed,omitempty"`
+}
+
+// Location returns the spot's timezone, falling back to UTC if it can't be loaded
+func (s *Spot) Location() *time.Location {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
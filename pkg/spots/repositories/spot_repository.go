@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"tidebot/pkg/spots/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SpotRepository interface {
+	ListAll() ([]models.Spot, error)
+	GetByID(id int) (models.Spot, error)
+	GetBySlug(slug string) (models.Spot, error)
+	// ListBySlugs returns the spots among the slugs in a single query, skipping the slugs of no spot
+	ListBySlugs(slugs []string) ([]models.Spot, error)
+	Save(writeModel models.SpotWriteModel) (models.Spot, error)
+	// UpdateWorldTidesStation binds the spot to a WorldTides station, or unbinds it when stationID is nil
+	UpdateWorldTidesStation(slug string, stationID *string) (models.Spot, error)
+}
+
+type spotRepositoryImpl struct {
+	db  *sql.DB
+	log echo.Logger
+}
+
+func NewSpotRepository(db *sql.DB, log echo.Logger) SpotRepository {
+	return &spotRepositoryImpl{db, log}
+}
+
+const spotColumns = `id, name, slug, latitude, longitude, timezone, display_label, noaa_station_id, worldtides_station_id, mean_tidal_range, datum, best_tides, best_wind_directions, min_wind_speed, max_wind_speed, created_at, updated_at`
+
+func scanSpot(row interface{ Scan(dest ...any) error }, spot *models.Spot) error {
+	return row.Scan(
+		&spot.ID,
+		&spot.Name,
+		&spot.Slug,
+		&spot.Latitude,
+		&spot.Longitude,
+		&spot.Timezone,
+		&spot.DisplayLabel,
+		&spot.NoaaStationID,
+		&spot.WorldTidesStationID,
+		&spot.MeanTidalRange,
+		&spot.Datum,
+		&spot.BestTides,
+		&spot.BestWindDirections,
+		&spot.MinWindSpeed,
+		&spot.MaxWindSpeed,
+		&spot.CreatedAt,
+		&spot.UpdatedAt,
+	)
+}
+
+func (r *spotRepositoryImpl) ListAll() ([]models.Spot, error) {
+	r.log.Debugf("Attempting to list all spots")
+
+	query := fmt.Sprintf(`SELECT %s FROM spots ORDER BY name ASC`, spotColumns)
+
+	rows, err := r.db.QueryContext(context.Background(), query)
+	if err != nil {
+		return []models.Spot{}, fmt.Errorf("failed to list all spots: %w", err)
+	}
+	defer rows.Close()
+
+	var allSpots []models.Spot
+
+	for rows.Next() {
+		var spot models.Spot
+		if err := scanSpot(rows, &spot); err != nil {
+			return []models.Spot{}, fmt.Errorf("failed to scan spot row: %w", err)
+		}
+		allSpots = append(allSpots, spot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []models.Spot{}, fmt.Errorf("failed to read rows when trying to list all spots: %w", err)
+	}
+
+	r.log.Debugf("Successfully listed %d spots", len(allSpots))
+	return allSpots, nil
+}
+
+func (r *spotRepositoryImpl) GetByID(id int) (models.Spot, error) {
+	r.log.Debugf("Attempting to get spot by ID: %d", id)
+
+	query := fmt.Sprintf(`SELECT %s FROM spots WHERE id = ? LIMIT 1`, spotColumns)
+
+	var spot models.Spot
+	err := scanSpot(r.db.QueryRowContext(context.Background(), query, id), &spot)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Spot{}, fmt.Errorf("spot not found with id '%d'", id)
+		}
+		return models.Spot{}, fmt.Errorf("failed to get spot by id: %w", err)
+	}
+
+	r.log.Debugf("Successfully found spot '%s' for ID '%d'", spot.Slug, id)
+	return spot, nil
+}
+
+func (r *spotRepositoryImpl) GetBySlug(slug string) (models.Spot, error) {
+	r.log.Debugf("Attempting to get spot by slug: %s", slug)
+
+	query := fmt.Sprintf(`SELECT %s FROM spots WHERE slug = ? LIMIT 1`, spotColumns)
+
+	var spot models.Spot
+	err := scanSpot(r.db.QueryRowContext(context.Background(), query, slug), &spot)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Spot{}, fmt.Errorf("spot not found with slug '%s'", slug)
+		}
+		return models.Spot{}, fmt.Errorf("failed to get spot by slug: %w", err)
+	}
+
+	r.log.Debugf("Successfully found spot with ID='%d' for slug '%s'", spot.ID, slug)
+	return spot, nil
+}
+
+func (r *spotRepositoryImpl) ListBySlugs(slugs []string) ([]models.Spot, error) {
+	r.log.Debugf("Attempting to list spots by slugs: %v", slugs)
+
+	if len(slugs) == 0 {
+		return []models.Spot{}, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(slugs)), ", ")
+	query := fmt.Sprintf(`SELECT %s FROM spots WHERE slug IN (%s)`, spotColumns, placeholders)
+
+	args := make([]any, len(slugs))
+	for i, slug := range slugs {
+		args[i] = slug
+	}
+
+	rows, err := r.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return []models.Spot{}, fmt.Errorf("failed to list spots by slugs: %w", err)
+	}
+	defer rows.Close()
+
+	var spots []models.Spot
+
+	for rows.Next() {
+		var spot models.Spot
+		if err := scanSpot(rows, &spot); err != nil {
+			return []models.Spot{}, fmt.Errorf("failed to scan spot row: %w", err)
+		}
+		spots = append(spots, spot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []models.Spot{}, fmt.Errorf("failed to read rows when trying to list spots by slugs: %w", err)
+	}
+
+	r.log.Debugf("Successfully listed %d spots", len(spots))
+	return spots, nil
+}
+
+func (r *spotRepositoryImpl) Save(writeModel models.SpotWriteModel) (models.Spot, error) {
+	r.log.Debugf("Attempting to save a new spot: %+v", writeModel)
+
+	query := fmt.Sprintf(`
+		INSERT INTO spots (name, slug, latitude, longitude, timezone, display_label, noaa_station_id, worldtides_station_id, mean_tidal_range, datum, best_tides, best_wind_directions, min_wind_speed, max_wind_speed, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING %s`, spotColumns)
+
+	var spot models.Spot
+	err := scanSpot(r.db.QueryRowContext(
+		context.Background(),
+		query,
+		writeModel.Name,
+		writeModel.Slug,
+		writeModel.Latitude,
+		writeModel.Longitude,
+		writeModel.Timezone,
+		writeModel.DisplayLabel,
+		writeModel.NoaaStationID,
+		writeModel.WorldTidesStationID,
+		writeModel.MeanTidalRange,
+		writeModel.Datum,
+		writeModel.BestTides,
+		writeModel.BestWindDirections,
+		writeModel.MinWindSpeed,
+		writeModel.MaxWindSpeed,
+	), &spot)
+
+	if err != nil {
+		return models.Spot{}, fmt.Errorf("failed to save new spot: %w", err)
+	}
+
+	r.log.Debugf("Saved new spot with id='%d'", spot.ID)
+	return spot, nil
+}
+
+func (r *spotRepositoryImpl) UpdateWorldTidesStation(slug string, stationID *string) (models.Spot, error) {
+	r.log.Debugf("Attempting to set the WorldTides station of spot '%s' to %v", slug, stationID)
+
+	query := fmt.Sprintf(`
+		UPDATE spots
+		SET worldtides_station_id = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE slug = ?
+		RETURNING %s`, spotColumns)
+
+	var spot models.Spot
+	err := scanSpot(r.db.QueryRowContext(context.Background(), query, stationID, slug), &spot)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.Spot{}, fmt.Errorf("spot not found with slug '%s'", slug)
+		}
+		return models.Spot{}, fmt.Errorf("failed to update WorldTides station of spot: %w", err)
+	}
+
+	r.log.Debugf("Set the WorldTides station of spot '%s' to %v", slug, stationID)
+	return spot, nil
+}
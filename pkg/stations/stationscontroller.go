@@ -0,0 +1,148 @@
+package stations
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"tidebot/pkg/middleware"
+	"tidebot/pkg/worldtides"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StationsController struct {
+	stationsService StationsService
+	apiKey          string
+	log             echo.Logger
+}
+
+func NewStationsController(stationsService StationsService, apiKey string, log echo.Logger) *StationsController {
+	return &StationsController{
+		stationsService: stationsService,
+		apiKey:          apiKey,
+		log:             log,
+	}
+}
+
+func (sc *StationsController) RegisterRoutes(e *echo.Echo) {
+	adminGroup := e.Group("/admin")
+	adminGroup.Use(middleware.APIKey(sc.apiKey, sc.log))
+
+	adminGroup.GET("/stations", sc.GetStationsNear)
+	adminGroup.PUT("/spots/:spot/station", sc.BindSpot)
+	adminGroup.DELETE("/spots/:spot/station", sc.UnbindSpot)
+}
+
+// GetStationsNear lists the stations around ?near=, a spot slug, "lat,lon" or a place name, within ?radius= km
+func (sc *StationsController) GetStationsNear(c echo.Context) error {
+	near := c.QueryParam("near")
+	if near == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  "error",
+			"message": "Missing 'near' query parameter",
+		})
+	}
+
+	radiusKm, err := parseRadius(c.QueryParam("radius"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  "error",
+			"message": "Invalid 'radius' query parameter",
+			"error":   err.Error(),
+		})
+	}
+
+	place, err := sc.stationsService.Resolve(c.Request().Context(), near)
+	if err != nil {
+		sc.log.Errorf("Failed to resolve '%s': %v", near, err)
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to find the place",
+			"error":   err.Error(),
+		})
+	}
+
+	stations, err := sc.stationsService.FindNear(c.Request().Context(), place, radiusKm, worldtides.AdminTrigger("stations"))
+	if err != nil {
+		sc.log.Errorf("Failed to find stations near '%s': %v", near, err)
+		return c.JSON(statusFor(err), map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to find stations",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":   "success",
+		"place":    place,
+		"radiusKm": radiusKm,
+		"stations": stations,
+	})
+}
+
+// BindSpot binds the spot to ?station=, or to the closest station within ?radius= km when it's not given
+func (sc *StationsController) BindSpot(c echo.Context) error {
+	slug := c.Param("spot")
+
+	radiusKm, err := parseRadius(c.QueryParam("radius"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"status":  "error",
+			"message": "Invalid 'radius' query parameter",
+			"error":   err.Error(),
+		})
+	}
+
+	spot, station, err := sc.stationsService.BindSpot(c.Request().Context(), slug, c.QueryParam("station"), radiusKm, worldtides.AdminTrigger("bind-station"))
+	if err != nil {
+		sc.log.Errorf("Failed to bind spot %s to a station: %v", slug, err)
+		return c.JSON(statusFor(err), map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to bind the spot to a station",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status":  "success",
+		"spot":    spot,
+		"station": station,
+	})
+}
+
+func (sc *StationsController) UnbindSpot(c echo.Context) error {
+	slug := c.Param("spot")
+
+	spot, err := sc.stationsService.UnbindSpot(slug)
+	if err != nil {
+		sc.log.Errorf("Failed to unbind spot %s from its station: %v", slug, err)
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"status":  "error",
+			"message": "Failed to unbind the spot from its station",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"spot":   spot,
+	})
+}
+
+func parseRadius(value string) (int, error) {
+	if value == "" {
+		return DefaultRadiusKm, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrStationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, worldtides.ErrCreditBudgetExhausted):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,174 @@
+package stations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"tidebot/pkg/openmeteo"
+	spotModels "tidebot/pkg/spots/models"
+	spotRepos "tidebot/pkg/spots/repositories"
+	tidePredictionRepos "tidebot/pkg/tidepredictions/repositories"
+	"tidebot/pkg/worldtides"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultRadiusKm is how far around a position stations are looked for unless asked otherwise
+const DefaultRadiusKm = 50
+
+// ErrStationNotFound is returned when binding a spot to a station that isn't within the search radius
+var ErrStationNotFound = errors.New("station not found")
+
+var coordinatesRegexp = regexp.MustCompile(`^(-?\d+(?:\.\d+)?)\s*[,\s]\s*(-?\d+(?:\.\d+)?)$`)
+
+type StationsService interface {
+	// Resolve turns a spot slug, "lat,lon" coordinates or a place name into a position
+	Resolve(ctx context.Context, query string) (openmeteo.Place, error)
+	// FindNear returns the stations within radiusKm of the place, closest first
+	FindNear(ctx context.Context, place openmeteo.Place, radiusKm int, trigger worldtides.Trigger) ([]worldtides.NearbyStation, error)
+	// BindSpot makes the spot use the station's tides, or the closest station's when stationID is empty
+	BindSpot(ctx context.Context, slug string, stationID string, radiusKm int, trigger worldtides.Trigger) (spotModels.Spot, worldtides.NearbyStation, error)
+	// UnbindSpot makes the spot use the tides at its coordinates again
+	UnbindSpot(slug string) (spotModels.Spot, error)
+}
+
+type stationsServiceImpl struct {
+	stationsClient           worldtides.StationsClient
+	geocodingClient          openmeteo.GeocodingClient
+	spotRepository           spotRepos.SpotRepository
+	tidePredictionRepository tidePredictionRepos.TidePredictionRepository
+	log                      echo.Logger
+}
+
+func NewStationsService(stationsClient worldtides.StationsClient, geocodingClient openmeteo.GeocodingClient, spotRepository spotRepos.SpotRepository, tidePredictionRepository tidePredictionRepos.TidePredictionRepository, log echo.Logger) StationsService {
+	return &stationsServiceImpl{
+		stationsClient:           stationsClient,
+		geocodingClient:          geocodingClient,
+		spotRepository:           spotRepository,
+		tidePredictionRepository: tidePredictionRepository,
+		log:                      log,
+	}
+}
+
+func (s *stationsServiceImpl) Resolve(ctx context.Context, query string) (openmeteo.Place, error) {
+	query = strings.TrimSpace(query)
+
+	if place, ok := parseCoordinates(query); ok {
+		return place, nil
+	}
+
+	spot, err := s.spotRepository.GetBySlug(strings.ToLower(query))
+	if err == nil {
+		return openmeteo.Place{Name: spot.Name, Latitude: spot.Latitude, Longitude: spot.Longitude}, nil
+	}
+
+	place, err := s.geocodingClient.FindPlace(ctx, query)
+	if err != nil {
+		return openmeteo.Place{}, fmt.Errorf("failed to find place %q: %w", query, err)
+	}
+
+	return place, nil
+}
+
+func (s *stationsServiceImpl) FindNear(ctx context.Context, place openmeteo.Place, radiusKm int, trigger worldtides.Trigger) ([]worldtides.NearbyStation, error) {
+	s.log.Debugf("Finding stations within %dkm of %s (%f,%f)", radiusKm, place.Name, place.Latitude, place.Longitude)
+
+	stations, err := s.stationsClient.GetStations(ctx, place.Latitude, place.Longitude, radiusKm, trigger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stations near %s: %w", place.Name, err)
+	}
+
+	return stations, nil
+}
+
+func (s *stationsServiceImpl) BindSpot(ctx context.Context, slug string, stationID string, radiusKm int, trigger worldtides.Trigger) (spotModels.Spot, worldtides.NearbyStation, error) {
+	spot, err := s.spotRepository.GetBySlug(slug)
+	if err != nil {
+		return spotModels.Spot{}, worldtides.NearbyStation{}, err
+	}
+
+	stations, err := s.stationsClient.GetStations(ctx, spot.Latitude, spot.Longitude, radiusKm, trigger)
+	if err != nil {
+		return spotModels.Spot{}, worldtides.NearbyStation{}, fmt.Errorf("failed to get stations near spot %s: %w", slug, err)
+	}
+
+	station, ok := pickStation(stations, stationID)
+	if !ok {
+		return spotModels.Spot{}, worldtides.NearbyStation{}, fmt.Errorf("%w within %dkm of spot %s", ErrStationNotFound, radiusKm, slug)
+	}
+
+	spot, err = s.spotRepository.UpdateWorldTidesStation(slug, &station.ID)
+	if err != nil {
+		return spotModels.Spot{}, worldtides.NearbyStation{}, err
+	}
+
+	s.evictCachedTides(spot)
+
+	s.log.Infof("Bound spot %s to WorldTides station %s (%s), %.1fkm away", slug, station.ID, station.Name, station.DistanceKm)
+	return spot, station, nil
+}
+
+func (s *stationsServiceImpl) UnbindSpot(slug string) (spotModels.Spot, error) {
+	spot, err := s.spotRepository.UpdateWorldTidesStation(slug, nil)
+	if err != nil {
+		return spotModels.Spot{}, err
+	}
+
+	s.evictCachedTides(spot)
+
+	s.log.Infof("Unbound spot %s from its WorldTides station", slug)
+	return spot, nil
+}
+
+// evictCachedTides drops the predictions cached from the spot's previous source of tides
+func (s *stationsServiceImpl) evictCachedTides(spot spotModels.Spot) {
+	_, err := s.tidePredictionRepository.DeleteAt(spot.Latitude, spot.Longitude)
+	if err != nil {
+		s.log.Errorf("Failed to evict cached tides of spot %s: %v", spot.Slug, err)
+	}
+}
+
+// pickStation returns the station with the ID, or the closest one when stationID is empty
+func pickStation(stations []worldtides.NearbyStation, stationID string) (worldtides.NearbyStation, bool) {
+	if stationID == "" {
+		if len(stations) == 0 {
+			return worldtides.NearbyStation{}, false
+		}
+		return stations[0], true
+	}
+
+	for _, station := range stations {
+		if station.ID == stationID {
+			return station, true
+		}
+	}
+
+	return worldtides.NearbyStation{}, false
+}
+
+// parseCoordinates accepts "lat,lon" and "lat lon" in decimal degrees
+func parseCoordinates(query string) (openmeteo.Place, bool) {
+	matches := coordinatesRegexp.FindStringSubmatch(query)
+	if matches == nil {
+		return openmeteo.Place{}, false
+	}
+
+	latitude, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return openmeteo.Place{}, false
+	}
+
+	longitude, err := strconv.ParseFloat(matches[2], 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return openmeteo.Place{}, false
+	}
+
+	return openmeteo.Place{
+		Name:      fmt.Sprintf("%.4f,%.4f", latitude, longitude),
+		Latitude:  latitude,
+		Longitude: longitude,
+	}, true
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// StationSearchKey identifies a cached station search. Coordinates are rounded to 3 decimal
+// places (~100m), plenty for searches spanning kilometers, so nearby searches share an entry.
+type StationSearchKey struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	RadiusKm  int     `json:"radius_km"`
+}
+
+type StationSearch struct {
+	ID int `json:"id"`
+	StationSearchKey
+	Response  string    `json:"response"`
+	FetchedAt time.Time `json:"fetched_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewStationSearchKey(latitude float64, longitude float64, radiusKm int) StationSearchKey {
+	return StationSearchKey{
+		Latitude:  roundCoordinate(latitude),
+		Longitude: roundCoordinate(longitude),
+		RadiusKm:  radiusKm,
+	}
+}
+
+func roundCoordinate(value float64) float64 {
+	return math.Round(value*1e3) / 1e3
+}
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"tidebot/pkg/stationsearches/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StationSearchRepository interface {
+	// Get returns the search for the key, or nil if there is no unexpired entry
+	Get(key models.StationSearchKey) (*models.StationSearch, error)
+	Save(key models.StationSearchKey, response string, expiresAt time.Time) error
+	DeleteExpired() (int64, error)
+}
+
+type stationSearchRepositoryImpl struct {
+	db  *sql.DB
+	log echo.Logger
+}
+
+func NewStationSearchRepository(db *sql.DB, log echo.Logger) StationSearchRepository {
+	return &stationSearchRepositoryImpl{db, log}
+}
+
+func (r *stationSearchRepositoryImpl) Get(key models.StationSearchKey) (*models.StationSearch, error) {
+	r.log.Debugf("Attempting to get station search: %+v", key)
+
+	query := `
+		SELECT id, latitude, longitude, radius_km, response, fetched_at, expires_at
+		FROM station_searches
+		WHERE latitude = ? AND longitude = ? AND radius_km = ? AND expires_at > ?
+		LIMIT 1`
+
+	var search models.StationSearch
+	err := r.db.QueryRowContext(
+		context.Background(),
+		query,
+		key.Latitude,
+		key.Longitude,
+		key.RadiusKm,
+		time.Now().UTC(),
+	).Scan(
+		&search.ID,
+		&search.Latitude,
+		&search.Longitude,
+		&search.RadiusKm,
+		&search.Response,
+		&search.FetchedAt,
+		&search.ExpiresAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get station search: %w", err)
+	}
+
+	return &search, nil
+}
+
+func (r *stationSearchRepositoryImpl) Save(key models.StationSearchKey, response string, expiresAt time.Time) error {
+	r.log.Debugf("Attempting to save station search: %+v, expires at %s", key, expiresAt)
+
+	query := `
+		INSERT INTO station_searches (latitude, longitude, radius_km, response, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(latitude, longitude, radius_km) DO UPDATE SET
+			response = excluded.response,
+			fetched_at = excluded.fetched_at,
+			expires_at = excluded.expires_at`
+
+	_, err := r.db.ExecContext(
+		context.Background(),
+		query,
+		key.Latitude,
+		key.Longitude,
+		key.RadiusKm,
+		response,
+		time.Now().UTC(),
+		expiresAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save station search: %w", err)
+	}
+
+	return nil
+}
+
+func (r *stationSearchRepositoryImpl) DeleteExpired() (int64, error) {
+	r.log.Debugf("Attempting to delete expired station searches")
+
+	result, err := r.db.ExecContext(context.Background(), `DELETE FROM station_searches WHERE expires_at <= ?`, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired station searches: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	r.log.Infof("Deleted %d expired station searches", rowsAffected)
+	return rowsAffected, nil
+}
@@ -0,0 +1,118 @@
+package tidecycle
+
+import (
+	"math"
+	"tidebot/pkg/astronomy"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+type Kind string
+
+const (
+	Spring       Kind = "spring"
+	Neap         Kind = "neap"
+	Intermediate Kind = "intermediate"
+)
+
+const (
+	// On the French coefficient scale the mean tide scores 70, mean springs 95 and mean neaps 45
+	meanCoefficient = 70
+	minCoefficient  = 20
+	maxCoefficient  = 120
+
+	springCoefficient = 85
+	neapCoefficient   = 55
+
+	// Spring tides lag the new and full moon by about a day and a half, the age of the tide
+	tideAgeDegrees = 18
+)
+
+// Day classifies a day of tides at a spot within the spring-neap cycle
+type Day struct {
+	Date time.Time
+	// Range is the largest difference in meters between consecutive extremes of the day
+	Range float64
+	// Coefficient scores the range from 20 to 120, 0 when the spot has no mean tidal range
+	Coefficient int
+	Kind        Kind
+	Moon        astronomy.MoonPhase
+}
+
+// Classify scores the tides of the spot's local day starting at date. Without a mean tidal range for the spot
+// the day is classified by the moon phase alone.
+func Classify(spot spotModels.Spot, date time.Time, tides *worldtides.WorldTidesResponse) Day {
+	location := spot.Location()
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+
+	day := Day{
+		Date:  from,
+		Range: dayRange(tides.Extremes),
+		Moon:  astronomy.NewMoonPhase(from.Add(12 * time.Hour)),
+	}
+
+	if spot.MeanTidalRange != nil && *spot.MeanTidalRange > 0 && day.Range > 0 {
+		coefficient := int(math.Round(meanCoefficient * day.Range / *spot.MeanTidalRange))
+		day.Coefficient = max(minCoefficient, min(coefficient, maxCoefficient))
+		day.Kind = kindByCoefficient(day.Coefficient)
+	} else {
+		day.Kind = kindByMoon(day.Moon)
+	}
+
+	return day
+}
+
+// ClassifyAll classifies consecutive days, one response per day starting at from
+func ClassifyAll(spot spotModels.Spot, from time.Time, responses []*worldtides.WorldTidesResponse) []Day {
+	days := make([]Day, len(responses))
+	for i, response := range responses {
+		days[i] = Classify(spot, from.AddDate(0, 0, i), response)
+	}
+	return days
+}
+
+func (d Day) Emoji() string {
+	switch d.Kind {
+	case Spring:
+		return "🌊"
+	case Neap:
+		return "〰️"
+	default:
+		return "➖"
+	}
+}
+
+func dayRange(extremes []worldtides.Extreme) float64 {
+	largest := 0.0
+	for i := 1; i < len(extremes); i++ {
+		largest = math.Max(largest, math.Abs(extremes[i].Height-extremes[i-1].Height))
+	}
+	return largest
+}
+
+func kindByCoefficient(coefficient int) Kind {
+	switch {
+	case coefficient >= springCoefficient:
+		return Spring
+	case coefficient <= neapCoefficient:
+		return Neap
+	default:
+		return Intermediate
+	}
+}
+
+// kindByMoon classifies by the angle from the closest syzygy, new or full moon, shifted by the age of the tide
+func kindByMoon(moon astronomy.MoonPhase) Kind {
+	angle := math.Mod(moon.Elongation-tideAgeDegrees+360, 180)
+	fromSyzygy := math.Min(angle, 180-angle)
+
+	switch {
+	case fromSyzygy <= 30:
+		return Spring
+	case fromSyzygy >= 60:
+		return Neap
+	default:
+		return Intermediate
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"tidebot/pkg/common"
+	"time"
+)
+
+// TidePredictionKey identifies a cached prediction. Coordinates are rounded
+// with common.RoundCoordinate.
+type TidePredictionKey struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Date      string  `json:"date"`
+	Datum     string  `json:"datum"`
+}
+
+type TidePrediction struct {
+	ID int `json:"id"`
+	TidePredictionKey
+	Response string `json:"response"`
+	// ResponseDatum is the datum the heights are relative to, which can differ from the requested Datum
+	ResponseDatum *string   `json:"response_datum"`
+	FetchedAt     time.Time `json:"fetched_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+func NewTidePredictionKey(latitude float64, longitude float64, date time.Time, datum string) TidePredictionKey {
+	return TidePredictionKey{
+		Latitude:  common.RoundCoordinate(latitude),
+		Longitude: common.RoundCoordinate(longitude),
+		Date:      date.Format("2006-01-02"),
+		Datum:     datum,
+	}
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"tidebot/pkg/tidepredictions/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TidePredictionRepository interface {
+	// Get returns the prediction for the key, or nil if there is no unexpired entry
+	Get(key models.TidePredictionKey) (*models.TidePrediction, error)
+	Save(key models.TidePredictionKey, response string, responseDatum string, expiresAt time.Time) error
+	Delete(key models.TidePredictionKey) error
+	// DeleteAt drops every prediction cached for the position, e.g. when the source of its tides changes
+	DeleteAt(latitude float64, longitude float64) (int64, error)
+	DeleteExpired() (int64, error)
+}
+
+type tidePredictionRepositoryImpl struct {
+	db  *sql.DB
+	log echo.Logger
+}
+
+func NewTidePredictionRepository(db *sql.DB, log echo.Logger) TidePredictionRepository {
+	return &tidePredictionRepositoryImpl{db, log}
+}
+
+func (r *tidePredictionRepositoryImpl) Get(key models.TidePredictionKey) (*models.TidePrediction, error) {
+	r.log.Debugf("Attempting to get tide prediction: %+v", key)
+
+	query := `
+		SELECT id, latitude, longitude, date, datum, response, response_datum, fetched_at, expires_at
+		FROM tide_predictions
+		WHERE latitude = ? AND longitude = ? AND date = ? AND datum = ? AND expires_at > ?
+		LIMIT 1`
+
+	var prediction models.TidePrediction
+	err := r.db.QueryRowContext(
+		context.Background(),
+		query,
+		key.Latitude,
+		key.Longitude,
+		key.Date,
+		key.Datum,
+		time.Now().UTC(),
+	).Scan(
+		&prediction.ID,
+		&prediction.Latitude,
+		&prediction.Longitude,
+		&prediction.Date,
+		&prediction.Datum,
+		&prediction.Response,
+		&prediction.ResponseDatum,
+		&prediction.FetchedAt,
+		&prediction.ExpiresAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tide prediction: %w", err)
+	}
+
+	return &prediction, nil
+}
+
+func (r *tidePredictionRepositoryImpl) Save(key models.TidePredictionKey, response string, responseDatum string, expiresAt time.Time) error {
+	r.log.Debugf("Attempting to save tide prediction: %+v, expires at %s", key, expiresAt)
+
+	query := `
+		INSERT INTO tide_predictions (latitude, longitude, date, datum, response, response_datum, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(latitude, longitude, date, datum) DO UPDATE SET
+			response = excluded.response,
+			response_datum = excluded.response_datum,
+			fetched_at = excluded.fetched_at,
+			expires_at = excluded.expires_at`
+
+	_, err := r.db.ExecContext(
+		context.Background(),
+		query,
+		key.Latitude,
+		key.Longitude,
+		key.Date,
+		key.Datum,
+		response,
+		responseDatum,
+		time.Now().UTC(),
+		expiresAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save tide prediction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tidePredictionRepositoryImpl) Delete(key models.TidePredictionKey) error {
+	r.log.Debugf("Attempting to delete tide prediction: %+v", key)
+
+	query := `DELETE FROM tide_predictions WHERE latitude = ? AND longitude = ? AND date = ? AND datum = ?`
+
+	_, err := r.db.ExecContext(context.Background(), query, key.Latitude, key.Longitude, key.Date, key.Datum)
+	if err != nil {
+		return fmt.Errorf("failed to delete tide prediction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tidePredictionRepositoryImpl) DeleteAt(latitude float64, longitude float64) (int64, error) {
+	// Keys are built for a date and datum, only the rounded coordinates are needed here
+	key := models.NewTidePredictionKey(latitude, longitude, time.Time{}, "")
+
+	r.log.Debugf("Attempting to delete tide predictions at %f,%f", key.Latitude, key.Longitude)
+
+	result, err := r.db.ExecContext(context.Background(), `DELETE FROM tide_predictions WHERE latitude = ? AND longitude = ?`, key.Latitude, key.Longitude)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete tide predictions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	r.log.Infof("Deleted %d tide predictions at %f,%f", rowsAffected, key.Latitude, key.Longitude)
+	return rowsAffected, nil
+}
+
+func (r *tidePredictionRepositoryImpl) DeleteExpired() (int64, error) {
+	r.log.Debugf("Attempting to delete expired tide predictions")
+
+	result, err := r.db.ExecContext(context.Background(), `DELETE FROM tide_predictions WHERE expires_at <= ?`, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired tide predictions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	r.log.Infof("Deleted %d expired tide predictions", rowsAffected)
+	return rowsAffected, nil
+}
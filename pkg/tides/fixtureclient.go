@@ -0,0 +1,61 @@
+package tides
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type fixtureClientImpl struct {
+	dir string
+	log echo.Logger
+}
+
+// NewFixtureClient serves WorldTides responses stored as <dir>/<spot slug>/<YYYY-MM-DD>.json,
+// e.g. for local development or manually curated data
+func NewFixtureClient(dir string, log echo.Logger) worldtides.WorldTidesClient {
+	return &fixtureClientImpl{dir, log}
+}
+
+func (c *fixtureClientImpl) GetTides(ctx context.Context, spot models.Spot, date time.Time, trigger worldtides.Trigger) (*worldtides.WorldTidesResponse, error) {
+	path := filepath.Join(c.dir, spot.Slug, fmt.Sprintf("%s.json", date.Format("2006-01-02")))
+
+	c.log.Debugf("Reading tides fixture: %s", path)
+
+	body, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no tides fixture %s: %w", path, worldtides.ErrNotSupported)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tides fixture: %w", err)
+	}
+
+	var response worldtides.WorldTidesResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tides fixture %s: %w", path, err)
+	}
+
+	// Fixtures without a datum are taken to be relative to mean sea level
+	fixtureDatum, _ := worldtides.ParseDatum(response.ResponseDatum)
+	if response.ResponseDatum == "" {
+		fixtureDatum = worldtides.DefaultDatum
+	}
+	if fixtureDatum != worldtides.SpotDatum(spot) {
+		return nil, fmt.Errorf("tides fixture %s is not in the %s datum: %w", path, worldtides.SpotDatum(spot), worldtides.ErrNotSupported)
+	}
+
+	return &response, nil
+}
+
+func (c *fixtureClientImpl) GetTidesRange(ctx context.Context, spot models.Spot, from time.Time, days int, trigger worldtides.Trigger) ([]*worldtides.WorldTidesResponse, error) {
+	return worldtides.GetTidesDayByDay(ctx, c, spot, from, days, trigger)
+}
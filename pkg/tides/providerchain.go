@@ -0,0 +1,216 @@
+package tides
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// A provider is skipped for unhealthyCooldown after failureThreshold consecutive failures
+	failureThreshold  = 3
+	unhealthyCooldown = 5 * time.Minute
+)
+
+// Provider is a named source of tide data
+type Provider struct {
+	Name   string
+	Client worldtides.WorldTidesClient
+}
+
+type ProviderHealth struct {
+	Name                string     `json:"name"`
+	Healthy             bool       `json:"healthy"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailureAt       *time.Time `json:"last_failure_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+}
+
+// ProviderChain asks its providers in priority order and returns the first successful
+// response, with Source set to the name of the provider that answered
+type ProviderChain interface {
+	worldtides.WorldTidesClient
+	Health() []ProviderHealth
+}
+
+type providerState struct {
+	provider Provider
+	health   ProviderHealth
+}
+
+type providerChainImpl struct {
+	mu        sync.Mutex
+	providers []*providerState
+	log       echo.Logger
+}
+
+func NewProviderChain(log echo.Logger, providers ...Provider) ProviderChain {
+	states := make([]*providerState, len(providers))
+	for i, provider := range providers {
+		states[i] = &providerState{
+			provider: provider,
+			health:   ProviderHealth{Name: provider.Name, Healthy: true},
+		}
+	}
+
+	return &providerChainImpl{
+		providers: states,
+		log:       log,
+	}
+}
+
+func (c *providerChainImpl) GetTides(ctx context.Context, spot models.Spot, date time.Time, trigger worldtides.Trigger) (*worldtides.WorldTidesResponse, error) {
+	responses, err := c.call(ctx, spot, date, func(client worldtides.WorldTidesClient) ([]*worldtides.WorldTidesResponse, error) {
+		response, err := client.GetTides(ctx, spot, date, trigger)
+		if err != nil {
+			return nil, err
+		}
+		return []*worldtides.WorldTidesResponse{response}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+func (c *providerChainImpl) GetTidesRange(ctx context.Context, spot models.Spot, from time.Time, days int, trigger worldtides.Trigger) ([]*worldtides.WorldTidesResponse, error) {
+	return c.call(ctx, spot, from, func(client worldtides.WorldTidesClient) ([]*worldtides.WorldTidesResponse, error) {
+		return client.GetTidesRange(ctx, spot, from, days, trigger)
+	})
+}
+
+func (c *providerChainImpl) call(ctx context.Context, spot models.Spot, date time.Time, fetch func(worldtides.WorldTidesClient) ([]*worldtides.WorldTidesResponse, error)) ([]*worldtides.WorldTidesResponse, error) {
+	var errs []error
+	var skipped []*providerState
+
+	for _, state := range c.providers {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if !c.isAvailable(state) {
+			c.log.Debugf("Skipping unhealthy tides provider %s", state.provider.Name)
+			skipped = append(skipped, state)
+			continue
+		}
+
+		responses, err := c.tryProvider(ctx, state, spot, date, fetch)
+		if err == nil {
+			return responses, nil
+		}
+		errs = append(errs, err)
+	}
+
+	// Every healthy provider failed, so give the ones cooling down a chance as a last resort
+	for _, state := range skipped {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		responses, err := c.tryProvider(ctx, state, spot, date, fetch)
+		if err == nil {
+			return responses, nil
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("no tides providers configured")
+	}
+
+	return nil, fmt.Errorf("all tides providers failed: %w", errors.Join(errs...))
+}
+
+func (c *providerChainImpl) tryProvider(ctx context.Context, state *providerState, spot models.Spot, date time.Time, fetch func(worldtides.WorldTidesClient) ([]*worldtides.WorldTidesResponse, error)) ([]*worldtides.WorldTidesResponse, error) {
+	responses, err := fetch(state.provider.Client)
+	if errors.Is(err, worldtides.ErrNotSupported) || errors.Is(err, worldtides.ErrCreditBudgetExhausted) {
+		c.log.Debugf("Tides provider %s can't serve spot %s on %s: %v", state.provider.Name, spot.Slug, date.Format("2006-01-02"), err)
+		return nil, fmt.Errorf("%s: %w", state.provider.Name, err)
+	}
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up, which says nothing about the provider
+		return nil, fmt.Errorf("%s: %w", state.provider.Name, err)
+	}
+	if err != nil {
+		c.log.Warnf("Tides provider %s failed for spot %s on %s: %v", state.provider.Name, spot.Slug, date.Format("2006-01-02"), err)
+		c.recordFailure(state, err)
+		return nil, fmt.Errorf("%s: %w", state.provider.Name, err)
+	}
+
+	sourced := make([]*worldtides.WorldTidesResponse, len(responses))
+	for i, response := range responses {
+		// Copy so cached responses shared by a provider aren't mutated
+		sourcedResponse := *response
+		sourcedResponse.Source = state.provider.Name
+		sourced[i] = &sourcedResponse
+	}
+
+	// Implausible data counts as a failure so the next provider is tried, suspect data is flagged on the responses
+	anomalies, err := worldtides.CheckDays(sourced, spot, date)
+	if err != nil {
+		dates := worldtides.LocalDays(spot, date, len(sourced))
+		for i := range sourced {
+			worldtides.LogAnomalies(c.log, state.provider.Name, spot, dates[i], anomalies[i])
+		}
+		c.log.Warnf("Tides provider %s returned invalid data for spot %s on %s: %v", state.provider.Name, spot.Slug, date.Format("2006-01-02"), err)
+		c.recordFailure(state, err)
+		return nil, fmt.Errorf("%s: %w", state.provider.Name, err)
+	}
+
+	c.recordSuccess(state)
+
+	return sourced, nil
+}
+
+func (c *providerChainImpl) isAvailable(state *providerState) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state.health.ConsecutiveFailures < failureThreshold {
+		return true
+	}
+
+	return state.health.LastFailureAt != nil && time.Since(*state.health.LastFailureAt) > unhealthyCooldown
+}
+
+func (c *providerChainImpl) recordFailure(state *providerState, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	state.health.ConsecutiveFailures++
+	state.health.LastError = err.Error()
+	state.health.LastFailureAt = &now
+	state.health.Healthy = state.health.ConsecutiveFailures < failureThreshold
+}
+
+func (c *providerChainImpl) recordSuccess(state *providerState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	state.health.ConsecutiveFailures = 0
+	state.health.LastError = ""
+	state.health.LastSuccessAt = &now
+	state.health.Healthy = true
+}
+
+func (c *providerChainImpl) Health() []ProviderHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	health := make([]ProviderHealth, len(c.providers))
+	for i, state := range c.providers {
+		health[i] = state.health
+	}
+
+	return health
+}
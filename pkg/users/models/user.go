@@ -8,6 +8,9 @@ type User struct {
 	ID          int       `json:"id"`
 	PhoneNumber string    `json:"phone_number"`
 	Name        *string   `json:"name"`
+	Datum       *string   `json:"datum"`    // overrides the datum of the spots, nil to use theirs
+	Units       string    `json:"units"`    // heights are shown in, "meters" or "feet"
+	Language    *string   `json:"language"` // replies are in, nil to guess it from the phone number
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -15,6 +15,8 @@ type UserRepository interface {
 	GetByPhoneNumber(phoneNumber string) (models.User, error)
 	Save(models.UserWriteModel) (models.User, error)
 	Update(id int, writeModel models.UserWriteModel) (models.User, error)
+	UpdatePreferences(id int, datum *string, units string) (models.User, error)
+	UpdateLanguage(id int, language *string) (models.User, error)
 	Delete(id int) error
 }
 
@@ -31,7 +33,7 @@ func NewUserRepository(db *sql.DB, log echo.Logger) UserRepository {
 func (r *userRepositoryImpl) ListAll() ([]models.User, error) {
 	r.log.Debugf("Attempting to list all users")
 
-	query := `SELECT id, phone_number, name, created_at, updated_at FROM users ORDER BY created_at DESC`
+	query := `SELECT id, phone_number, name, datum, units, language, created_at, updated_at FROM users ORDER BY created_at DESC`
 
 	rows, err := r.db.QueryContext(context.Background(), query)
 	if err != nil {
@@ -47,6 +49,9 @@ func (r *userRepositoryImpl) ListAll() ([]models.User, error) {
 			&user.ID,
 			&user.PhoneNumber,
 			&user.Name,
+			&user.Datum,
+			&user.Units,
+			&user.Language,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -67,13 +72,16 @@ func (r *userRepositoryImpl) ListAll() ([]models.User, error) {
 func (r *userRepositoryImpl) GetByID(id int) (models.User, error) {
 	r.log.Debugf("Attempting to get user by ID: %d", id)
 
-	query := `SELECT id, phone_number, name, created_at, updated_at FROM users WHERE id = ? LIMIT 1`
+	query := `SELECT id, phone_number, name, datum, units, language, created_at, updated_at FROM users WHERE id = ? LIMIT 1`
 
 	var user models.User
 	err := r.db.QueryRowContext(context.Background(), query, id).Scan(
 		&user.ID,
 		&user.PhoneNumber,
 		&user.Name,
+		&user.Datum,
+		&user.Units,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -92,13 +100,16 @@ func (r *userRepositoryImpl) GetByID(id int) (models.User, error) {
 func (r *userRepositoryImpl) GetByPhoneNumber(phoneNumber string) (models.User, error) {
 	r.log.Debugf("Attempting to get user by phone number: %s", phoneNumber)
 
-	query := `SELECT id, phone_number, name, created_at, updated_at FROM users WHERE phone_number = ? LIMIT 1`
+	query := `SELECT id, phone_number, name, datum, units, language, created_at, updated_at FROM users WHERE phone_number = ? LIMIT 1`
 
 	var user models.User
 	err := r.db.QueryRowContext(context.Background(), query, phoneNumber).Scan(
 		&user.ID,
 		&user.PhoneNumber,
 		&user.Name,
+		&user.Datum,
+		&user.Units,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -120,7 +131,7 @@ func (r *userRepositoryImpl) Save(writeModel models.UserWriteModel) (models.User
 	query := `
 		INSERT INTO users (phone_number, name, created_at, updated_at) 
 		VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) 
-		RETURNING id, phone_number, name, created_at, updated_at`
+		RETURNING id, phone_number, name, datum, units, language, created_at, updated_at`
 
 	var user models.User
 	err := r.db.QueryRowContext(
@@ -132,6 +143,9 @@ func (r *userRepositoryImpl) Save(writeModel models.UserWriteModel) (models.User
 		&user.ID,
 		&user.PhoneNumber,
 		&user.Name,
+		&user.Datum,
+		&user.Units,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -151,7 +165,7 @@ func (r *userRepositoryImpl) Update(id int, writeModel models.UserWriteModel) (m
 		UPDATE users 
 		SET phone_number = ?, name = ?, updated_at = CURRENT_TIMESTAMP 
 		WHERE id = ?
-		RETURNING id, phone_number, name, created_at, updated_at`
+		RETURNING id, phone_number, name, datum, units, language, created_at, updated_at`
 
 	var user models.User
 	err := r.db.QueryRowContext(
@@ -164,6 +178,9 @@ func (r *userRepositoryImpl) Update(id int, writeModel models.UserWriteModel) (m
 		&user.ID,
 		&user.PhoneNumber,
 		&user.Name,
+		&user.Datum,
+		&user.Units,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -179,6 +196,81 @@ func (r *userRepositoryImpl) Update(id int, writeModel models.UserWriteModel) (m
 	return user, nil
 }
 
+func (r *userRepositoryImpl) UpdatePreferences(id int, datum *string, units string) (models.User, error) {
+	r.log.Debugf("Attempting to update preferences of user with id='%d': datum=%v, units=%s", id, datum, units)
+
+	query := `
+		UPDATE users
+		SET datum = ?, units = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+		RETURNING id, phone_number, name, datum, units, language, created_at, updated_at`
+
+	var user models.User
+	err := r.db.QueryRowContext(
+		context.Background(),
+		query,
+		datum,
+		units,
+		id,
+	).Scan(
+		&user.ID,
+		&user.PhoneNumber,
+		&user.Name,
+		&user.Datum,
+		&user.Units,
+		&user.Language,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.User{}, fmt.Errorf("user not found with id='%d'", id)
+		}
+		return models.User{}, fmt.Errorf("failed to update user preferences: %w", err)
+	}
+
+	r.log.Debugf("Successfully updated preferences of user with id='%d'", id)
+	return user, nil
+}
+
+func (r *userRepositoryImpl) UpdateLanguage(id int, language *string) (models.User, error) {
+	r.log.Debugf("Attempting to update language of user with id='%d': %v", id, language)
+
+	query := `
+		UPDATE users
+		SET language = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+		RETURNING id, phone_number, name, datum, units, language, created_at, updated_at`
+
+	var user models.User
+	err := r.db.QueryRowContext(
+		context.Background(),
+		query,
+		language,
+		id,
+	).Scan(
+		&user.ID,
+		&user.PhoneNumber,
+		&user.Name,
+		&user.Datum,
+		&user.Units,
+		&user.Language,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.User{}, fmt.Errorf("user not found with id='%d'", id)
+		}
+		return models.User{}, fmt.Errorf("failed to update user language: %w", err)
+	}
+
+	r.log.Debugf("Successfully updated language of user with id='%d'", id)
+	return user, nil
+}
+
 func (r *userRepositoryImpl) Delete(id int) error {
 	r.log.Debugf("Attempting to delete user with id='%d'", id)
 
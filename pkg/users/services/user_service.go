@@ -15,6 +15,12 @@ type UserService interface {
 	GetAllUsers() ([]models.User, error)
 	GetUserByID(id int) (models.User, error)
 	GetUserByPhoneNumber(phoneNumber string) (models.User, error)
+	// UpdatePreferences sets the datum, nil for the spot's, and the units heights are shown to the user in
+	UpdatePreferences(phoneNumber string, datum *string, units string) (models.User, error)
+	// UpdateLanguage sets the language replies are in, nil to guess it from the phone number
+	UpdateLanguage(phoneNumber string, language *string) (models.User, error)
+	// DeleteUser forgets the user, whose subscription and calendar feed are deleted with it
+	DeleteUser(phoneNumber string) error
 }
 
 type userServiceImpl struct {
@@ -104,3 +110,54 @@ func (s *userServiceImpl) GetUserByPhoneNumber(phoneNumber string) (models.User,
 	s.log.Debugf("Successfully retrieved user with id %d and phone number %s", user.ID, user.PhoneNumber)
 	return user, nil
 }
+
+func (s *userServiceImpl) UpdatePreferences(phoneNumber string, datum *string, units string) (models.User, error) {
+	s.log.Debugf("Updating preferences of user with phone number %s", phoneNumber)
+
+	user, err := s.userRepository.GetByPhoneNumber(phoneNumber)
+	if err != nil {
+		return models.User{}, fmt.Errorf("failed to get user by phone number %s: %w", phoneNumber, err)
+	}
+
+	updatedUser, err := s.userRepository.UpdatePreferences(user.ID, datum, units)
+	if err != nil {
+		return models.User{}, fmt.Errorf("failed to update preferences of user %d: %w", user.ID, err)
+	}
+
+	s.log.Infof("Updated preferences of user %d: datum=%v, units=%s", updatedUser.ID, updatedUser.Datum, updatedUser.Units)
+	return updatedUser, nil
+}
+
+func (s *userServiceImpl) UpdateLanguage(phoneNumber string, language *string) (models.User, error) {
+	s.log.Debugf("Updating language of user with phone number %s", phoneNumber)
+
+	user, err := s.userRepository.GetByPhoneNumber(phoneNumber)
+	if err != nil {
+		return models.User{}, fmt.Errorf("failed to get user by phone number %s: %w", phoneNumber, err)
+	}
+
+	updatedUser, err := s.userRepository.UpdateLanguage(user.ID, language)
+	if err != nil {
+		return models.User{}, fmt.Errorf("failed to update language of user %d: %w", user.ID, err)
+	}
+
+	s.log.Infof("Updated language of user %d: %v", updatedUser.ID, updatedUser.Language)
+	return updatedUser, nil
+}
+
+func (s *userServiceImpl) DeleteUser(phoneNumber string) error {
+	s.log.Debugf("Deleting user with phone number %s", phoneNumber)
+
+	user, err := s.userRepository.GetByPhoneNumber(phoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get user by phone number %s: %w", phoneNumber, err)
+	}
+
+	err = s.userRepository.Delete(user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user %d: %w", user.ID, err)
+	}
+
+	s.log.Infof("Deleted user %d", user.ID)
+	return nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"tidebot/pkg/common"
+	"time"
+)
+
+// WeatherForecastKey identifies the Open-Meteo forecast of a spot for a local date, at coordinates rounded
+// with common.RoundCoordinate.
+type WeatherForecastKey struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Date      string  `json:"date"`
+}
+
+type WeatherForecast struct {
+	ID int `json:"id"`
+	WeatherForecastKey
+	Response  string    `json:"response"`
+	FetchedAt time.Time `json:"fetched_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewWeatherForecastKey(latitude float64, longitude float64, date time.Time) WeatherForecastKey {
+	return WeatherForecastKey{
+		Latitude:  common.RoundCoordinate(latitude),
+		Longitude: common.RoundCoordinate(longitude),
+		Date:      date.Format("2006-01-02"),
+	}
+}
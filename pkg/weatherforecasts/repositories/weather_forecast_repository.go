@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"tidebot/pkg/weatherforecasts/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WeatherForecastRepository interface {
+	// Get returns the forecast for the key, or nil if there is no unexpired entry
+	Get(key models.WeatherForecastKey) (*models.WeatherForecast, error)
+	Save(key models.WeatherForecastKey, response string, expiresAt time.Time) error
+	DeleteExpired() (int64, error)
+}
+
+type weatherForecastRepositoryImpl struct {
+	db  *sql.DB
+	log echo.Logger
+}
+
+func NewWeatherForecastRepository(db *sql.DB, log echo.Logger) WeatherForecastRepository {
+	return &weatherForecastRepositoryImpl{db, log}
+}
+
+func (r *weatherForecastRepositoryImpl) Get(key models.WeatherForecastKey) (*models.WeatherForecast, error) {
+	r.log.Debugf("Attempting to get weather forecast: %+v", key)
+
+	query := `
+		SELECT id, latitude, longitude, date, response, fetched_at, expires_at
+		FROM weather_forecasts
+		WHERE latitude = ? AND longitude = ? AND date = ? AND expires_at > ?
+		LIMIT 1`
+
+	var forecast models.WeatherForecast
+	err := r.db.QueryRowContext(
+		context.Background(),
+		query,
+		key.Latitude,
+		key.Longitude,
+		key.Date,
+		time.Now().UTC(),
+	).Scan(
+		&forecast.ID,
+		&forecast.Latitude,
+		&forecast.Longitude,
+		&forecast.Date,
+		&forecast.Response,
+		&forecast.FetchedAt,
+		&forecast.ExpiresAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather forecast: %w", err)
+	}
+
+	return &forecast, nil
+}
+
+func (r *weatherForecastRepositoryImpl) Save(key models.WeatherForecastKey, response string, expiresAt time.Time) error {
+	r.log.Debugf("Attempting to save weather forecast: %+v, expires at %s", key, expiresAt)
+
+	query := `
+		INSERT INTO weather_forecasts (latitude, longitude, date, response, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(latitude, longitude, date) DO UPDATE SET
+			response = excluded.response,
+			fetched_at = excluded.fetched_at,
+			expires_at = excluded.expires_at`
+
+	_, err := r.db.ExecContext(
+		context.Background(),
+		query,
+		key.Latitude,
+		key.Longitude,
+		key.Date,
+		response,
+		time.Now().UTC(),
+		expiresAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save weather forecast: %w", err)
+	}
+
+	return nil
+}
+
+func (r *weatherForecastRepositoryImpl) DeleteExpired() (int64, error) {
+	r.log.Debugf("Attempting to delete expired weather forecasts")
+
+	result, err := r.db.ExecContext(context.Background(), `DELETE FROM weather_forecasts WHERE expires_at <= ?`, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired weather forecasts: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	r.log.Infof("Deleted %d expired weather forecasts", rowsAffected)
+	return rowsAffected, nil
+}
@@ -11,6 +11,7 @@ import (
 
 type WhatsappClient interface {
 	SendMessage(msg string, toNumber string) error
+	SendMediaMessage(mediaURL string, caption string, toNumber string) error
 	SendInteractiveTemplate(templateSID string, toNumber string) error
 	SendTemplateWithVariables(templateSID string, variables []string, toNumber string) error
 }
@@ -41,6 +42,18 @@ func (client *whatsappClientImpl) SendMessage(msg string, toNumber string) error
 	return err
 }
 
+func (client *whatsappClientImpl) SendMediaMessage(mediaURL string, caption string, toNumber string) error {
+	params := &api.CreateMessageParams{}
+	params.SetFrom(whatsappNumber(client.fromNumber))
+	params.SetTo(whatsappNumber(toNumber))
+	params.SetMediaUrl([]string{mediaURL})
+	params.SetBody(caption)
+
+	_, err := client.twilioClient.Api.CreateMessage(params)
+
+	return err
+}
+
 func (client *whatsappClientImpl) SendInteractiveTemplate(templateSID string, toNumber string) error {
 	params := &api.CreateMessageParams{}
 	params.SetFrom(whatsappNumber(client.fromNumber))
@@ -0,0 +1,189 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"tidebot/pkg/i18n"
+)
+
+// commandRequest is an incoming message addressed to a command
+type commandRequest struct {
+	phoneNumber string
+	profileName *string
+	// settings are loaded once per message, for the handlers and everything they call
+	settings userSettings
+	// arguments are the lowercased words following the command name
+	arguments []string
+}
+
+type commandHandler func(ctx context.Context, request commandRequest) error
+
+// Command is a WhatsApp command. Its keywords, aliases and help texts are looked up in every language's catalog
+// under "command.<name>.", e.g. "command.tides.keyword", so it answers to the keywords of all languages.
+type Command struct {
+	// Name is the command's catalog key, and its English keyword
+	Name  string
+	Emoji string
+	// TextArgs fill in the verbs of the usage, summary and details, e.g. a number of days
+	TextArgs []any
+	// Hidden commands work but aren't listed in the help
+	Hidden bool
+
+	handler commandHandler
+}
+
+// text is a help text of the command in the language, or "" when it has none
+func (c *Command) text(tr i18n.Localizer, field string) string {
+	key := "command." + c.Name + "." + field
+	if !tr.Has(key) {
+		return ""
+	}
+	return tr.T(key, c.TextArgs...)
+}
+
+// Keyword is the name the command is sent with in the language
+func (c *Command) Keyword(tr i18n.Localizer) string {
+	if keyword := c.text(tr, "keyword"); keyword != "" {
+		return keyword
+	}
+	return c.Name
+}
+
+// Aliases are the other names the command answers to in the language
+func (c *Command) Aliases(tr i18n.Localizer) []string {
+	return splitList(c.text(tr, "aliases"), ",")
+}
+
+// Examples are sample command lines in the language
+func (c *Command) Examples(tr i18n.Localizer) []string {
+	return splitList(c.text(tr, "examples"), "|")
+}
+
+func splitList(list string, separator string) []string {
+	var items []string
+	for _, item := range strings.Split(list, separator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// CommandRegistry routes messages and button payloads to commands
+type CommandRegistry struct {
+	commands []*Command
+	byName   map[string]*Command
+}
+
+var whitespaceRegexp = regexp.MustCompile(`\s+`)
+
+// NewCommandRegistry registers the commands in the order the help lists them, under their names, keywords and
+// aliases in every language. A name may only belong to one command.
+func NewCommandRegistry(commands ...*Command) *CommandRegistry {
+	registry := &CommandRegistry{
+		byName: make(map[string]*Command),
+	}
+
+	for _, command := range commands {
+		names := []string{command.Name}
+		for _, language := range i18n.Languages {
+			tr := i18n.NewLocalizer(language)
+			names = append(names, command.Keyword(tr))
+			names = append(names, command.Aliases(tr)...)
+		}
+
+		for _, name := range names {
+			name = strings.ToLower(name)
+			if registered, exists := registry.byName[name]; exists && registered != command {
+				panic(fmt.Sprintf("command name %q registered by %s and %s", name, registered.Name, command.Name))
+			}
+			registry.byName[name] = command
+		}
+
+		registry.commands = append(registry.commands, command)
+	}
+
+	return registry
+}
+
+// Lookup finds a command by its name or one of its aliases
+func (r *CommandRegistry) Lookup(name string) (*Command, bool) {
+	command, ok := r.byName[strings.ToLower(name)]
+	return command, ok
+}
+
+// Parse splits a message into its command and arguments, returning false when it doesn't start with a command
+func (r *CommandRegistry) Parse(body string) (*Command, []string, bool) {
+	words := whitespaceRegexp.Split(strings.ToLower(strings.TrimSpace(body)), -1)
+
+	command, ok := r.Lookup(words[0])
+	if !ok {
+		return nil, nil, false
+	}
+
+	return command, words[1:], true
+}
+
+// Button finds the command behind a template button. The payload ID is a command line whose words may be
+// joined by underscores, e.g. "tides_tomorrow", and the button text is tried when the payload isn't one.
+func (r *CommandRegistry) Button(payload string, text string) (*Command, []string, bool) {
+	if command, arguments, ok := r.Parse(strings.ReplaceAll(payload, "_", " ")); ok {
+		return command, arguments, true
+	}
+
+	return r.Parse(text)
+}
+
+// Help lists the visible commands with their examples, in the language
+func (r *CommandRegistry) Help(tr i18n.Localizer) string {
+	var help strings.Builder
+	help.WriteString(tr.T("help.title") + "\n")
+
+	for _, command := range r.commands {
+		if command.Hidden {
+			continue
+		}
+
+		help.WriteString(tr.T("help.line", command.Emoji, command.Keyword(tr), command.text(tr, "summary")) + "\n")
+		if examples := command.Examples(tr); len(examples) > 0 {
+			help.WriteString("   " + tr.T("help.examples", formatExamples(examples)) + "\n")
+		}
+	}
+
+	help.WriteString(tr.T("help.more") + "\n")
+
+	return help.String()
+}
+
+// CommandHelp describes a single command with its grammar and aliases, in the language
+func (r *CommandRegistry) CommandHelp(tr i18n.Localizer, command *Command) string {
+	var help strings.Builder
+
+	help.WriteString(fmt.Sprintf("%s *%s*", command.Emoji, command.Keyword(tr)))
+	if usage := command.text(tr, "usage"); usage != "" {
+		help.WriteString(" " + usage)
+	}
+	help.WriteString(fmt.Sprintf("\n\n%s\n", command.text(tr, "summary")))
+	if details := command.text(tr, "details"); details != "" {
+		help.WriteString(fmt.Sprintf("\n%s\n", details))
+	}
+
+	if examples := command.Examples(tr); len(examples) > 0 {
+		help.WriteString("\n" + tr.T("help.examples", formatExamples(examples)) + "\n")
+	}
+	if aliases := command.Aliases(tr); len(aliases) > 0 {
+		help.WriteString("\n" + tr.T("help.aliases", formatExamples(aliases)) + "\n")
+	}
+
+	return help.String()
+}
+
+func formatExamples(examples []string) string {
+	formatted := make([]string, len(examples))
+	for i, example := range examples {
+		formatted[i] = "_" + example + "_"
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,170 @@
+package whatsapp
+
+import (
+	"context"
+	"tidebot/pkg/common"
+)
+
+// CANCEL_COMMAND leaves a flow, and is handled before the flow sees the message
+const CANCEL_COMMAND = "cancel"
+
+// newCommandRegistry declares the commands in the order the help lists them
+func (s *whatsappServiceImpl) newCommandRegistry() *CommandRegistry {
+	return NewCommandRegistry(
+		&Command{
+			Name:     "tides",
+			Emoji:    "📱",
+			TextArgs: []any{common.MaxParsedDays},
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleTidesCommand(ctx, request.phoneNumber, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "now",
+			Emoji: "⏱️",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleNowCommand(ctx, request.phoneNumber, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "below",
+			Emoji: "🔎",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleWindowCommand(ctx, request.phoneNumber, request.settings, true, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "above",
+			Emoji: "🔎",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleWindowCommand(ctx, request.phoneNumber, request.settings, false, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "conditions",
+			Emoji: "🏄",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleConditionsCommand(ctx, request.phoneNumber, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:     "best",
+			Emoji:    "⭐",
+			TextArgs: []any{BEST_COMMAND_MAX_DAYS},
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleBestCommand(ctx, request.phoneNumber, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:     "month",
+			Emoji:    "🌙",
+			TextArgs: []any{MONTH_COMMAND_DAYS},
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleMonthCommand(ctx, request.phoneNumber, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "units",
+			Emoji: "📏",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleUnitsCommand(request.phoneNumber, request.profileName, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "datum",
+			Emoji: "📐",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleDatumCommand(request.phoneNumber, request.profileName, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "lang",
+			Emoji: "🌐",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleLangCommand(request.phoneNumber, request.profileName, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "spots",
+			Emoji: "📍",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleSpotsCommand(request.phoneNumber, request.settings)
+			},
+		},
+		&Command{
+			Name:  "calendar",
+			Emoji: "📅",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleCalendarCommand(request.phoneNumber, request.profileName, request.settings)
+			},
+		},
+		&Command{
+			Name:  "stations",
+			Emoji: "📡",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleStationsCommand(ctx, request.phoneNumber, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "setup",
+			Emoji: "🧭",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleSetupCommand(ctx, request.phoneNumber, request.profileName, request.settings)
+			},
+		},
+		&Command{
+			Name:  "start",
+			Emoji: "🔔",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleStartCommand(request.phoneNumber, request.profileName, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  "stop",
+			Emoji: "🔕",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleStopCommand(request.phoneNumber, request.settings)
+			},
+		},
+		&Command{
+			Name:  "delete",
+			Emoji: "🗑️",
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleDeleteCommand(ctx, request.phoneNumber, request.profileName, request.settings)
+			},
+		},
+		&Command{
+			Name:  "help",
+			Emoji: "❓",
+			// Help lists itself with its own line
+			Hidden: true,
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleHelpCommand(request.phoneNumber, request.settings, request.arguments)
+			},
+		},
+		&Command{
+			Name:  CANCEL_COMMAND,
+			Emoji: "✖️",
+			// The questions of the flows tell how to cancel them
+			Hidden: true,
+			handler: func(ctx context.Context, request commandRequest) error {
+				return s.handleCancelCommand(request.phoneNumber, request.settings)
+			},
+		},
+	)
+}
+
+func (s *whatsappServiceImpl) handleHelpCommand(phoneNumber string, settings userSettings, arguments []string) error {
+	tr := settings.localizer()
+
+	if len(arguments) == 0 {
+		return s.whatsappClient.SendMessage(s.commands.Help(tr), phoneNumber)
+	}
+
+	command, ok := s.commands.Lookup(arguments[0])
+	if !ok {
+		return s.whatsappClient.SendMessage(tr.T("error.unknown_command", arguments[0], s.commands.Help(tr)), phoneNumber)
+	}
+
+	return s.whatsappClient.SendMessage(s.commands.CommandHelp(tr, command), phoneNumber)
+}
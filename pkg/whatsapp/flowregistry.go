@@ -0,0 +1,80 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	conversationModels "tidebot/pkg/conversations/models"
+)
+
+// flowRequest is a reply to the question of a flow's step
+type flowRequest struct {
+	phoneNumber  string
+	profileName  *string
+	settings     userSettings
+	conversation *conversationModels.Conversation
+	// answer is the lowercased reply, without surrounding spaces
+	answer string
+}
+
+// errUnexpectedAnswer is returned by a step that can't make sense of a reply. The reply is then run as a
+// command, leaving the flow, or the question is asked again.
+var errUnexpectedAnswer = errors.New("unexpected answer")
+
+// FlowStep asks a question and handles its answer
+type FlowStep struct {
+	Name string
+
+	ask func(ctx context.Context, request flowRequest) error
+	// answer handles the reply, keeping what it needs in the conversation's data, and returns the next step,
+	// or "" when the flow is done
+	answer func(ctx context.Context, request flowRequest) (string, error)
+}
+
+// Flow is a guided conversation, e.g. onboarding, that asks its steps' questions one message at a time. The
+// step a phone number is at is kept in the conversations table until it expires, and cancel leaves a flow at
+// any step.
+type Flow struct {
+	Name string
+	// Steps start with the first question
+	Steps []*FlowStep
+}
+
+func (f *Flow) step(name string) (*FlowStep, bool) {
+	for _, step := range f.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return nil, false
+}
+
+// FlowRegistry finds flows by name
+type FlowRegistry struct {
+	byName map[string]*Flow
+}
+
+// NewFlowRegistry registers the flows under their names, which must be unique and have steps
+func NewFlowRegistry(flows ...*Flow) *FlowRegistry {
+	registry := &FlowRegistry{
+		byName: make(map[string]*Flow),
+	}
+
+	for _, flow := range flows {
+		if _, exists := registry.byName[flow.Name]; exists {
+			panic(fmt.Sprintf("flow %q registered twice", flow.Name))
+		}
+		if len(flow.Steps) == 0 {
+			panic(fmt.Sprintf("flow %q has no steps", flow.Name))
+		}
+		registry.byName[flow.Name] = flow
+	}
+
+	return registry
+}
+
+// Lookup finds a flow by its name
+func (r *FlowRegistry) Lookup(name string) (*Flow, bool) {
+	flow, ok := r.byName[name]
+	return flow, ok
+}
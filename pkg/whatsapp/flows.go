@@ -0,0 +1,371 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"tidebot/pkg/common"
+	conversationModels "tidebot/pkg/conversations/models"
+	"tidebot/pkg/i18n"
+	spotModels "tidebot/pkg/spots/models"
+	"time"
+)
+
+const (
+	SETUP_FLOW     = "setup"
+	DELETE_FLOW    = "delete"
+	SUBSCRIBE_FLOW = "subscribe"
+	STATIONS_FLOW  = "stations"
+)
+
+// YES_WORDS confirm a question such as deleting the user's data
+var YES_WORDS = map[string]bool{"yes": true, "y": true, "sí": true, "si": true, "ja": true, "tak": true}
+
+// newFlowRegistry declares the guided flows
+func (s *whatsappServiceImpl) newFlowRegistry() *FlowRegistry {
+	return NewFlowRegistry(
+		&Flow{
+			Name: SETUP_FLOW,
+			Steps: []*FlowStep{
+				{Name: "spot", ask: s.askSetupSpot, answer: s.answerSetupSpot},
+				{Name: "hour", ask: s.askSetupHour, answer: s.answerSetupHour},
+				{Name: "language", ask: s.askSetupLanguage, answer: s.answerSetupLanguage},
+			},
+		},
+		&Flow{
+			Name: DELETE_FLOW,
+			Steps: []*FlowStep{
+				{Name: "confirm", ask: s.askDeleteConfirmation, answer: s.answerDeleteConfirmation},
+			},
+		},
+		&Flow{
+			Name: SUBSCRIBE_FLOW,
+			Steps: []*FlowStep{
+				{Name: "confirm", ask: s.askSubscribeConfirmation, answer: s.answerSubscribeConfirmation},
+			},
+		},
+		&Flow{
+			Name: STATIONS_FLOW,
+			Steps: []*FlowStep{
+				{Name: "place", ask: s.askStationsPlace, answer: s.answerStationsPlace},
+			},
+		},
+	)
+}
+
+// startFlow asks the first question of the flow, replacing any flow the phone number was in. The data, which may
+// be nil, is what the steps know from the start.
+func (s *whatsappServiceImpl) startFlow(ctx context.Context, phoneNumber string, profileName *string, settings userSettings, name string, data map[string]string) error {
+	flow, ok := s.flows.Lookup(name)
+	if !ok {
+		return fmt.Errorf("unknown flow %s", name)
+	}
+
+	s.log.Infof("Starting flow %s for %s", name, phoneNumber)
+
+	conversation := conversationModels.Conversation{
+		PhoneNumber: phoneNumber,
+		Flow:        flow.Name,
+		Step:        flow.Steps[0].Name,
+		Data:        make(map[string]string),
+		ExpiresAt:   time.Now().Add(s.conversationTTL),
+	}
+	for key, value := range data {
+		conversation.Data[key] = value
+	}
+
+	err := s.conversationRepository.Save(conversation)
+	if err != nil {
+		s.log.Errorf("Failed to start flow %s for %s: %v", name, phoneNumber, err)
+		return s.whatsappClient.SendMessage(settings.localizer().T("error.generic"), phoneNumber)
+	}
+
+	return flow.Steps[0].ask(ctx, flowRequest{
+		phoneNumber:  phoneNumber,
+		profileName:  profileName,
+		settings:     settings,
+		conversation: &conversation,
+	})
+}
+
+// continueFlow hands the message to the step the conversation is at, and asks the next question. Messages the
+// step doesn't expect run as commands, which leaves the flow, or get the question asked again.
+func (s *whatsappServiceImpl) continueFlow(ctx context.Context, conversation conversationModels.Conversation, body string, profileName *string, settings userSettings) error {
+	phoneNumber := conversation.PhoneNumber
+
+	flow, ok := s.flows.Lookup(conversation.Flow)
+	var step *FlowStep
+	if ok {
+		step, ok = flow.step(conversation.Step)
+	}
+	if !ok {
+		// Flows and steps may be renamed between releases
+		s.log.Warnf("Dropping conversation of %s at unknown step %s/%s", phoneNumber, conversation.Flow, conversation.Step)
+		s.endFlow(phoneNumber)
+		return s.processCommand(ctx, body, phoneNumber, profileName, settings)
+	}
+
+	s.log.Debugf("Continuing flow %s at step %s for %s", flow.Name, step.Name, phoneNumber)
+
+	request := flowRequest{
+		phoneNumber:  phoneNumber,
+		profileName:  profileName,
+		settings:     settings,
+		conversation: &conversation,
+		answer:       normalizeAnswer(body),
+	}
+
+	next, err := step.answer(ctx, request)
+	if errors.Is(err, errUnexpectedAnswer) {
+		if command, arguments, ok := s.commands.Parse(body); ok {
+			s.log.Infof("Leaving flow %s of %s for command %s", flow.Name, phoneNumber, command.Name)
+			s.endFlow(phoneNumber)
+			return s.runCommand(ctx, command, phoneNumber, profileName, settings, arguments)
+		}
+
+		err = s.whatsappClient.SendMessage(settings.localizer().T("flow.unexpected"), phoneNumber)
+		if err != nil {
+			return err
+		}
+		return step.ask(ctx, request)
+	}
+	if err != nil {
+		return err
+	}
+
+	if next == "" {
+		s.log.Infof("Finished flow %s for %s", flow.Name, phoneNumber)
+		s.endFlow(phoneNumber)
+		return nil
+	}
+
+	nextStep, ok := flow.step(next)
+	if !ok {
+		s.endFlow(phoneNumber)
+		return fmt.Errorf("flow %s has no step %s", flow.Name, next)
+	}
+
+	conversation.Step = nextStep.Name
+	conversation.ExpiresAt = time.Now().Add(s.conversationTTL)
+	err = s.conversationRepository.Save(conversation)
+	if err != nil {
+		s.log.Errorf("Failed to save conversation of %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(settings.localizer().T("error.generic"), phoneNumber)
+	}
+
+	return nextStep.ask(ctx, request)
+}
+
+// endFlow forgets the flow the phone number is in, if any
+func (s *whatsappServiceImpl) endFlow(phoneNumber string) {
+	err := s.conversationRepository.Delete(phoneNumber)
+	if err != nil {
+		s.log.Errorf("Failed to end conversation of %s: %v", phoneNumber, err)
+	}
+}
+
+// handleCancelCommand leaves the flow the user is in
+func (s *whatsappServiceImpl) handleCancelCommand(phoneNumber string, settings userSettings) error {
+	s.log.Infof("Handling cancel command for %s", phoneNumber)
+
+	tr := settings.localizer()
+
+	conversation, err := s.conversationRepository.Get(phoneNumber)
+	if err != nil {
+		s.log.Errorf("Failed to get conversation of %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+	if conversation == nil {
+		return s.whatsappClient.SendMessage(tr.T("flow.nothing_to_cancel"), phoneNumber)
+	}
+
+	s.endFlow(phoneNumber)
+
+	return s.whatsappClient.SendMessage(tr.T("flow.cancelled"), phoneNumber)
+}
+
+func normalizeAnswer(body string) string {
+	return whitespaceRegexp.ReplaceAllString(strings.ToLower(strings.TrimSpace(body)), " ")
+}
+
+// handleSetupCommand walks the user through picking a spot, the hour of the daily report and a language
+func (s *whatsappServiceImpl) handleSetupCommand(ctx context.Context, phoneNumber string, profileName *string, settings userSettings) error {
+	s.log.Infof("Handling setup command for %s", phoneNumber)
+
+	return s.startFlow(ctx, phoneNumber, profileName, settings, SETUP_FLOW, nil)
+}
+
+func (s *whatsappServiceImpl) askSetupSpot(ctx context.Context, request flowRequest) error {
+	tr := request.settings.localizer()
+
+	spots, err := s.spotRepository.ListAll()
+	if err != nil {
+		s.log.Errorf("Failed to list spots: %v", err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), request.phoneNumber)
+	}
+
+	var list strings.Builder
+	for i, spot := range spots {
+		list.WriteString(fmt.Sprintf("%d. *%s* - %s\n", i+1, spot.Slug, spot.DisplayLabel))
+	}
+
+	return s.whatsappClient.SendMessage(tr.T("setup.spot", list.String()), request.phoneNumber)
+}
+
+// answerSetupSpot takes the number of a spot in the list, its slug or its name
+func (s *whatsappServiceImpl) answerSetupSpot(ctx context.Context, request flowRequest) (string, error) {
+	spots, err := s.spotRepository.ListAll()
+	if err != nil {
+		s.log.Errorf("Failed to list spots: %v", err)
+		return "", s.whatsappClient.SendMessage(request.settings.localizer().T("error.generic"), request.phoneNumber)
+	}
+
+	if number, err := strconv.Atoi(request.answer); err == nil && number >= 1 && number <= len(spots) {
+		request.conversation.Data["spot"] = spots[number-1].Slug
+		return "hour", nil
+	}
+
+	slug := strings.ReplaceAll(request.answer, " ", "-")
+	for _, spot := range spots {
+		if slug == spot.Slug || request.answer == strings.ToLower(spot.Name) {
+			request.conversation.Data["spot"] = spot.Slug
+			return "hour", nil
+		}
+	}
+
+	return "", errUnexpectedAnswer
+}
+
+func (s *whatsappServiceImpl) askSetupHour(ctx context.Context, request flowRequest) error {
+	tr := request.settings.localizer()
+
+	spot, err := s.spotRepository.GetBySlug(request.conversation.Data["spot"])
+	if err != nil {
+		s.log.Errorf("Failed to get spot %s: %v", request.conversation.Data["spot"], err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), request.phoneNumber)
+	}
+
+	return s.whatsappClient.SendMessage(tr.T("setup.hour", spot.DisplayLabel, spot.Timezone), request.phoneNumber)
+}
+
+// answerSetupHour takes a time of day such as "7", "7 am", "18:00" or "19h", whose minutes are dropped
+func (s *whatsappServiceImpl) answerSetupHour(ctx context.Context, request flowRequest) (string, error) {
+	answer := strings.ReplaceAll(request.answer, " ", "")
+	answer = strings.TrimSuffix(strings.TrimSuffix(answer, "uhr"), "h")
+
+	hour, _, err := common.ParseTimeOfDay(answer)
+	if err != nil {
+		return "", errUnexpectedAnswer
+	}
+
+	request.conversation.Data["hour"] = strconv.Itoa(hour)
+	return "language", nil
+}
+
+func (s *whatsappServiceImpl) askSetupLanguage(ctx context.Context, request flowRequest) error {
+	tr := request.settings.localizer()
+
+	var list strings.Builder
+	for _, language := range i18n.Languages {
+		list.WriteString(fmt.Sprintf("_%s_ - %s\n", language, language.Name()))
+	}
+
+	return s.whatsappClient.SendMessage(tr.T("setup.language", list.String()), request.phoneNumber)
+}
+
+// answerSetupLanguage takes a language code or name, or "auto", and saves the answers
+func (s *whatsappServiceImpl) answerSetupLanguage(ctx context.Context, request flowRequest) (string, error) {
+	var language *string
+	if request.answer != "auto" {
+		parsed, ok := i18n.ParseLanguage(request.answer)
+		if !ok {
+			return "", errUnexpectedAnswer
+		}
+		code := string(parsed)
+		language = &code
+	}
+
+	spot, hour, err := s.finishSetup(request, language)
+	if err != nil {
+		s.log.Errorf("Failed to finish the setup of %s: %v", request.phoneNumber, err)
+		return "", s.whatsappClient.SendMessage(request.settings.localizer().T("error.generic"), request.phoneNumber)
+	}
+
+	// The confirmation is in the picked language
+	tr := s.getUserSettings(request.phoneNumber).localizer()
+
+	return "", s.whatsappClient.SendMessage(tr.T("setup.done", spot.DisplayLabel, hour, tr.Language.Name()), request.phoneNumber)
+}
+
+// finishSetup subscribes the user to the spot of the answers at their hour, and sets their language
+func (s *whatsappServiceImpl) finishSetup(request flowRequest, language *string) (spotModels.Spot, int, error) {
+	spot, err := s.spotRepository.GetBySlug(request.conversation.Data["spot"])
+	if err != nil {
+		return spotModels.Spot{}, 0, fmt.Errorf("failed to get spot %s: %w", request.conversation.Data["spot"], err)
+	}
+
+	hour, err := strconv.Atoi(request.conversation.Data["hour"])
+	if err != nil {
+		return spotModels.Spot{}, 0, fmt.Errorf("invalid hour %q: %w", request.conversation.Data["hour"], err)
+	}
+
+	user, err := s.userService.SaveUser(request.phoneNumber, request.profileName)
+	if err != nil {
+		return spotModels.Spot{}, 0, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	err = s.notificationSubscriptionRepository.CreateSubscription(user.ID, spot.ID)
+	if err != nil {
+		return spotModels.Spot{}, 0, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	err = s.notificationSubscriptionRepository.UpdateNotifyHour(user.ID, &hour)
+	if err != nil {
+		return spotModels.Spot{}, 0, fmt.Errorf("failed to set notify hour: %w", err)
+	}
+
+	_, err = s.userService.UpdateLanguage(request.phoneNumber, language)
+	if err != nil {
+		return spotModels.Spot{}, 0, fmt.Errorf("failed to set language: %w", err)
+	}
+
+	return spot, hour, nil
+}
+
+// handleDeleteCommand asks the user to confirm the deletion of their data
+func (s *whatsappServiceImpl) handleDeleteCommand(ctx context.Context, phoneNumber string, profileName *string, settings userSettings) error {
+	s.log.Infof("Handling delete command for %s", phoneNumber)
+
+	_, err := s.userService.GetUserByPhoneNumber(phoneNumber)
+	if err != nil {
+		return s.whatsappClient.SendMessage(settings.localizer().T("delete.none"), phoneNumber)
+	}
+
+	return s.startFlow(ctx, phoneNumber, profileName, settings, DELETE_FLOW, nil)
+}
+
+func (s *whatsappServiceImpl) askDeleteConfirmation(ctx context.Context, request flowRequest) error {
+	return s.whatsappClient.SendMessage(request.settings.localizer().T("delete.confirm"), request.phoneNumber)
+}
+
+// answerDeleteConfirmation deletes the user's data on yes, and keeps it on anything but a command
+func (s *whatsappServiceImpl) answerDeleteConfirmation(ctx context.Context, request flowRequest) (string, error) {
+	tr := request.settings.localizer()
+
+	if !YES_WORDS[request.answer] {
+		if _, _, ok := s.commands.Parse(request.answer); ok {
+			return "", errUnexpectedAnswer
+		}
+		return "", s.whatsappClient.SendMessage(tr.T("delete.kept"), request.phoneNumber)
+	}
+
+	err := s.userService.DeleteUser(request.phoneNumber)
+	if err != nil {
+		s.log.Errorf("Failed to delete user %s: %v", request.phoneNumber, err)
+		return "", s.whatsappClient.SendMessage(tr.T("error.generic"), request.phoneNumber)
+	}
+
+	return "", s.whatsappClient.SendMessage(tr.T("delete.done"), request.phoneNumber)
+}
@@ -0,0 +1,145 @@
+package whatsapp
+
+import (
+	"fmt"
+	"strings"
+	"tidebot/pkg/astronomy"
+	"tidebot/pkg/common"
+	"tidebot/pkg/i18n"
+	"tidebot/pkg/sessions"
+	"tidebot/pkg/tidecycle"
+	"tidebot/pkg/worldtides"
+)
+
+// MOON_PHASE_KEYS are the catalog keys of the moon phase names
+var MOON_PHASE_KEYS = map[astronomy.MoonPhaseName]string{
+	astronomy.NewMoon:        "moon.new",
+	astronomy.WaxingCrescent: "moon.waxing_crescent",
+	astronomy.FirstQuarter:   "moon.first_quarter",
+	astronomy.WaxingGibbous:  "moon.waxing_gibbous",
+	astronomy.FullMoon:       "moon.full",
+	astronomy.WaningGibbous:  "moon.waning_gibbous",
+	astronomy.LastQuarter:    "moon.last_quarter",
+	astronomy.WaningCrescent: "moon.waning_crescent",
+}
+
+// TIDE_CYCLE_KEYS are the catalog keys of the kinds of tides
+var TIDE_CYCLE_KEYS = map[tidecycle.Kind]string{
+	tidecycle.Spring:       "cycle.spring",
+	tidecycle.Neap:         "cycle.neap",
+	tidecycle.Intermediate: "cycle.intermediate",
+}
+
+// DATE_ERROR_KEYS are the catalog keys of the reasons a date wasn't understood, but for too many days which
+// is a plural
+var DATE_ERROR_KEYS = map[common.DateErrorReason]string{
+	common.NotADate:             "date.not_a_date",
+	common.NoSuchDay:            "date.no_such_day",
+	common.NotANumberOfDays:     "date.not_a_number_of_days",
+	common.NotASingleDay:        "date.not_a_single_day",
+	common.RangeEndsBeforeStart: "date.ends_before_start",
+}
+
+// localizer speaks the user's language, the one of their phone number's country unless they picked another
+func (u userSettings) localizer() i18n.Localizer {
+	return i18n.NewLocalizer(u.language)
+}
+
+// handleLangCommand shows or sets the language replies are in, "lang auto" going back to the phone number's
+func (s *whatsappServiceImpl) handleLangCommand(phoneNumber string, profileName *string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling lang command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+	codes := make([]string, len(i18n.Languages))
+	for i, language := range i18n.Languages {
+		codes[i] = "_" + string(language) + "_"
+	}
+
+	if len(arguments) == 0 {
+		return s.whatsappClient.SendMessage(tr.T("lang.current", tr.Language.Name(), strings.Join(codes, ", ")), phoneNumber)
+	}
+
+	var language *string
+	if arguments[0] != "auto" {
+		parsed, ok := i18n.ParseLanguage(arguments[0])
+		if !ok {
+			return s.whatsappClient.SendMessage(tr.T("lang.invalid", strings.Join(codes, ", ")), phoneNumber)
+		}
+		code := string(parsed)
+		language = &code
+	}
+
+	_, err := s.userService.SaveUser(phoneNumber, profileName)
+	if err == nil {
+		_, err = s.userService.UpdateLanguage(phoneNumber, language)
+	}
+	if err != nil {
+		s.log.Errorf("Failed to update language of %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	// The confirmation is in the new language
+	tr = s.getUserSettings(phoneNumber).localizer()
+	if language == nil {
+		return s.whatsappClient.SendMessage(tr.T("lang.updated_auto", tr.Language.Name()), phoneNumber)
+	}
+	return s.whatsappClient.SendMessage(tr.T("lang.updated", tr.Language.Name()), phoneNumber)
+}
+
+// formatDateError words why a date wasn't understood
+func formatDateError(tr i18n.Localizer, dateErr *common.DateError) string {
+	reason := string(dateErr.Reason)
+	if dateErr.Reason == common.TooManyDays {
+		reason = tr.N("date.too_many_days", common.MaxParsedDays)
+	} else if key, ok := DATE_ERROR_KEYS[dateErr.Reason]; ok {
+		reason = tr.T(key)
+	}
+
+	return tr.T("error.date", dateErr.Expression, reason)
+}
+
+// extremeName is e.g. "High Tide", or "Next Low Tide" when next is set
+func extremeName(tr i18n.Localizer, extreme worldtides.Extreme, next bool) string {
+	switch {
+	case extreme.IsHighTide() && next:
+		return tr.T("tide.next_high")
+	case extreme.IsHighTide():
+		return tr.T("tide.high")
+	case next:
+		return tr.T("tide.next_low")
+	default:
+		return tr.T("tide.low")
+	}
+}
+
+// moonPhaseName is the localized name of the moon phase
+func moonPhaseName(tr i18n.Localizer, name astronomy.MoonPhaseName) string {
+	if key, ok := MOON_PHASE_KEYS[name]; ok {
+		return tr.T(key)
+	}
+	return string(name)
+}
+
+// tideCycleName is e.g. "spring tides"
+func tideCycleName(tr i18n.Localizer, kind tidecycle.Kind) string {
+	if key, ok := TIDE_CYCLE_KEYS[kind]; ok {
+		return tr.T(key)
+	}
+	return fmt.Sprintf("%s tides", kind)
+}
+
+// formatTideRule is e.g. "mid tide rising"
+func formatTideRule(tr i18n.Localizer, rule sessions.TideRule) string {
+	if rule.Movement == sessions.AnyMovement {
+		return tr.T("session." + string(rule.Level))
+	}
+	return tr.T("session."+string(rule.Level)) + " " + tr.T("session."+string(rule.Movement))
+}
+
+// unitsName is the localized name of the units
+func unitsName(tr i18n.Localizer, units common.Units) string {
+	if units == common.UnitsFeet {
+		return tr.T("units.feet")
+	}
+	return tr.T("units.meters")
+}
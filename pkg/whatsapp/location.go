@@ -0,0 +1,158 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"tidebot/pkg/common"
+	conversationModels "tidebot/pkg/conversations/models"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/stations"
+)
+
+// LOCATION_MAX_SPOT_DISTANCE_KM is how far from a pin the nearest spot can be for its tides to be sent
+const LOCATION_MAX_SPOT_DISTANCE_KM = 100
+
+func (s *whatsappServiceImpl) ProcessLocation(ctx context.Context, latitude float64, longitude float64, from string, profileName *string) error {
+	s.log.Debugf("Processing WhatsApp location - %.5f,%.5f from: %s", latitude, longitude, from)
+
+	cleanPhoneNumber := strings.TrimPrefix(from, "whatsapp:")
+	settings := s.getUserSettings(cleanPhoneNumber)
+
+	tr := settings.localizer()
+
+	conversation, err := s.conversationRepository.Get(cleanPhoneNumber)
+	if err != nil {
+		s.log.Errorf("Failed to get conversation of %s: %v", cleanPhoneNumber, err)
+	}
+
+	// A pin is answered like a command, leaving any flow, unless it is the place a stations search waits for
+	s.endFlow(cleanPhoneNumber)
+
+	if conversation != nil && conversation.Flow == STATIONS_FLOW {
+		s.log.Infof("Finding stations near %.5f,%.5f for %s", latitude, longitude, cleanPhoneNumber)
+		return s.sendStationsNear(ctx, cleanPhoneNumber, tr, fmt.Sprintf("%.5f,%.5f", latitude, longitude), stationsRadiusKm(*conversation))
+	}
+
+	spots, err := s.spotRepository.ListAll()
+	if err != nil {
+		s.log.Errorf("Failed to list spots: %v", err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), cleanPhoneNumber)
+	}
+
+	spot, distanceKm, ok := nearestSpot(spots, latitude, longitude)
+	if !ok {
+		return s.whatsappClient.SendMessage(tr.T("location.no_spots"), cleanPhoneNumber)
+	}
+
+	s.log.Infof("Nearest spot to %.5f,%.5f for %s is %s, %.1fkm away", latitude, longitude, cleanPhoneNumber, spot.Slug, distanceKm)
+
+	// The tides of a spot further away are of no use at the pin
+	if distanceKm > LOCATION_MAX_SPOT_DISTANCE_KM {
+		return s.whatsappClient.SendMessage(tr.T("location.too_far", LOCATION_MAX_SPOT_DISTANCE_KM), cleanPhoneNumber)
+	}
+
+	err = s.whatsappClient.SendMessage(tr.T("location.nearest", spot.DisplayLabel, distanceKm), cleanPhoneNumber)
+	if err != nil {
+		return err
+	}
+
+	err = s.handleTidesCommand(ctx, cleanPhoneNumber, settings, []string{spot.Slug})
+	if err != nil {
+		return err
+	}
+
+	if s.isSubscribedTo(cleanPhoneNumber, spot) {
+		return nil
+	}
+
+	return s.startFlow(ctx, cleanPhoneNumber, profileName, settings, SUBSCRIBE_FLOW, map[string]string{"spot": spot.Slug})
+}
+
+// nearestSpot is the spot closest to the coordinates with its distance, false when there are no spots
+func nearestSpot(spots []spotModels.Spot, latitude float64, longitude float64) (spotModels.Spot, float64, bool) {
+	var nearest spotModels.Spot
+	nearestKm := math.Inf(1)
+
+	for _, spot := range spots {
+		distanceKm := common.DistanceKm(latitude, longitude, spot.Latitude, spot.Longitude)
+		if distanceKm < nearestKm {
+			nearest, nearestKm = spot, distanceKm
+		}
+	}
+
+	return nearest, nearestKm, len(spots) > 0
+}
+
+// isSubscribedTo tells whether the user already gets the daily notifications of the spot
+func (s *whatsappServiceImpl) isSubscribedTo(phoneNumber string, spot spotModels.Spot) bool {
+	user, err := s.userService.GetUserByPhoneNumber(phoneNumber)
+	if err != nil {
+		return false
+	}
+
+	subscription, err := s.notificationSubscriptionRepository.GetSubscriptionByUserID(user.ID)
+	if err != nil || subscription == nil {
+		return false
+	}
+
+	return subscription.Enabled && subscription.SpotID == spot.ID
+}
+
+// askSubscribeConfirmation offers the daily notifications of the spot, instead of those of the user's spot if
+// they have one
+func (s *whatsappServiceImpl) askSubscribeConfirmation(ctx context.Context, request flowRequest) error {
+	tr := request.settings.localizer()
+
+	spot, err := s.spotRepository.GetBySlug(request.conversation.Data["spot"])
+	if err != nil {
+		s.log.Errorf("Failed to get spot %s: %v", request.conversation.Data["spot"], err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), request.phoneNumber)
+	}
+
+	current, err := s.getSubscribedSpot(request.phoneNumber)
+	if err == nil && current.ID != spot.ID {
+		return s.whatsappClient.SendMessage(tr.T("subscribe.offer_switch", spot.DisplayLabel, current.DisplayLabel), request.phoneNumber)
+	}
+
+	return s.whatsappClient.SendMessage(tr.T("subscribe.offer", spot.DisplayLabel), request.phoneNumber)
+}
+
+// answerSubscribeConfirmation subscribes the user to the spot on yes, and lets it be on anything but a command
+func (s *whatsappServiceImpl) answerSubscribeConfirmation(ctx context.Context, request flowRequest) (string, error) {
+	slug := request.conversation.Data["spot"]
+
+	if !YES_WORDS[request.answer] {
+		if _, _, ok := s.commands.Parse(request.answer); ok {
+			return "", errUnexpectedAnswer
+		}
+		return "", s.whatsappClient.SendMessage(request.settings.localizer().T("subscribe.declined", slug), request.phoneNumber)
+	}
+
+	return "", s.handleStartCommand(request.phoneNumber, request.profileName, request.settings, []string{slug})
+}
+
+// askStationsPlace asks where to look for tide stations, after a "stations near" without a place
+func (s *whatsappServiceImpl) askStationsPlace(ctx context.Context, request flowRequest) error {
+	return s.whatsappClient.SendMessage(request.settings.localizer().T("stations.ask_place"), request.phoneNumber)
+}
+
+// answerStationsPlace lists the tide stations near the place sent. Location pins answer it in ProcessLocation.
+func (s *whatsappServiceImpl) answerStationsPlace(ctx context.Context, request flowRequest) (string, error) {
+	if _, _, ok := s.commands.Parse(request.answer); ok {
+		return "", errUnexpectedAnswer
+	}
+
+	return "", s.sendStationsNear(ctx, request.phoneNumber, request.settings.localizer(), request.answer, stationsRadiusKm(*request.conversation))
+}
+
+// stationsRadiusKm is the radius the stations search was started with
+func stationsRadiusKm(conversation conversationModels.Conversation) int {
+	radiusKm, err := strconv.Atoi(conversation.Data["radius"])
+	if err != nil {
+		return stations.DefaultRadiusKm
+	}
+	return radiusKm
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
@@ -65,20 +66,45 @@ func RegisterWhatsappWebhook(e *echo.Echo, whatsappService WhatsAppService) {
 					profileNamePtr = &profileName
 				}
 
-				// Determine what message to process
-				var messageToProcess string
 				if messageType == "button" && buttonPayload != "" {
-					// Use button payload (ID) for button responses
-					messageToProcess = buttonPayload
+					// Button responses are routed by their payload (ID)
 					logger.Infof("📱 Processing button response - ID: %s, Text: %s", buttonPayload, buttonText)
-				} else {
-					// Use message body for regular text messages
-					messageToProcess = messageBody
-					logger.Infof("📱 Processing text message: %s", messageBody)
+					err := whatsappService.ProcessButton(c.Request().Context(), buttonPayload, buttonText, from, profileNamePtr)
+					if err != nil {
+						logger.Errorf("📱 Failed to process button response: %v", err)
+						return c.JSON(http.StatusInternalServerError, map[string]string{
+							"error": "Failed to process message",
+						})
+					}
+
+					return c.JSON(http.StatusOK, map[string]string{
+						"status":  "received",
+						"message": "Webhook processed successfully",
+					})
 				}
 
-				if messageToProcess != "" {
-					err := whatsappService.ProcessMessage(messageToProcess, from, profileNamePtr)
+				if latitude, longitude, ok := parseLocation(formData); ok {
+					// A shared location pin gets the tides of the nearest spot
+					logger.Infof("📱 Processing location pin: %f,%f", latitude, longitude)
+					err := whatsappService.ProcessLocation(c.Request().Context(), latitude, longitude, from, profileNamePtr)
+					if err != nil {
+						logger.Errorf("📱 Failed to process location pin: %v", err)
+						return c.JSON(http.StatusInternalServerError, map[string]string{
+							"error": "Failed to process message",
+						})
+					}
+
+					return c.JSON(http.StatusOK, map[string]string{
+						"status":  "received",
+						"message": "Webhook processed successfully",
+					})
+				}
+
+				// Use message body for regular text messages
+				logger.Infof("📱 Processing text message: %s", messageBody)
+
+				if messageBody != "" {
+					err := whatsappService.ProcessMessage(c.Request().Context(), messageBody, from, profileNamePtr)
 					if err != nil {
 						logger.Errorf("📱 Failed to process message: %v", err)
 						return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -98,3 +124,19 @@ func RegisterWhatsappWebhook(e *echo.Echo, whatsappService WhatsAppService) {
 
 	// TODO: implement status callback endpoint -- http://twilio.com/docs/whatsapp/sandbox#set-a-status-callback-url-to-track-message-delivery
 }
+
+// parseLocation reads the coordinates of a location message, whose Latitude and Longitude fields Twilio only
+// sends for shared pins
+func parseLocation(formData url.Values) (float64, float64, bool) {
+	latitude, err := strconv.ParseFloat(formData.Get("Latitude"), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return 0, 0, false
+	}
+
+	longitude, err := strconv.ParseFloat(formData.Get("Longitude"), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return 0, 0, false
+	}
+
+	return latitude, longitude, true
+}
@@ -1,15 +1,29 @@
 package whatsapp
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"os"
-	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"tidebot/pkg/astronomy"
+	"tidebot/pkg/calendars"
+	"tidebot/pkg/charts"
 	"tidebot/pkg/common"
+	conversationRepos "tidebot/pkg/conversations/repositories"
 	"tidebot/pkg/environment"
+	"tidebot/pkg/i18n"
 	"tidebot/pkg/notifications/repositories"
+	"tidebot/pkg/openmeteo"
+	"tidebot/pkg/sessions"
+	spotModels "tidebot/pkg/spots/models"
+	spotRepos "tidebot/pkg/spots/repositories"
+	"tidebot/pkg/stations"
+	"tidebot/pkg/tidecycle"
 	"tidebot/pkg/users/services"
 	"tidebot/pkg/worldtides"
 	"time"
@@ -18,316 +32,1423 @@ import (
 )
 
 type WhatsAppService interface {
-	ProcessMessage(body string, from string, profileName *string) error
-	SendTideExtremesMessage(phoneNumber string, extremes []worldtides.Extreme, date time.Time) error
-	SendDailyTideNotification(phoneNumber string, userName string, extremes []worldtides.Extreme) error
+	ProcessMessage(ctx context.Context, body string, from string, profileName *string) error
+	// ProcessButton runs the command behind a template button's payload, or its text
+	ProcessButton(ctx context.Context, payload string, text string, from string, profileName *string) error
+	// ProcessLocation replies to a shared location pin with the tides of the nearest spot, and offers to subscribe to it
+	ProcessLocation(ctx context.Context, latitude float64, longitude float64, from string, profileName *string) error
+	SendTideExtremesMessage(phoneNumber string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, date time.Time) error
+	SendDailyTideNotification(ctx context.Context, phoneNumber string, userName string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse) error
+	// SendSuspectTidesAlert tells an admin the job held back the reports of a spot because its tides look wrong
+	SendSuspectTidesAlert(phoneNumber string, job string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, date time.Time) error
 }
 
 type whatsappServiceImpl struct {
 	userService                        services.UserService
 	notificationSubscriptionRepository repositories.NotificationSubscriptionRepository
-	worldTidesClient                   worldtides.WorldTidesClient
+	spotRepository                     spotRepos.SpotRepository
+	tidesClient                        worldtides.WorldTidesClient
+	marineWeatherClient                openmeteo.MarineWeatherClient
+	stationsService                    stations.StationsService
+	calendarsService                   calendars.CalendarsService
+	conversationRepository             conversationRepos.ConversationRepository
+	conversationTTL                    time.Duration
 	whatsappClient                     WhatsappClient
+	publicBaseURL                      string
+	commands                           *CommandRegistry
+	flows                              *FlowRegistry
 	log                                echo.Logger
 }
 
-func NewWhatsAppService(userService services.UserService, notificationSubscriptionRepository repositories.NotificationSubscriptionRepository, worldTidesClient worldtides.WorldTidesClient, whatsappClient WhatsappClient, log echo.Logger) WhatsAppService {
-	return &whatsappServiceImpl{
+// NewWhatsAppService creates the service. Tide charts are only sent when publicBaseURL, where Twilio can fetch them, is set.
+// Guided flows are forgotten when they get no answer for conversationTTL.
+func NewWhatsAppService(userService services.UserService, notificationSubscriptionRepository repositories.NotificationSubscriptionRepository, spotRepository spotRepos.SpotRepository, tidesClient worldtides.WorldTidesClient, marineWeatherClient openmeteo.MarineWeatherClient, stationsService stations.StationsService, calendarsService calendars.CalendarsService, conversationRepository conversationRepos.ConversationRepository, conversationTTL time.Duration, whatsappClient WhatsappClient, publicBaseURL string, log echo.Logger) WhatsAppService {
+	service := &whatsappServiceImpl{
 		userService:                        userService,
 		notificationSubscriptionRepository: notificationSubscriptionRepository,
-		worldTidesClient:                   worldTidesClient,
+		spotRepository:                     spotRepository,
+		tidesClient:                        tidesClient,
+		marineWeatherClient:                marineWeatherClient,
+		stationsService:                    stationsService,
+		calendarsService:                   calendarsService,
+		conversationRepository:             conversationRepository,
+		conversationTTL:                    conversationTTL,
 		whatsappClient:                     whatsappClient,
+		publicBaseURL:                      publicBaseURL,
 		log:                                log,
 	}
+	service.commands = service.newCommandRegistry()
+	service.flows = service.newFlowRegistry()
+
+	return service
+}
+
+func (s *whatsappServiceImpl) ProcessMessage(ctx context.Context, body string, from string, profileName *string) error {
+	s.log.Debugf("Processing WhatsApp message - body: %s, from: %s, profileName: %v", body, from, profileName)
+
+	cleanPhoneNumber := strings.TrimPrefix(from, "whatsapp:")
+	settings := s.getUserSettings(cleanPhoneNumber)
+
+	// Cancel is always available, also in the middle of a flow
+	if command, arguments, ok := s.commands.Parse(body); ok && command.Name == CANCEL_COMMAND {
+		return s.runCommand(ctx, command, cleanPhoneNumber, profileName, settings, arguments)
+	}
+
+	conversation, err := s.conversationRepository.Get(cleanPhoneNumber)
+	if err != nil {
+		s.log.Errorf("Failed to get conversation of %s, handling the message as a command: %v", cleanPhoneNumber, err)
+	} else if conversation != nil {
+		return s.continueFlow(ctx, *conversation, body, profileName, settings)
+	}
+
+	return s.processCommand(ctx, body, cleanPhoneNumber, profileName, settings)
+}
+
+// processCommand runs the command the message starts with, or welcomes the user when it's not one
+func (s *whatsappServiceImpl) processCommand(ctx context.Context, body string, phoneNumber string, profileName *string, settings userSettings) error {
+	command, arguments, ok := s.commands.Parse(body)
+	if !ok {
+		return s.defaultMessageHandler(ctx, phoneNumber, profileName, settings)
+	}
+
+	return s.runCommand(ctx, command, phoneNumber, profileName, settings, arguments)
+}
+
+func (s *whatsappServiceImpl) ProcessButton(ctx context.Context, payload string, text string, from string, profileName *string) error {
+	s.log.Debugf("Processing WhatsApp button - payload: %s, text: %s, from: %s", payload, text, from)
+
+	cleanPhoneNumber := strings.TrimPrefix(from, "whatsapp:")
+	settings := s.getUserSettings(cleanPhoneNumber)
+
+	command, arguments, ok := s.commands.Button(payload, text)
+	if !ok {
+		s.log.Warnf("Unknown button payload %q (%s) from %s", payload, text, cleanPhoneNumber)
+		return s.defaultMessageHandler(ctx, cleanPhoneNumber, profileName, settings)
+	}
+
+	// Buttons run their command, leaving any flow
+	s.endFlow(cleanPhoneNumber)
+
+	return s.runCommand(ctx, command, cleanPhoneNumber, profileName, settings, arguments)
+}
+
+func (s *whatsappServiceImpl) runCommand(ctx context.Context, command *Command, phoneNumber string, profileName *string, settings userSettings, arguments []string) error {
+	s.log.Debugf("Running command %s for %s with arguments %v", command.Name, phoneNumber, arguments)
+
+	return command.handler(ctx, commandRequest{
+		phoneNumber: phoneNumber,
+		profileName: profileName,
+		settings:    settings,
+		arguments:   arguments,
+	})
+}
+
+func (s *whatsappServiceImpl) SendTideExtremesMessage(phoneNumber string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, date time.Time) error {
+	return s.sendTideExtremesMessage(phoneNumber, s.getUserSettings(phoneNumber), spot, tides, date)
+}
+
+func (s *whatsappServiceImpl) sendTideExtremesMessage(phoneNumber string, settings userSettings, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, date time.Time) error {
+	s.log.Debugf("Sending tide extremes message to %s for spot %s and date %s", phoneNumber, spot.Slug, date)
+
+	tr := settings.localizer()
+	message := s.formatTideExtremesMessage(tr, spot, tides, date, settings.units)
+
+	err := s.whatsappClient.SendMessage(message, phoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to send tide extremes message to %s: %w", phoneNumber, err)
+	}
+
+	s.log.Infof("Successfully sent tide extremes message to %s", phoneNumber)
+
+	s.sendTideChart(tr, phoneNumber, spot, tides, date, settings.units)
+
+	return nil
+}
+
+// sendTideChart follows the extremes with the tide curve, which is best effort
+func (s *whatsappServiceImpl) sendTideChart(tr i18n.Localizer, phoneNumber string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, date time.Time, units common.Units) {
+	if s.publicBaseURL == "" || len(tides.Heights) == 0 {
+		return
+	}
+
+	chartURL := charts.TideChartURL(s.publicBaseURL, spot.Slug, date, units, worldtides.SpotDatum(spot))
+	caption := tr.T("tides.chart", tr.Format(date, "layout.day"))
+
+	err := s.whatsappClient.SendMediaMessage(chartURL, caption, phoneNumber)
+	if err != nil {
+		s.log.Errorf("Failed to send tide chart %s to %s: %v", chartURL, phoneNumber, err)
+	}
+}
+
+func (s *whatsappServiceImpl) formatTideExtremesMessage(tr i18n.Localizer, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, date time.Time, units common.Units) string {
+	title := tr.T("tides.title", tr.Format(date, "layout.day"))
+	extremes := tides.Extremes
+	if len(extremes) == 0 {
+		return fmt.Sprintf("%s\n\n%s\n\n📍 %s", title, tr.T("tides.none"), spot.DisplayLabel)
+	}
+
+	var message strings.Builder
+	message.WriteString(title + "\n\n")
+
+	tz := spot.Location()
+	daylight := astronomy.NewDaylight(spot.Latitude, spot.Longitude, time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, tz))
+
+	for _, extreme := range extremes {
+		// Convert time to the spot's timezone
+		tideTimeAtSpot := extreme.Time().In(tz)
+		tideTime := tideTimeAtSpot.Format("15:04")
+
+		var emoji string
+		var extraNewLine string
+		if extreme.IsHighTide() {
+			emoji = "⬆️"
+			extraNewLine = "\n"
+		} else {
+			emoji = "⬇️"
+			extraNewLine = ""
+		}
+
+		message.WriteString(fmt.Sprintf("%s *%s*: %s (%s)%s%s\n",
+			emoji, extremeName(tr, extreme, false), tideTime, units.FormatHeight(extreme.Height, 2), lightLabel(tr, daylight.LightAt(tideTimeAtSpot)), extraNewLine))
+	}
+
+	message.WriteString(formatDaylight(tr, daylight, tz))
+	message.WriteString(formatTideCycle(tr, tidecycle.Classify(spot, date, tides)))
+
+	message.WriteString(fmt.Sprintf("\n📍 %s", spot.DisplayLabel))
+	message.WriteString(formatDatumNote(tr, tides.ResponseDatum))
+	message.WriteString(formatSourceNote(tr, tides.Source))
+
+	return message.String()
+}
+
+// lightLabel flags tides that happen outside daylight
+func lightLabel(tr i18n.Localizer, light astronomy.Light) string {
+	switch light {
+	case astronomy.LightTwilight:
+		return fmt.Sprintf(" 🌗 _%s_", tr.T("light.twilight"))
+	case astronomy.LightDark:
+		return fmt.Sprintf(" 🌙 _%s_", tr.T("light.dark"))
+	default:
+		return ""
+	}
+}
+
+func formatTideCycle(tr i18n.Localizer, day tidecycle.Day) string {
+	if day.Coefficient == 0 {
+		return fmt.Sprintf("%s %s, %s %s\n", day.Moon.Emoji, moonPhaseName(tr, day.Moon.Name), day.Emoji(), tideCycleName(tr, day.Kind))
+	}
+
+	return fmt.Sprintf("%s %s, %s %s, %s\n", day.Moon.Emoji, moonPhaseName(tr, day.Moon.Name), day.Emoji(), tideCycleName(tr, day.Kind), tr.T("cycle.coefficient", day.Coefficient))
+}
+
+func formatDaylight(tr i18n.Localizer, daylight astronomy.Daylight, tz *time.Location) string {
+	if daylight.Sunrise.IsZero() {
+		if daylight.AlwaysUp {
+			return "\n" + tr.T("daylight.no_set") + "\n"
+		}
+		return "\n" + tr.T("daylight.no_rise") + "\n"
+	}
+
+	var message strings.Builder
+	message.WriteString("\n" + tr.T("daylight.sun", daylight.Sunrise.In(tz).Format("15:04"), daylight.Sunset.In(tz).Format("15:04")) + "\n")
+
+	if !daylight.Dawn.IsZero() {
+		message.WriteString(tr.T("daylight.light", daylight.Dawn.In(tz).Format("15:04"), daylight.Dusk.In(tz).Format("15:04")) + "\n")
+	}
+
+	return message.String()
+}
+
+// defaultMessageHandler welcomes the user, and walks new users through the setup
+func (s *whatsappServiceImpl) defaultMessageHandler(ctx context.Context, phoneNumber string, profileName *string, settings userSettings) error {
+	s.log.Info("Received message, saving user")
+
+	_, err := s.userService.GetUserByPhoneNumber(phoneNumber)
+	isNewUser := false
+
+	if err != nil {
+		_, err = s.userService.SaveUser(phoneNumber, profileName)
+
+		if err != nil {
+			return fmt.Errorf("Failed to save user with phone number %s: %w", phoneNumber, err)
+		}
+
+		isNewUser = true
+	}
+
+	err = s.sendWelcomeMessage(phoneNumber, profileName, settings, isNewUser)
+	if err != nil {
+		s.log.Errorf("Failed to send welcome message to %s: %v", phoneNumber, err)
+	}
+
+	if isNewUser {
+		err = s.startFlow(ctx, phoneNumber, profileName, settings, SETUP_FLOW, nil)
+		if err != nil {
+			s.log.Errorf("Failed to start the setup of %s: %v", phoneNumber, err)
+		}
+	}
+
+	s.log.Info("Successfully processed message")
+	return nil
+}
+
+type TidesResponseForDay struct {
+	Day           time.Time
+	TidesResponse *worldtides.WorldTidesResponse
+	Err           error
+}
+
+func (s *whatsappServiceImpl) handleTidesCommand(ctx context.Context, phoneNumber string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling tides command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	spot, arguments, err := s.resolveSpot(phoneNumber, settings, arguments)
+	if err != nil {
+		s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	if i := slices.IndexFunc(arguments, isNowWord); i != -1 {
+		return s.handleNowCommand(ctx, phoneNumber, settings, append([]string{spot.Slug}, slices.Delete(slices.Clone(arguments), i, i+1)...))
+	}
+
+	arguments, atTime, hasAtTime := splitAtTime(arguments)
+	if hasAtTime {
+		return s.handleTidesAtCommand(ctx, phoneNumber, settings, spot, arguments, atTime)
+	}
+
+	dates, err := s.parseDates(phoneNumber, spot, arguments)
+	if err != nil {
+		return s.sendDateError(tr, phoneNumber, err)
+	}
+
+	var responses []TidesResponseForDay
+
+	trigger := worldtides.UserTrigger(phoneNumber)
+
+	if len(dates) > 1 && areConsecutiveDays(dates) {
+		responses = s.getTidesForRange(ctx, spot, dates, trigger)
+	} else {
+		ch := make(chan TidesResponseForDay, len(dates))
+
+		for _, day := range dates {
+			go s.getTidesWorker(ctx, spot, day, trigger, ch)
+		}
+
+		for range dates {
+			res := <-ch
+			responses = append(responses, res)
+		}
+	}
+
+	// Nobody is waiting for the reply anymore
+	if ctx.Err() != nil {
+		return fmt.Errorf("tides command for %s cancelled: %w", phoneNumber, ctx.Err())
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].Day.Before(responses[j].Day)
+	})
+
+	for _, response := range responses {
+		if errors.Is(response.Err, worldtides.ErrCreditBudgetExhausted) {
+			s.whatsappClient.SendMessage(tr.T("error.budget_day", tr.Format(response.Day, "layout.date")), phoneNumber)
+		} else if response.Err != nil {
+			s.whatsappClient.SendMessage(tr.T("error.tides_day", tr.Format(response.Day, "layout.date")), phoneNumber)
+		} else {
+			s.sendTideExtremesMessage(phoneNumber, settings, spot, response.TidesResponse, response.Day)
+		}
+	}
+
+	return nil
+}
+
+// handleTidesAtCommand answers "tides [dates] at HH:MM" with the water level at that time on each date
+func (s *whatsappServiceImpl) handleTidesAtCommand(ctx context.Context, phoneNumber string, settings userSettings, spot spotModels.Spot, arguments []string, atTime string) error {
+	tr := settings.localizer()
+
+	hour, minute, err := common.ParseTimeOfDay(atTime)
+	if err != nil {
+		s.log.Infof("Invalid time %q in tides command from %s: %v", atTime, phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.time", atTime), phoneNumber)
+	}
+
+	dates, err := s.parseDates(phoneNumber, spot, arguments)
+	if err != nil {
+		return s.sendDateError(tr, phoneNumber, err)
+	}
+
+	trigger := worldtides.UserTrigger(phoneNumber)
+
+	for _, date := range dates {
+		moment := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, spot.Location())
+
+		// Include the next day so the next extreme is known late in the evening
+		tides, ok := s.getTidesWithNextDay(ctx, tr, phoneNumber, spot, date, trigger)
+		if ctx.Err() != nil {
+			return fmt.Errorf("tides command for %s cancelled: %w", phoneNumber, ctx.Err())
+		}
+		if !ok {
+			continue
+		}
+
+		state, err := tides.StateAt(moment)
+		if err != nil {
+			s.log.Errorf("Failed to compute tide state for spot %s at %s: %v", spot.Slug, moment, err)
+			s.whatsappClient.SendMessage(tr.T("error.heights_at", tr.Format(moment, "layout.date_time")), phoneNumber)
+			continue
+		}
+
+		s.whatsappClient.SendMessage(s.formatTideStateMessage(tr, spot, state, tides, settings.units), phoneNumber)
+	}
+
+	return nil
 }
 
-func (s *whatsappServiceImpl) ProcessMessage(body string, from string, profileName *string) error {
-	s.log.Debugf("Processing WhatsApp message - body: %s, from: %s, profileName: %v", body, from, profileName)
+// handleNowCommand answers "now [spot]" with the live water level, its trend and where it is in the tidal cycle
+func (s *whatsappServiceImpl) handleNowCommand(ctx context.Context, phoneNumber string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling now command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	spot, _, err := s.resolveSpot(phoneNumber, settings, arguments)
+	if err != nil {
+		s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	now := time.Now().In(spot.Location())
+	today := common.TodayIn(spot.Location())
+
+	// The surrounding days hold the low water before midnight and the extremes after it
+	tides, ok := s.getMergedTides(ctx, tr, phoneNumber, spot, today.AddDate(0, 0, -1), 3, worldtides.UserTrigger(phoneNumber))
+	if ctx.Err() != nil {
+		return fmt.Errorf("now command for %s cancelled: %w", phoneNumber, ctx.Err())
+	}
+	if !ok {
+		return nil
+	}
+
+	progress, err := tides.ProgressAt(now)
+	if err != nil {
+		s.log.Errorf("Failed to compute tide progress for spot %s at %s: %v", spot.Slug, now, err)
+		return s.whatsappClient.SendMessage(tr.T("error.heights_now"), phoneNumber)
+	}
+
+	return s.whatsappClient.SendMessage(s.formatTideProgressMessage(tr, spot, progress, tides, settings.units), phoneNumber)
+}
+
+func (s *whatsappServiceImpl) formatTideProgressMessage(tr i18n.Localizer, spot spotModels.Spot, progress *worldtides.TideProgress, tides *worldtides.WorldTidesResponse, units common.Units) string {
+	tz := spot.Location()
+	now := progress.Time.In(tz)
+
+	var message strings.Builder
+	message.WriteString(tr.T("now.title", tr.Format(now, "layout.time_day")) + "\n\n")
+
+	rate := tr.T("now.rate", units.FormatHeight(math.Abs(progress.RatePerHour), 2))
+	if progress.Rising {
+		message.WriteString(fmt.Sprintf("%s %s\n", tr.T("tide.rising", units.FormatHeight(progress.Height, 2)), rate))
+	} else {
+		message.WriteString(fmt.Sprintf("%s %s\n", tr.T("tide.falling", units.FormatHeight(progress.Height, 2)), rate))
+	}
+
+	var upcoming []*worldtides.Extreme
+	for _, extreme := range []*worldtides.Extreme{progress.NextHigh, progress.NextLow} {
+		if extreme != nil {
+			upcoming = append(upcoming, extreme)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].Dt < upcoming[j].Dt
+	})
+
+	for _, extreme := range upcoming {
+		at := extreme.Time().In(tz)
+		daySuffix := ""
+		if at.YearDay() != now.YearDay() {
+			daySuffix = " " + tr.T("now.tomorrow")
+		}
+		message.WriteString(fmt.Sprintf("⏱️ *%s*: %s%s (%s), %s\n",
+			extremeName(tr, *extreme, false), at.Format("15:04"), daySuffix, units.FormatHeight(extreme.Height, 2), tr.T("tide.in", formatDuration(at.Sub(now)))))
+	}
+
+	if fraction, ok := progress.CycleFraction(); ok {
+		message.WriteString(tr.T("now.cycle",
+			int(math.Round(fraction*100)), progress.PreviousLow.Time().In(tz).Format("15:04"), progress.NextLow.Time().In(tz).Format("15:04")) + "\n")
+	}
+
+	message.WriteString(fmt.Sprintf("\n📍 %s", spot.DisplayLabel))
+	message.WriteString(formatDatumNote(tr, tides.ResponseDatum))
+	message.WriteString(formatSourceNote(tr, tides.Source))
+
+	return message.String()
+}
+
+// handleWindowCommand answers "below|above <level> [dates]" with the times the water is below or above the level
+func (s *whatsappServiceImpl) handleWindowCommand(ctx context.Context, phoneNumber string, settings userSettings, below bool, arguments []string) error {
+	s.log.Infof("Handling window command for %s. Below: %t, arguments: %v", phoneNumber, below, arguments)
+
+	tr := settings.localizer()
+
+	spot, arguments, err := s.resolveSpot(phoneNumber, settings, arguments)
+	if err != nil {
+		s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	units := settings.units
+
+	threshold, arguments, err := parseLevel(arguments, units)
+	if err != nil {
+		s.log.Infof("Invalid level in window command from %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.level", unitsName(tr, units)), phoneNumber)
+	}
+
+	dates, err := s.parseDates(phoneNumber, spot, arguments)
+	if err != nil {
+		return s.sendDateError(tr, phoneNumber, err)
+	}
+
+	trigger := worldtides.UserTrigger(phoneNumber)
+
+	for _, date := range dates {
+		// Include the next day so a window running past midnight gets its true end
+		tides, ok := s.getTidesWithNextDay(ctx, tr, phoneNumber, spot, date, trigger)
+		if ctx.Err() != nil {
+			return fmt.Errorf("window command for %s cancelled: %w", phoneNumber, ctx.Err())
+		}
+		if !ok {
+			continue
+		}
+
+		dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, spot.Location())
+		dayEnd := dayStart.AddDate(0, 0, 1)
+
+		// Windows starting on the day, with their end on the next day if they run past midnight
+		var windows []worldtides.Window
+		for _, window := range tides.Windows(threshold, below, dayStart, dayEnd.AddDate(0, 0, 1)) {
+			if window.From.Before(dayEnd) {
+				windows = append(windows, window)
+			}
+		}
+
+		s.whatsappClient.SendMessage(s.formatWindowsMessage(tr, spot, tides, windows, threshold, below, dayStart, units), phoneNumber)
+	}
+
+	return nil
+}
+
+func (s *whatsappServiceImpl) formatWindowsMessage(tr i18n.Localizer, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, windows []worldtides.Window, threshold float64, below bool, day time.Time, units common.Units) string {
+	tz := spot.Location()
+	dayEnd := day.AddDate(0, 0, 1)
+
+	direction := "above"
+	if below {
+		direction = "below"
+	}
+
+	var message strings.Builder
+	message.WriteString(tr.T("window."+direction, units.FormatHeight(threshold, 2), tr.Format(day, "layout.day")) + "\n\n")
+
+	if len(windows) == 0 {
+		message.WriteString(tr.T("window.never_"+direction, units.FormatHeight(threshold, 2)) + "\n")
+	}
+
+	for _, window := range windows {
+		to := window.To.In(tz).Format("15:04")
+		if !window.To.Before(dayEnd) {
+			to += " (+1)"
+		}
+
+		message.WriteString(fmt.Sprintf("🕐 %s – %s (%s)\n", window.From.In(tz).Format("15:04"), to, formatDuration(window.Duration())))
+	}
+
+	message.WriteString(fmt.Sprintf("\n📍 %s", spot.DisplayLabel))
+	message.WriteString(formatDatumNote(tr, tides.ResponseDatum))
+	message.WriteString(formatSourceNote(tr, tides.Source))
+
+	return message.String()
+}
+
+// handleConditionsCommand merges the wind, swell and water temperature forecast with the tide extremes of a day
+func (s *whatsappServiceImpl) handleConditionsCommand(ctx context.Context, phoneNumber string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling conditions command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	spot, arguments, err := s.resolveSpot(phoneNumber, settings, arguments)
+	if err != nil {
+		s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	dates, err := s.parseDates(phoneNumber, spot, arguments)
+	if err != nil {
+		return s.sendDateError(tr, phoneNumber, err)
+	}
+	// The forecast is for a single day, the first one asked for
+	date := dates[0]
+
+	// Either half is still worth sending when the other one fails
+	conditions, weatherErr := s.marineWeatherClient.GetConditions(ctx, spot, date)
+	if weatherErr != nil {
+		s.log.Errorf("Failed to fetch conditions for spot %s on %s: %v", spot.Slug, date.Format("2006-01-02"), weatherErr)
+	}
+
+	tides, tidesErr := s.tidesClient.GetTides(ctx, spot, date, worldtides.UserTrigger(phoneNumber))
+	if tidesErr != nil {
+		s.log.Errorf("Failed to fetch tides for spot %s on %s: %v", spot.Slug, date.Format("2006-01-02"), tidesErr)
+	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("conditions command for %s cancelled: %w", phoneNumber, ctx.Err())
+	}
+
+	if weatherErr != nil && tidesErr != nil {
+		return s.whatsappClient.SendMessage(tr.T("error.conditions", tr.Format(date, "layout.date")), phoneNumber)
+	}
+
+	return s.whatsappClient.SendMessage(s.formatConditionsMessage(tr, spot, date, conditions, tides, settings.units), phoneNumber)
+}
+
+func (s *whatsappServiceImpl) formatConditionsMessage(tr i18n.Localizer, spot spotModels.Spot, date time.Time, conditions *openmeteo.DayConditions, tides *worldtides.WorldTidesResponse, units common.Units) string {
+	tz := spot.Location()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, tz)
+
+	var message strings.Builder
+	message.WriteString(tr.T("conditions.title", tr.Format(day, "layout.day")) + "\n\n")
+
+	if conditions != nil {
+		var waterTemperature *float64
+
+		for _, hour := range CONDITIONS_HOURS {
+			hourly := conditions.At(day.Add(time.Duration(hour) * time.Hour))
+			if hourly == nil {
+				continue
+			}
+
+			message.WriteString(fmt.Sprintf("*%02d:00* %s\n", hour, formatHourlyConditions(tr, hourly, units)))
+
+			if hourly.WaterTemperature != nil {
+				waterTemperature = hourly.WaterTemperature
+			}
+		}
+
+		if waterTemperature != nil {
+			message.WriteString(tr.T("conditions.water", *waterTemperature) + "\n")
+		}
+	} else {
+		message.WriteString(tr.T("conditions.no_forecast") + "\n")
+	}
+
+	message.WriteString("\n")
+
+	if tides != nil && len(tides.Extremes) > 0 {
+		for _, extreme := range tides.Extremes {
+			emoji := "⬇️"
+			if extreme.IsHighTide() {
+				emoji = "⬆️"
+			}
+			message.WriteString(fmt.Sprintf("%s *%s*: %s (%s)\n", emoji, extremeName(tr, extreme, false), extreme.Time().In(tz).Format("15:04"), units.FormatHeight(extreme.Height, 2)))
+		}
+	} else {
+		message.WriteString(tr.T("conditions.no_tides") + "\n")
+	}
+
+	message.WriteString(fmt.Sprintf("\n📍 %s", spot.DisplayLabel))
+
+	var sources []string
+	if conditions != nil && conditions.Source != "" {
+		sources = append(sources, conditions.Source)
+	}
+	if tides != nil && tides.Source != "" {
+		sources = append(sources, tides.Source)
+	}
+	message.WriteString(formatSourceNote(tr, strings.Join(sources, ", ")))
+
+	return message.String()
+}
+
+// formatHourlyConditions formats e.g. "💨 14kn NE (gusts 19kn) 🌊 1.2m 9s NW", leaving out missing values
+func formatHourlyConditions(tr i18n.Localizer, hourly *openmeteo.HourlyConditions, units common.Units) string {
+	var parts []string
+
+	if hourly.WindSpeed != nil {
+		wind := fmt.Sprintf("💨 %.0fkn", *hourly.WindSpeed)
+		if hourly.WindDirection != nil {
+			wind += " " + openmeteo.CompassPoint(*hourly.WindDirection)
+		}
+		if hourly.WindGusts != nil {
+			wind += fmt.Sprintf(" (%s)", tr.T("conditions.gusts", *hourly.WindGusts))
+		}
+		parts = append(parts, wind)
+	}
+
+	if hourly.SwellHeight != nil {
+		swell := "🌊 " + units.FormatHeight(*hourly.SwellHeight, 1)
+		if hourly.SwellPeriod != nil {
+			swell += fmt.Sprintf(" %.0fs", *hourly.SwellPeriod)
+		}
+		if hourly.SwellDirection != nil {
+			swell += " " + openmeteo.CompassPoint(*hourly.SwellDirection)
+		}
+		parts = append(parts, swell)
+	}
+
+	if len(parts) == 0 {
+		return tr.T("conditions.no_data")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// handleMonthCommand lists the spring-neap cycle of the coming month to plan sessions around spring tides
+func (s *whatsappServiceImpl) handleMonthCommand(ctx context.Context, phoneNumber string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling month command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	spot, _, err := s.resolveSpot(phoneNumber, settings, arguments)
+	if err != nil {
+		s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	today := common.TodayIn(spot.Location())
+
+	tidesResponses, err := s.tidesClient.GetTidesRange(ctx, spot, today, MONTH_COMMAND_DAYS, worldtides.UserTrigger(phoneNumber))
+	if ctx.Err() != nil {
+		return fmt.Errorf("month command for %s cancelled: %w", phoneNumber, ctx.Err())
+	}
+	if errors.Is(err, worldtides.ErrCreditBudgetExhausted) {
+		return s.whatsappClient.SendMessage(tr.T("error.budget"), phoneNumber)
+	}
+	if err != nil {
+		s.log.Errorf("Failed to fetch tides for spot %s for the month command: %v", spot.Slug, err)
+		return s.whatsappClient.SendMessage(tr.T("error.tides"), phoneNumber)
+	}
+
+	var message strings.Builder
+	message.WriteString(tr.N("month.title", MONTH_COMMAND_DAYS) + "\n\n")
+
+	for _, day := range tidecycle.ClassifyAll(spot, today, tidesResponses) {
+		line := fmt.Sprintf("%s %s %s %s", tr.Format(day.Date, "layout.short_day"), day.Moon.Emoji, day.Emoji(), tideCycleName(tr, day.Kind))
+		if day.Coefficient > 0 {
+			line = fmt.Sprintf("%s %s %d %s %s", tr.Format(day.Date, "layout.short_day"), day.Moon.Emoji, day.Coefficient, day.Emoji(), tideCycleName(tr, day.Kind))
+		}
+		message.WriteString(line + "\n")
+	}
+
+	message.WriteString(fmt.Sprintf("\n📍 %s", spot.DisplayLabel))
+
+	return s.whatsappClient.SendMessage(message.String(), phoneNumber)
+}
+
+// handleBestCommand ranks the sessions of the coming days at a spot by its tide, wind and daylight preferences
+func (s *whatsappServiceImpl) handleBestCommand(ctx context.Context, phoneNumber string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling best command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	spot, arguments, err := s.resolveSpot(phoneNumber, settings, arguments)
+	if err != nil {
+		s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	days := BEST_COMMAND_DEFAULT_DAYS
+	if len(arguments) > 0 {
+		days, err = strconv.Atoi(arguments[0])
+		if err != nil || days < 1 || days > BEST_COMMAND_MAX_DAYS {
+			return s.whatsappClient.SendMessage(tr.T("error.best_days", BEST_COMMAND_MAX_DAYS), phoneNumber)
+		}
+	}
+
+	preferences, err := sessions.NewPreferences(spot)
+	if err != nil {
+		s.log.Errorf("Failed to read session preferences: %v", err)
+	}
+	if err != nil || preferences.IsZero() {
+		return s.whatsappClient.SendMessage(tr.T("best.no_preferences", spot.Name), phoneNumber)
+	}
+
+	today := common.TodayIn(spot.Location())
+
+	// One more day of tides to know the extremes after the last evening
+	tidesResponses, err := s.tidesClient.GetTidesRange(ctx, spot, today, days+1, worldtides.UserTrigger(phoneNumber))
+	if ctx.Err() != nil {
+		return fmt.Errorf("best command for %s cancelled: %w", phoneNumber, ctx.Err())
+	}
+	if errors.Is(err, worldtides.ErrCreditBudgetExhausted) {
+		return s.whatsappClient.SendMessage(tr.T("error.budget"), phoneNumber)
+	}
+	if err != nil {
+		s.log.Errorf("Failed to fetch tides for spot %s for the best command: %v", spot.Slug, err)
+		return s.whatsappClient.SendMessage(tr.T("error.tides"), phoneNumber)
+	}
+
+	var conditions []*openmeteo.DayConditions
+	withoutWind := false
+	for i := range days {
+		dayConditions, err := s.marineWeatherClient.GetConditions(ctx, spot, today.AddDate(0, 0, i))
+		if err != nil {
+			s.log.Errorf("Failed to fetch conditions for spot %s, recommending on tides alone: %v", spot.Slug, err)
+			withoutWind = true
+			continue
+		}
+		conditions = append(conditions, dayConditions)
+	}
+
+	recommended := sessions.Recommend(spot, preferences, worldtides.Merge(tidesResponses), conditions, time.Now(), today.AddDate(0, 0, days))
+
+	var message strings.Builder
+	message.WriteString(tr.N("best.title", days) + "\n\n")
+
+	if len(recommended) == 0 {
+		message.WriteString(tr.T("best.none") + "\n")
+	}
+
+	for _, session := range recommended[:min(len(recommended), BEST_COMMAND_SESSIONS)] {
+		message.WriteString(fmt.Sprintf("• %s\n", formatSession(tr, session, spot.Location(), true)))
+	}
+
+	if withoutWind {
+		message.WriteString("\n" + tr.T("best.without_wind") + "\n")
+	}
+
+	message.WriteString(fmt.Sprintf("\n📍 %s", spot.DisplayLabel))
+
+	return s.whatsappClient.SendMessage(message.String(), phoneNumber)
+}
+
+// formatSession formats e.g. "Sat 12/07 14:00-17:00 ⭐ 86, mid tide rising, 💨 18kn NE", leaving out the day unless withDay is set
+func formatSession(tr i18n.Localizer, session sessions.Session, tz *time.Location, withDay bool) string {
+	period := fmt.Sprintf("%s-%s", session.From.In(tz).Format("15:04"), session.To.In(tz).Format("15:04"))
+	if withDay {
+		period = tr.Format(session.From.In(tz), "layout.short_day") + " " + period
+	}
+
+	parts := []string{fmt.Sprintf("*%s* ⭐ %d", period, session.Score), formatTideRule(tr, session.Tide)}
+
+	if hourly := session.Conditions; hourly != nil && hourly.WindSpeed != nil {
+		wind := fmt.Sprintf("💨 %.0fkn", *hourly.WindSpeed)
+		if hourly.WindDirection != nil {
+			wind += " " + openmeteo.CompassPoint(*hourly.WindDirection)
+		}
+		parts = append(parts, wind)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// parseLevel takes the water level from the first argument, accepting "0.5", "0,5", "0.5m" and "2ft"
+// optionally followed by a separate unit. Levels without a unit are in the user's units.
+// The level is returned in meters and the returned arguments no longer contain it.
+func parseLevel(arguments []string, units common.Units) (float64, []string, error) {
+	if len(arguments) == 0 {
+		return 0, arguments, fmt.Errorf("missing level")
+	}
+
+	levelStr := strings.ReplaceAll(arguments[0], ",", ".")
+	for _, suffix := range []string{"ft", "m"} {
+		if number, found := strings.CutSuffix(levelStr, suffix); found {
+			levelStr = number
+			units, _ = common.ParseUnits(suffix)
+			break
+		}
+	}
+
+	level, err := strconv.ParseFloat(levelStr, 64)
+	if err != nil {
+		return 0, arguments, fmt.Errorf("invalid level %q: %w", arguments[0], err)
+	}
+
+	arguments = arguments[1:]
+	if len(arguments) > 0 {
+		if explicitUnits, ok := common.ParseUnits(arguments[0]); ok {
+			units = explicitUnits
+			arguments = arguments[1:]
+		}
+	}
+
+	return units.ToMeters(level), arguments, nil
+}
+
+// getTidesWithNextDay fetches the day and the following one as a single response.
+// It replies to the user when the tides can't be fetched.
+func (s *whatsappServiceImpl) getTidesWithNextDay(ctx context.Context, tr i18n.Localizer, phoneNumber string, spot spotModels.Spot, date time.Time, trigger worldtides.Trigger) (*worldtides.WorldTidesResponse, bool) {
+	return s.getMergedTides(ctx, tr, phoneNumber, spot, date, 2, trigger)
+}
+
+// getMergedTides fetches consecutive days from date as a single response.
+// It replies to the user when the tides can't be fetched.
+func (s *whatsappServiceImpl) getMergedTides(ctx context.Context, tr i18n.Localizer, phoneNumber string, spot spotModels.Spot, date time.Time, days int, trigger worldtides.Trigger) (*worldtides.WorldTidesResponse, bool) {
+	dateFormatted := tr.Format(date, "layout.date")
+
+	tidesResponses, err := s.tidesClient.GetTidesRange(ctx, spot, date, days, trigger)
+	if ctx.Err() != nil {
+		return nil, false
+	}
+	if errors.Is(err, worldtides.ErrCreditBudgetExhausted) {
+		s.whatsappClient.SendMessage(tr.T("error.budget_day", dateFormatted), phoneNumber)
+		return nil, false
+	}
+	if err != nil {
+		s.log.Errorf("Failed to fetch tides for spot %s from %s: %v", spot.Slug, date.Format("2006-01-02"), err)
+		s.whatsappClient.SendMessage(tr.T("error.tides_day", dateFormatted), phoneNumber)
+		return nil, false
+	}
+
+	return worldtides.Merge(tidesResponses), true
+}
 
-	cleanPhoneNumber := strings.TrimPrefix(from, "whatsapp:")
+func (s *whatsappServiceImpl) formatTideStateMessage(tr i18n.Localizer, spot spotModels.Spot, state *worldtides.TideState, tides *worldtides.WorldTidesResponse, units common.Units) string {
+	tz := spot.Location()
 
-	trimmedBody := strings.ToLower(strings.TrimSpace(body))
+	var message strings.Builder
+	message.WriteString(tr.T("tides.at", tr.Format(state.Time.In(tz), "layout.time_day_date")) + "\n\n")
 
-	whitespaceRegexp := regexp.MustCompile(`\s+`)
-	commandWithArguments := whitespaceRegexp.Split(trimmedBody, -1)
+	if state.Rising {
+		message.WriteString(tr.T("tide.rising", units.FormatHeight(state.Height, 2)) + "\n")
+	} else {
+		message.WriteString(tr.T("tide.falling", units.FormatHeight(state.Height, 2)) + "\n")
+	}
 
-	if len(commandWithArguments) < 1 {
-		return s.defaultMessageHandler(cleanPhoneNumber, profileName)
+	if state.NextExtreme != nil {
+		next := state.NextExtreme
+		message.WriteString(fmt.Sprintf("⏱️ *%s*: %s (%s), %s\n",
+			extremeName(tr, *next, true), next.Time().In(tz).Format("15:04"), units.FormatHeight(next.Height, 2), tr.T("tide.in", formatDuration(next.Time().Sub(state.Time)))))
 	}
 
-	command := commandWithArguments[0]
-	arguments := commandWithArguments[1:]
+	message.WriteString(fmt.Sprintf("\n📍 %s", spot.DisplayLabel))
+	message.WriteString(formatDatumNote(tr, tides.ResponseDatum))
+	message.WriteString(formatSourceNote(tr, tides.Source))
 
-	switch command {
-	case "tides":
-		return s.handleTidesCommand(cleanPhoneNumber, arguments)
-	case "start":
-		return s.handleStartCommand(cleanPhoneNumber, profileName)
-	case "stop":
-		return s.handleStopCommand(cleanPhoneNumber)
-	default:
-		return s.defaultMessageHandler(cleanPhoneNumber, profileName)
+	return message.String()
+}
+
+// splitAtTime removes "at <time>" from the arguments, joining a separate "am" or "pm" to the time. The time
+// may also follow "a las", "um" or "o", and be followed by "uhr".
+func splitAtTime(arguments []string) ([]string, string, bool) {
+	start, timeAt := -1, -1
+	for i, argument := range arguments {
+		if AT_WORDS[argument] {
+			start, timeAt = i, i+1
+			break
+		}
+		// "a las 15:30", "a la 1"
+		if argument == "a" && i+1 < len(arguments) && (arguments[i+1] == "las" || arguments[i+1] == "la") {
+			start, timeAt = i, i+2
+			break
+		}
+	}
+	if start == -1 {
+		return arguments, "", false
+	}
 
+	end := min(timeAt+1, len(arguments))
+	atTime := strings.Join(arguments[timeAt:end], "")
+
+	if end < len(arguments) && (arguments[end] == "am" || arguments[end] == "pm") {
+		atTime += arguments[end]
+		end++
+	} else if end < len(arguments) && arguments[end] == "uhr" {
+		end++
 	}
+
+	return slices.Delete(slices.Clone(arguments), start, end), atTime, true
 }
 
-func (s *whatsappServiceImpl) SendTideExtremesMessage(phoneNumber string, extremes []worldtides.Extreme, date time.Time) error {
-	s.log.Debugf("Sending tide extremes message to %s for date %s", phoneNumber, date)
+// AT_WORDS come before the time of "tides at 15:30", "gezeiten um 15:30" or "pływy o 15:30"
+var AT_WORDS = map[string]bool{"at": true, "um": true, "o": true}
 
-	message := s.formatTideExtremesMessage(extremes, date)
+// NOW_WORDS ask for the live water level, as in "tides now"
+var NOW_WORDS = map[string]bool{"now": true, "ahora": true, "jetzt": true, "teraz": true}
 
-	err := s.whatsappClient.SendMessage(message, phoneNumber)
-	if err != nil {
-		return fmt.Errorf("failed to send tide extremes message to %s: %w", phoneNumber, err)
+// NEAR_WORDS may come before the place of the stations command
+var NEAR_WORDS = map[string]bool{"near": true, "cerca": true, "bei": true, "nahe": true, "blisko": true, "koło": true, "kolo": true}
+
+func isNowWord(argument string) bool {
+	return NOW_WORDS[argument]
+}
+
+// formatDuration formats a duration as e.g. "2h 05m" or "45m"
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
 	}
 
-	s.log.Infof("Successfully sent tide extremes message to %s", phoneNumber)
-	return nil
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
 }
 
-func (s *whatsappServiceImpl) formatTideExtremesMessage(extremes []worldtides.Extreme, date time.Time) string {
-	dateFormatted := date.Format("Monday, 2006-01-02")
-	if len(extremes) == 0 {
-		return fmt.Sprintf("🌊 *Tides for %s*\n\nNo tide data available for today.", dateFormatted)
+func (s *whatsappServiceImpl) getTidesWorker(ctx context.Context, spot spotModels.Spot, day time.Time, trigger worldtides.Trigger, results chan<- TidesResponseForDay) {
+	tidesResponse, err := s.tidesClient.GetTides(ctx, spot, day, trigger)
+	dayFormatted := day.Format("2006-01-02")
+
+	if err != nil {
+		wrappedError := fmt.Errorf("Failed to fetch tide extremes for spot %s and day %s: %w", spot.Slug, dayFormatted, err)
+		s.log.Errorf("%v", wrappedError)
+		results <- TidesResponseForDay{
+			Day:           day,
+			TidesResponse: nil,
+			Err:           wrappedError,
+		}
+	} else {
+		results <- TidesResponseForDay{
+			Day:           day,
+			TidesResponse: tidesResponse,
+			Err:           nil,
+		}
 	}
+}
 
-	var message strings.Builder
-	message.WriteString(fmt.Sprintf("🌊 *Tides for %s*\n\n", dateFormatted))
+// getTidesForRange fetches consecutive days with a single range request
+func (s *whatsappServiceImpl) getTidesForRange(ctx context.Context, spot spotModels.Spot, dates []time.Time, trigger worldtides.Trigger) []TidesResponseForDay {
+	responses := make([]TidesResponseForDay, len(dates))
 
-	// Load Atlantic/Canary timezone
-	canaryTZ, err := time.LoadLocation("Atlantic/Canary")
+	tidesResponses, err := s.tidesClient.GetTidesRange(ctx, spot, dates[0], len(dates), trigger)
 	if err != nil {
-		s.log.Errorf("Failed to load Atlantic/Canary timezone: %v", err)
-		canaryTZ = time.UTC // Fallback to UTC
+		s.log.Errorf("Failed to fetch tide extremes for spot %s from %s for %d days: %v", spot.Slug, dates[0].Format("2006-01-02"), len(dates), err)
 	}
 
-	for _, extreme := range extremes {
-		// Convert time to Canary timezone
-		tideTimeInCanary := extreme.Time().In(canaryTZ)
-		tideTime := tideTimeInCanary.Format("15:04")
+	for i, day := range dates {
+		responses[i] = TidesResponseForDay{Day: day, Err: err}
+		if err == nil {
+			responses[i].TidesResponse = tidesResponses[i]
+		}
+	}
 
-		var emoji string
-		var extraNewLine string
-		if extreme.IsHighTide() {
-			emoji = "⬆️"
-			extraNewLine = "\n"
-		} else {
-			emoji = "⬇️"
-			extraNewLine = ""
+	return responses
+}
+
+func areConsecutiveDays(dates []time.Time) bool {
+	for i := 1; i < len(dates); i++ {
+		expected := dates[i-1].AddDate(0, 0, 1).Format("2006-01-02")
+		if dates[i].Format("2006-01-02") != expected {
+			return false
 		}
+	}
 
-		message.WriteString(fmt.Sprintf("%s *%s Tide*: %s (%.2fm)%s\n",
-			emoji, extreme.Type, tideTime, extreme.Height, extraNewLine))
+	return true
+}
+
+// parseDates reads the date expressions among the arguments in the spot's timezone, with numeric dates in the
+// day and month order of the user's country. No arguments means today.
+func (s *whatsappServiceImpl) parseDates(phoneNumber string, spot spotModels.Spot, arguments []string) ([]time.Time, error) {
+	today := common.TodayIn(spot.Location())
+	if len(arguments) == 0 {
+		return []time.Time{today}, nil
 	}
 
-	message.WriteString("\n📍 Fuerteventura, Risco del Paso, Canary Islands")
+	return common.NewDateParser(today, common.DayOrderForPhoneNumber(phoneNumber)).ParseDates(arguments)
+}
 
-	return message.String()
+// sendDateError tells the user which words weren't understood as dates instead of silently skipping them
+func (s *whatsappServiceImpl) sendDateError(tr i18n.Localizer, phoneNumber string, err error) error {
+	s.log.Infof("Invalid dates from %s: %v", phoneNumber, err)
+
+	var dateErr *common.DateError
+	if !errors.As(err, &dateErr) {
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	return s.whatsappClient.SendMessage(formatDateError(tr, dateErr), phoneNumber)
 }
 
-func (s *whatsappServiceImpl) defaultMessageHandler(phoneNumber string, profileName *string) error {
-	s.log.Info("Received message, saving user")
+// resolveSpot picks the spot for a command. A spot slug among the arguments wins,
+// then the spot of the user's subscription, then the default spot.
+// The spot's tides are requested in the user's datum if the user has picked one.
+// The returned arguments no longer contain the spot slug.
+func (s *whatsappServiceImpl) resolveSpot(phoneNumber string, settings userSettings, arguments []string) (spotModels.Spot, []string, error) {
+	datum := settings.datum
 
-	_, err := s.userService.GetUserByPhoneNumber(phoneNumber)
-	isNewUser := false
+	slugs := make([]string, len(arguments))
+	for i, argument := range arguments {
+		slugs[i] = strings.ToLower(argument)
+	}
 
+	spots, err := s.spotRepository.ListBySlugs(slugs)
 	if err != nil {
-		_, err = s.userService.SaveUser(phoneNumber, profileName)
+		s.log.Errorf("Failed to look up spots among the arguments of %s: %v", phoneNumber, err)
+	}
 
-		if err != nil {
-			return fmt.Errorf("Failed to save user with phone number %s: %w", phoneNumber, err)
+	for i, slug := range slugs {
+		if j := slices.IndexFunc(spots, func(spot spotModels.Spot) bool { return spot.Slug == slug }); j != -1 {
+			return worldtides.WithDatum(spots[j], datum), slices.Delete(slices.Clone(arguments), i, i+1), nil
 		}
-
-		isNewUser = true
 	}
 
-	err = s.sendWelcomeMessage(phoneNumber, profileName, isNewUser)
+	spot, err := s.getSubscribedSpot(phoneNumber)
 	if err != nil {
-		s.log.Errorf("Failed to send welcome message to %s: %v", phoneNumber, err)
+		s.log.Debugf("No subscribed spot for %s, using default spot: %v", phoneNumber, err)
+		spot, err = s.spotRepository.GetBySlug(spotModels.DefaultSpotSlug)
 	}
 
-	s.log.Info("Successfully processed message")
-	return nil
+	return worldtides.WithDatum(spot, datum), arguments, err
 }
 
-type TidesResponseForDay struct {
-	Day           time.Time
-	TidesResponse *worldtides.WorldTidesResponse
-	Err           error
+// userSettings are how a user wants heights shown, and the language replies are in
+type userSettings struct {
+	units common.Units
+	// datum overrides the spot's datum when set
+	datum    *string
+	language i18n.Language
 }
 
-func (s *whatsappServiceImpl) handleTidesCommand(phoneNumber string, arguments []string) error {
-	s.log.Infof("Handling tides command for %s. Arguments: %v", phoneNumber, arguments)
+// getUserSettings returns the user's settings, or the defaults for unknown users. The language is the one of
+// the phone number's country unless the user picked another. Incoming messages load them once and pass them
+// down, only reloading them after changing them.
+func (s *whatsappServiceImpl) getUserSettings(phoneNumber string) userSettings {
+	settings := userSettings{units: common.UnitsMeters, language: i18n.LanguageForPhoneNumber(phoneNumber)}
 
-	var dates []time.Time
+	user, err := s.userService.GetUserByPhoneNumber(phoneNumber)
+	if err != nil {
+		s.log.Debugf("No user for %s, using the default settings: %v", phoneNumber, err)
+		return settings
+	}
 
-	if len(arguments) > 0 {
-		dates = s.parseTidesCommandArguments(arguments)
-	} else {
-		dates = append(dates, common.Today())
+	if units, ok := common.ParseUnits(user.Units); ok {
+		settings.units = units
+	}
+	settings.datum = user.Datum
+	if user.Language != nil {
+		if language, ok := i18n.ParseLanguage(*user.Language); ok {
+			settings.language = language
+		}
 	}
 
-	ch := make(chan TidesResponseForDay, len(dates))
+	return settings
+}
+
+// handleUnitsCommand shows or sets the units heights are shown in
+func (s *whatsappServiceImpl) handleUnitsCommand(phoneNumber string, profileName *string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling units command for %s. Arguments: %v", phoneNumber, arguments)
 
-	for _, day := range dates {
-		go s.getTidesWorker(day, ch)
+	tr := settings.localizer()
+
+	if len(arguments) == 0 {
+		return s.whatsappClient.SendMessage(tr.T("units.current", unitsName(tr, settings.units)), phoneNumber)
 	}
 
-	var responses []TidesResponseForDay
-	for range dates {
-		res := <-ch
-		responses = append(responses, res)
+	units, ok := common.ParseUnits(arguments[0])
+	if !ok {
+		return s.whatsappClient.SendMessage(tr.T("units.invalid"), phoneNumber)
 	}
 
-	sort.Slice(responses, func(i, j int) bool {
-		return responses[i].Day.Before(responses[j].Day)
-	})
+	err := s.updateUserSettings(phoneNumber, profileName, settings.datum, units)
+	if err != nil {
+		s.log.Errorf("Failed to update units of %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
 
-	for _, response := range responses {
-		if response.Err != nil {
-			s.whatsappClient.SendMessage(fmt.Sprintf("❌ Sorry, I couldn't fetch tide data for %s. Please try again later.", response.Day.Format("2006-01-02")), phoneNumber)
-		} else {
-			s.SendTideExtremesMessage(phoneNumber, response.TidesResponse.Extremes, response.Day)
+	return s.whatsappClient.SendMessage(tr.T("units.updated", unitsName(tr, units)), phoneNumber)
+}
+
+// handleDatumCommand shows or sets the datum heights are relative to, "datum spot" going back to the spot's
+func (s *whatsappServiceImpl) handleDatumCommand(phoneNumber string, profileName *string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling datum command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	datums := strings.Join(worldtides.Datums, ", ")
+
+	if len(arguments) == 0 {
+		current := tr.T("datum.spot")
+		if settings.datum != nil {
+			current = *settings.datum
 		}
+		return s.whatsappClient.SendMessage(tr.T("datum.current", current, datums, tr.T("datum.help")), phoneNumber)
 	}
 
-	return nil
+	var datum *string
+	if arguments[0] != "spot" {
+		parsed, ok := worldtides.ParseDatum(arguments[0])
+		if !ok {
+			return s.whatsappClient.SendMessage(tr.T("datum.invalid", datums), phoneNumber)
+		}
+		datum = &parsed
+	}
+
+	err := s.updateUserSettings(phoneNumber, profileName, datum, settings.units)
+	if err != nil {
+		s.log.Errorf("Failed to update datum of %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	if datum == nil {
+		return s.whatsappClient.SendMessage(tr.T("datum.updated_spot"), phoneNumber)
+	}
+	return s.whatsappClient.SendMessage(tr.T("datum.updated", *datum), phoneNumber)
 }
 
-func (s *whatsappServiceImpl) getTidesWorker(day time.Time, results chan<- TidesResponseForDay) {
-	tidesResponse, err := s.worldTidesClient.GetTides(day)
-	dayFormatted := day.Format("2006-01-02")
+// updateUserSettings registers the user if needed, so settings can be changed before subscribing
+func (s *whatsappServiceImpl) updateUserSettings(phoneNumber string, profileName *string, datum *string, units common.Units) error {
+	_, err := s.userService.SaveUser(phoneNumber, profileName)
+	if err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	_, err = s.userService.UpdatePreferences(phoneNumber, datum, string(units))
+	return err
+}
+
+// formatDatumNote mentions the datum unless it's the default mean sea level
+func formatDatumNote(tr i18n.Localizer, datum string) string {
+	if parsed, _ := worldtides.ParseDatum(datum); datum == "" || parsed == worldtides.DefaultDatum {
+		return ""
+	}
+	return fmt.Sprintf("\n_%s_", tr.T("tide.datum", datum))
+}
 
+// formatSourceNote credits the source of the data, if known
+func formatSourceNote(tr i18n.Localizer, source string) string {
+	if source == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n_%s_", tr.T("tide.source", source))
+}
+
+func (s *whatsappServiceImpl) getSubscribedSpot(phoneNumber string) (spotModels.Spot, error) {
+	user, err := s.userService.GetUserByPhoneNumber(phoneNumber)
 	if err != nil {
-		wrappedError := fmt.Errorf("Failed to fetch tide extremes for day %s: %v", dayFormatted, err)
-		s.log.Errorf("%v", wrappedError)
-		results <- TidesResponseForDay{
-			Day:           day,
-			TidesResponse: nil,
-			Err:           wrappedError,
-		}
-	} else {
-		results <- TidesResponseForDay{
-			Day:           day,
-			TidesResponse: tidesResponse,
-			Err:           nil,
-		}
+		return spotModels.Spot{}, err
 	}
+
+	subscription, err := s.notificationSubscriptionRepository.GetSubscriptionByUserID(user.ID)
+	if err != nil {
+		return spotModels.Spot{}, err
+	}
+	if subscription == nil {
+		return spotModels.Spot{}, fmt.Errorf("no subscription found for user %d", user.ID)
+	}
+
+	return s.spotRepository.GetByID(subscription.SpotID)
 }
 
-func (s *whatsappServiceImpl) parseTidesCommandArguments(args []string) []time.Time {
-	argsClean := slices.Clone(args)
+func (s *whatsappServiceImpl) handleStartCommand(phoneNumber string, profileName *string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling start command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	spot, remainingArguments, err := s.resolveSpot(phoneNumber, settings, arguments)
+	if err != nil {
+		s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
+
+	if len(remainingArguments) > 0 {
+		return s.whatsappClient.SendMessage(tr.T("error.unknown_spot", remainingArguments[0]), phoneNumber)
+	}
+
+	user, err := s.userService.SaveUser(phoneNumber, profileName)
+	if err != nil {
+		s.log.Errorf("Failed to save user for phone %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
 
-	for i := range argsClean {
-		argsClean[i] = strings.ToLower(argsClean[i])
+	err = s.notificationSubscriptionRepository.CreateSubscription(user.ID, spot.ID)
+	if err != nil {
+		s.log.Errorf("Failed to create subscription for user %d: %v", user.ID, err)
+		return s.whatsappClient.SendMessage(tr.T("error.notifications"), phoneNumber)
 	}
 
-	containsWeekArg := slices.Contains(argsClean, "week")
+	return s.whatsappClient.SendMessage(tr.T("start.message", spot.DisplayLabel), phoneNumber)
+}
 
-	if containsWeekArg {
-		week := make([]time.Time, 7)
+func (s *whatsappServiceImpl) handleSpotsCommand(phoneNumber string, settings userSettings) error {
+	s.log.Infof("Handling spots command for %s", phoneNumber)
 
-		for i := range week {
-			week[i] = common.Today().Add(time.Duration(i*24) * time.Hour)
-		}
+	tr := settings.localizer()
 
-		return week
+	spots, err := s.spotRepository.ListAll()
+	if err != nil {
+		s.log.Errorf("Failed to list spots: %v", err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
 	}
 
-	var dates []time.Time
+	var message strings.Builder
+	message.WriteString(tr.T("spots.title") + "\n\n")
 
-	for i := range argsClean {
-		date, err := common.ParseDate(argsClean[i])
+	for _, spot := range spots {
+		message.WriteString(fmt.Sprintf("• *%s* - %s\n", spot.Slug, spot.DisplayLabel))
+	}
 
-		if err != nil {
-			continue
+	example := spotModels.DefaultSpotSlug
+	if len(spots) > 0 {
+		example = spots[0].Slug
+	}
+
+	message.WriteString("\n" + tr.T("spots.examples", example, example))
+
+	return s.whatsappClient.SendMessage(message.String(), phoneNumber)
+}
+
+// handleStationsCommand lists the tide stations near a place, coordinates or spot, by default the user's spot.
+// A bare "stations near" asks for the place, which a location pin can answer.
+// Examples: "stations near tarifa", "stations near 36.01,-5.60 100km"
+func (s *whatsappServiceImpl) handleStationsCommand(ctx context.Context, phoneNumber string, settings userSettings, arguments []string) error {
+	s.log.Infof("Handling stations command for %s. Arguments: %v", phoneNumber, arguments)
+
+	tr := settings.localizer()
+
+	near := len(arguments) > 0 && NEAR_WORDS[arguments[0]]
+	if near {
+		arguments = arguments[1:]
+	}
+	// "cerca de tarifa"
+	if len(arguments) > 0 && arguments[0] == "de" {
+		arguments = arguments[1:]
+	}
+
+	radiusKm := stations.DefaultRadiusKm
+	if len(arguments) > 0 {
+		if last, found := strings.CutSuffix(arguments[len(arguments)-1], "km"); found {
+			if radius, err := strconv.Atoi(last); err == nil {
+				radiusKm = min(max(radius, 1), worldtides.MaxStationRadiusKm)
+				arguments = arguments[:len(arguments)-1]
+			}
 		}
+	}
 
-		dates = append(dates, date)
+	query := strings.Join(arguments, " ")
+	if query == "" && near {
+		return s.startFlow(ctx, phoneNumber, nil, settings, STATIONS_FLOW, map[string]string{"radius": strconv.Itoa(radiusKm)})
+	}
+	if query == "" {
+		spot, _, err := s.resolveSpot(phoneNumber, settings, nil)
+		if err != nil {
+			s.log.Errorf("Failed to resolve spot for %s: %v", phoneNumber, err)
+			return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+		}
+		query = spot.Slug
 	}
 
-	return dates
+	return s.sendStationsNear(ctx, phoneNumber, tr, query, radiusKm)
 }
 
-func (s *whatsappServiceImpl) handleStartCommand(phoneNumber string, profileName *string) error {
-	s.log.Infof("Handling start command for %s", phoneNumber)
-
-	user, err := s.userService.SaveUser(phoneNumber, profileName)
+// sendStationsNear lists the tide stations within the radius of the place, spot or coordinates in the query
+func (s *whatsappServiceImpl) sendStationsNear(ctx context.Context, phoneNumber string, tr i18n.Localizer, query string, radiusKm int) error {
+	place, err := s.stationsService.Resolve(ctx, query)
+	if errors.Is(err, openmeteo.ErrPlaceNotFound) {
+		return s.whatsappClient.SendMessage(tr.T("error.unknown_place", query), phoneNumber)
+	}
 	if err != nil {
-		s.log.Errorf("Failed to save user for phone %s: %v", phoneNumber, err)
-		return s.whatsappClient.SendMessage("❌ Sorry, there was an error. Please try again later.", phoneNumber)
+		s.log.Errorf("Failed to resolve '%s' for %s: %v", query, phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
 	}
 
-	err = s.notificationSubscriptionRepository.CreateSubscription(user.ID)
+	nearby, err := s.stationsService.FindNear(ctx, place, radiusKm, worldtides.UserTrigger(phoneNumber))
+	if ctx.Err() != nil {
+		return fmt.Errorf("stations command for %s cancelled: %w", phoneNumber, ctx.Err())
+	}
+	if errors.Is(err, worldtides.ErrCreditBudgetExhausted) {
+		return s.whatsappClient.SendMessage(tr.T("error.budget"), phoneNumber)
+	}
 	if err != nil {
-		s.log.Errorf("Failed to create subscription for user %d: %v", user.ID, err)
-		return s.whatsappClient.SendMessage("❌ Sorry, there was an error enabling notifications. Please try again later.", phoneNumber)
+		s.log.Errorf("Failed to find stations near %s for %s: %v", place.Name, phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.stations"), phoneNumber)
+	}
+
+	return s.whatsappClient.SendMessage(formatStationsMessage(tr, place, radiusKm, nearby), phoneNumber)
+}
+
+func formatStationsMessage(tr i18n.Localizer, place openmeteo.Place, radiusKm int, nearby []worldtides.NearbyStation) string {
+	if len(nearby) == 0 {
+		return tr.T("stations.none", radiusKm, place.Name, place.Name)
+	}
+
+	var message strings.Builder
+	message.WriteString(tr.T("stations.title", radiusKm, place.Name) + "\n\n")
+
+	for i, station := range nearby {
+		if i == STATIONS_COMMAND_MAX_STATIONS {
+			message.WriteString(tr.N("stations.more", len(nearby)-i) + "\n")
+			break
+		}
+		message.WriteString(fmt.Sprintf("%d. *%s* - %.1fkm\n   _%s_\n", i+1, station.Name, station.DistanceKm, station.ID))
 	}
 
-	confirmationMessage := `🔔 *Notifications Enabled!*
+	message.WriteString("\n" + tr.T("stations.footer"))
 
-You'll now receive daily tide reports for *Risco del Paso, Fuerteventura* every morning.
+	return message.String()
+}
+
+// handleCalendarCommand replies with the user's personal calendar feed, which follows their spot, units and datum
+func (s *whatsappServiceImpl) handleCalendarCommand(phoneNumber string, profileName *string, settings userSettings) error {
+	s.log.Infof("Handling calendar command for %s", phoneNumber)
+
+	tr := settings.localizer()
+
+	if s.publicBaseURL == "" {
+		return s.whatsappClient.SendMessage(tr.T("calendar.unavailable"), phoneNumber)
+	}
+
+	user, err := s.userService.SaveUser(phoneNumber, profileName)
+	if err != nil {
+		s.log.Errorf("Failed to save user %s: %v", phoneNumber, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
 
-📱 Send *tides* anytime for current tide info
-🔕 Send *stop* to disable notifications
+	feed, err := s.calendarsService.GetOrCreateFeed(user.ID)
+	if err != nil {
+		s.log.Errorf("Failed to get calendar feed of user %d: %v", user.ID, err)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
+	}
 
-Welcome aboard! 🌊`
+	feedURL := calendars.UserFeedURL(s.publicBaseURL, feed.Token)
 
-	return s.whatsappClient.SendMessage(confirmationMessage, phoneNumber)
+	return s.whatsappClient.SendMessage(tr.T("calendar.message", feedURL, feedURL), phoneNumber)
 }
 
-func (s *whatsappServiceImpl) handleStopCommand(phoneNumber string) error {
+func (s *whatsappServiceImpl) handleStopCommand(phoneNumber string, settings userSettings) error {
 	s.log.Infof("Handling stop command for %s", phoneNumber)
 
+	tr := settings.localizer()
+
 	user, err := s.userService.GetUserByPhoneNumber(phoneNumber)
 	if err != nil {
 		s.log.Warnf("User not found for phone %s, cannot stop notifications", phoneNumber)
-		return s.whatsappClient.SendMessage("🤷‍♂️ You don't have any active notifications to stop.\n\nSend *start* to enable tide notifications!", phoneNumber)
+		return s.whatsappClient.SendMessage(tr.T("stop.none"), phoneNumber)
 	}
 
 	err = s.notificationSubscriptionRepository.DisableSubscription(user.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "no subscription found") {
-			return s.whatsappClient.SendMessage("🤷‍♂️ You don't have any active notifications to stop.\n\nSend *start* to enable tide notifications!", phoneNumber)
+			return s.whatsappClient.SendMessage(tr.T("stop.none"), phoneNumber)
 		}
 		s.log.Errorf("Failed to disable subscription for user %d: %v", user.ID, err)
-		return s.whatsappClient.SendMessage("❌ Sorry, there was an error. Please try again later.", phoneNumber)
+		return s.whatsappClient.SendMessage(tr.T("error.generic"), phoneNumber)
 	}
 
-	confirmationMessage := `🔕 *Notifications Disabled*
-
-You'll no longer receive daily tide reports.
-
-📱 Send *tides* anytime for current tide info
-🔔 Send *start* to re-enable notifications
-
-Thanks for using TideBot! 🌊`
-
-	return s.whatsappClient.SendMessage(confirmationMessage, phoneNumber)
+	return s.whatsappClient.SendMessage(tr.T("stop.message"), phoneNumber)
 }
 
-func (s *whatsappServiceImpl) sendWelcomeMessage(phoneNumber string, profileName *string, isNewUser bool) error {
-	personalizedWelcome := "Hi!"
+func (s *whatsappServiceImpl) sendWelcomeMessage(phoneNumber string, profileName *string, settings userSettings, isNewUser bool) error {
+	tr := settings.localizer()
+
+	personalizedWelcome := tr.T("welcome.hi")
 
 	if profileName != nil {
-		personalizedWelcome = fmt.Sprintf(`Hi %s!`, *profileName)
+		personalizedWelcome = tr.T("welcome.hi_name", *profileName)
 	}
 
-	newUserMessage := " Welcome to TideBot!"
+	newUserMessage := tr.T("welcome.new_user")
 	if !isNewUser {
 		newUserMessage = ""
 	}
 
-	welcomeMessage := fmt.Sprintf(`🌊 *%s%s*
-
-Tide reports for *Risco del Paso, Fuerteventura*.
-
-Your tide reports include high and low tide times with precise heights 🏄‍♂️
+	spotLabel := tr.T("welcome.any_spot")
+	spot, _, err := s.resolveSpot(phoneNumber, settings, nil)
+	if err == nil {
+		spotLabel = spot.DisplayLabel
+	}
 
-%s
-`, personalizedWelcome, newUserMessage, AVAILABLE_COMMANDS)
+	welcomeMessage := tr.T("welcome.message", personalizedWelcome, newUserMessage, spotLabel, s.commands.Help(tr))
 
-	err := s.whatsappClient.SendMessage(welcomeMessage, phoneNumber)
+	err = s.whatsappClient.SendMessage(welcomeMessage, phoneNumber)
 	if err != nil {
 		return fmt.Errorf("failed to send welcome message: %w", err)
 	}
@@ -348,21 +1469,29 @@ func (s *whatsappServiceImpl) sendQuickReplyMessage(phoneNumber string) {
 	}
 }
 
-func (s *whatsappServiceImpl) SendDailyTideNotification(phoneNumber string, userName string, extremes []worldtides.Extreme) error {
+func (s *whatsappServiceImpl) SendDailyTideNotification(ctx context.Context, phoneNumber string, userName string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse) error {
 	// Check environment - use text message in development, template in production
 	env := os.Getenv("GO_ENV")
 
-	variables := s.buildDailyTidesNotificationVariables(userName, extremes)
+	extremes := tides.Extremes
+	daylight := astronomy.NewDaylight(spot.Latitude, spot.Longitude, common.TodayIn(spot.Location()))
+	settings := s.getUserSettings(phoneNumber)
+	tr := settings.localizer()
 
 	if env == string(environment.EnvDevelopment) {
 		s.log.Infof("Using text message for daily notification in development environment")
-		return s.sendDailyTideNotificationAsText(phoneNumber, variables)
+		variables := s.buildDailyTidesNotificationVariables(tr, userName, spot, extremes, daylight, settings.units)
+		tideCycle := tidecycle.Classify(spot, common.TodayIn(spot.Location()), tides)
+		return s.sendDailyTideNotificationAsText(tr, phoneNumber, spot, tides.Source, tides.ResponseDatum, daylight, tideCycle, s.bestSessionToday(ctx, tr, spot, tides), variables)
 	}
 
 	s.log.Infof("Sending daily tide notification template to %s", phoneNumber)
 
-	if len(extremes) < 4 {
-		return fmt.Errorf("insufficient tide extremes: need 4, got %d", len(extremes))
+	// The approved template is in English, so are its variables
+	variables := s.buildDailyTidesNotificationVariables(i18n.NewLocalizer(i18n.English), userName, spot, extremes, daylight, settings.units)
+
+	if len(extremes) < DAILY_TIDE_NOTIFICATION_TEMPLATE_TIDES {
+		return fmt.Errorf("insufficient tide extremes: need %d, got %d", DAILY_TIDE_NOTIFICATION_TEMPLATE_TIDES, len(extremes))
 	}
 
 	err := s.whatsappClient.SendTemplateWithVariables(DAILY_TIDE_NOTIFICATION_TEMPLATE_SID, variables, phoneNumber)
@@ -371,10 +1500,67 @@ func (s *whatsappServiceImpl) SendDailyTideNotification(phoneNumber string, user
 	}
 
 	s.log.Infof("Successfully sent daily tide notification to %s", phoneNumber)
+
+	// The template has no room for the daylight, the tide cycle and the session, so they follow as a separate message
+	var followUp strings.Builder
+	followUp.WriteString(formatDaylight(tr, daylight, spot.Location()))
+	followUp.WriteString(formatTideCycle(tr, tidecycle.Classify(spot, common.TodayIn(spot.Location()), tides)))
+	if bestSession := s.bestSessionToday(ctx, tr, spot, tides); bestSession != "" {
+		followUp.WriteString(bestSession + "\n")
+	}
+
+	err = s.whatsappClient.SendMessage(strings.TrimSpace(followUp.String()), phoneNumber)
+	if err != nil {
+		s.log.Errorf("Failed to send daily notification follow-up to %s: %v", phoneNumber, err)
+	}
+
+	return nil
+}
+
+func (s *whatsappServiceImpl) SendSuspectTidesAlert(phoneNumber string, job string, spot spotModels.Spot, tides *worldtides.WorldTidesResponse, date time.Time) error {
+	var anomalies strings.Builder
+	for _, anomaly := range tides.Anomalies {
+		anomalies.WriteString(fmt.Sprintf("• %s\n", anomaly.Message))
+	}
+
+	message := fmt.Sprintf(SUSPECT_TIDES_ALERT_MESSAGE, job, spot.Name, date.Format("Monday, January 2"), tides.Source, worldtides.SpotDatum(spot), anomalies.String(), spot.Slug)
+
+	err := s.whatsappClient.SendMessage(message, phoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to send suspect tides alert to %s: %w", phoneNumber, err)
+	}
+
 	return nil
 }
 
-func (s *whatsappServiceImpl) sendDailyTideNotificationAsText(phoneNumber string, variables []string) error {
+// bestSessionToday describes the best session left today at the spot, or returns "" when the spot has no
+// session preferences or nothing scores well enough
+func (s *whatsappServiceImpl) bestSessionToday(ctx context.Context, tr i18n.Localizer, spot spotModels.Spot, tides *worldtides.WorldTidesResponse) string {
+	preferences, err := sessions.NewPreferences(spot)
+	if err != nil {
+		s.log.Errorf("Failed to read session preferences: %v", err)
+		return ""
+	}
+	if preferences.IsZero() {
+		return ""
+	}
+
+	today := common.TodayIn(spot.Location())
+
+	conditions, err := s.marineWeatherClient.GetConditions(ctx, spot, today)
+	if err != nil {
+		s.log.Errorf("Failed to fetch conditions for spot %s, recommending on tides alone: %v", spot.Slug, err)
+	}
+
+	recommended := sessions.Recommend(spot, preferences, tides, []*openmeteo.DayConditions{conditions}, time.Now(), today.AddDate(0, 0, 1))
+	if len(recommended) == 0 {
+		return ""
+	}
+
+	return tr.T("best.today", formatSession(tr, recommended[0], spot.Location(), false))
+}
+
+func (s *whatsappServiceImpl) sendDailyTideNotificationAsText(tr i18n.Localizer, phoneNumber string, spot spotModels.Spot, source string, datum string, daylight astronomy.Daylight, tideCycle tidecycle.Day, bestSession string, variables []string) error {
 	s.log.Infof("Sending daily tide notification as text to %s", phoneNumber)
 
 	if len(variables) != 9 {
@@ -383,19 +1569,32 @@ func (s *whatsappServiceImpl) sendDailyTideNotificationAsText(phoneNumber string
 
 	// Build the message text
 	var message strings.Builder
-	message.WriteString(fmt.Sprintf("Hi %s!\n\n", variables[8]))
-	message.WriteString("Here is your daily tide report:\n\n")
+	message.WriteString(tr.T("daily.hi", variables[8]) + "\n\n")
+	message.WriteString(tr.T("daily.intro") + "\n\n")
 
 	// Process each extreme
 	for i, j := 0, 1; i < 7; i, j = i+2, j+1 {
 		tideType := variables[i]
 		tideInfo := variables[i+1]
 
-		message.WriteString(fmt.Sprintf("  %d. %s tide: %s\n", j, tideType, tideInfo))
+		message.WriteString(fmt.Sprintf("  %d. %s\n", j, tr.T("daily.tide", tideType, tideInfo)))
+	}
+
+	message.WriteString(formatDaylight(tr, daylight, spot.Location()))
+	message.WriteString(formatTideCycle(tr, tideCycle))
+	if bestSession != "" {
+		message.WriteString(bestSession + "\n")
 	}
 
-	message.WriteString("\nLocation: Risco del Paso, Fuerteventura\n\n")
-	message.WriteString("If you don't want to receive those notifications anymore, reply 'stop' to this message. Have a great day on the water!")
+	message.WriteString("\n" + tr.T("daily.location", spot.DisplayLabel) + "\n")
+	if datum != "" && datum != worldtides.DefaultDatum {
+		message.WriteString(tr.T("tide.datum", datum) + "\n")
+	}
+	if source != "" {
+		message.WriteString(tr.T("tide.source", source) + "\n")
+	}
+	message.WriteString("\n")
+	message.WriteString(tr.T("daily.footer"))
 
 	err := s.whatsappClient.SendMessage(message.String(), phoneNumber)
 	if err != nil {
@@ -406,16 +1605,16 @@ func (s *whatsappServiceImpl) sendDailyTideNotificationAsText(phoneNumber string
 	return nil
 }
 
-func (s *whatsappServiceImpl) buildDailyTidesNotificationVariables(userName string, extremes []worldtides.Extreme) []string {
+// buildDailyTidesNotificationVariables fills the template, whose tide variables also flag tides outside daylight
+func (s *whatsappServiceImpl) buildDailyTidesNotificationVariables(tr i18n.Localizer, userName string, spot spotModels.Spot, extremes []worldtides.Extreme, daylight astronomy.Daylight, units common.Units) []string {
 	if len(extremes) < 3 {
 		return []string{}
 	}
 
-	// TODO: correlate timezone with place when adding support for more location
-	tz := s.getTimezone()
+	tz := spot.Location()
 
-	nowInCanary := time.Now().In(tz)
-	todayInCanary := nowInCanary.Format("2006-01-02")
+	nowAtSpot := time.Now().In(tz)
+	todayAtSpot := nowAtSpot.Format("2006-01-02")
 
 	// Build variables for the template
 	variables := make([]string, 9) // 9 variables total
@@ -427,51 +1626,60 @@ func (s *whatsappServiceImpl) buildDailyTidesNotificationVariables(userName stri
 	// {{9}} - User name
 	variables[8] = userName
 
-	// Process each extreme ({{1}} through {{8}})
-	for i := range extremes {
+	// Process each extreme ({{1}} through {{8}}), days with more tides than the template holds only get their first ones
+	for i := range min(len(extremes), DAILY_TIDE_NOTIFICATION_TEMPLATE_TIDES) {
 		extreme := extremes[i]
 
-		// Convert time to Canary timezone
-		tideTimeInCanary := extreme.Time().In(tz)
+		// Convert time to the spot's timezone
+		tideTimeAtSpot := extreme.Time().In(tz)
 
-		// Determine if this tide is on the next day (compared to Canary time)
+		// Determine if this tide is on the next day (compared to the spot's local time)
 		daySuffix := ""
-		if tideTimeInCanary.Format("2006-01-02") != todayInCanary {
-			daySuffix = " (+1 day)"
+		if tideTimeAtSpot.Format("2006-01-02") != todayAtSpot {
+			daySuffix = fmt.Sprintf(" (%s)", tr.T("daily.next_day"))
 		}
 
 		// {{1}} - index 0, {{3}} - index 2, {{5}} - index 4, {{7}} - index 6 -- Tide type (High/Low)
 		if extreme.IsHighTide() {
-			variables[i*2] = "High"
+			variables[i*2] = tr.T("daily.high")
 		} else {
-			variables[i*2] = "Low"
+			variables[i*2] = tr.T("daily.low")
 		}
 
-		// {{2}} - index 1, {{4}} - index 3, {{6}} - index 5, {{8}} - index 7 -- Time and height in Canary timezone
-		tideTime := tideTimeInCanary.Format("15:04")
-		variables[i*2+1] = fmt.Sprintf("%s%s (%.2fm)", tideTime, daySuffix, extreme.Height)
+		// {{2}} - index 1, {{4}} - index 3, {{6}} - index 5, {{8}} - index 7 -- Time and height in the spot's timezone
+		tideTime := tideTimeAtSpot.Format("15:04")
+		variables[i*2+1] = fmt.Sprintf("%s%s (%s)%s", tideTime, daySuffix, units.FormatHeight(extreme.Height, 2), lightLabel(tr, daylight.LightAt(tideTimeAtSpot)))
 	}
 
 	return variables
 }
 
-func (s *whatsappServiceImpl) getTimezone() *time.Location {
-	canaryTZ, err := time.LoadLocation("Atlantic/Canary")
-	if err != nil {
-		s.log.Errorf("Failed to load Atlantic/Canary timezone: %v", err)
-		canaryTZ = time.UTC // Fallback to UTC
-	}
+// CONDITIONS_HOURS are the local hours shown by the conditions command
+var CONDITIONS_HOURS = []int{6, 9, 12, 15, 18, 21}
 
-	return canaryTZ
-}
+// BEST_COMMAND_DEFAULT_DAYS and BEST_COMMAND_MAX_DAYS bound how far ahead the best command looks,
+// the marine forecast reaching about a week ahead
+const BEST_COMMAND_DEFAULT_DAYS = 3
+const BEST_COMMAND_MAX_DAYS = 7
+
+// BEST_COMMAND_SESSIONS is how many sessions the best command lists
+const BEST_COMMAND_SESSIONS = 3
+
+// SUSPECT_TIDES_ALERT_MESSAGE goes to admins, so it isn't translated
+const SUSPECT_TIDES_ALERT_MESSAGE = `⚠️ *Suspect tide data*
 
-const AVAILABLE_COMMANDS = `
-*Available commands:*
-📱 Send *tides* - Get today's tide info
-   Examples: _tides tomorrow_, _tides week_, _tides today tomorrow_, _tides today 24/12/2025_
-🔔 Send *start* - Enable daily notifications  
-🔕 Send *stop* - Disable notifications
-`
+The %s job held back the reports of %s for %s, the tides from %s (%s) look wrong:
+%s
+Check them with _tides %s_ and, if they are fine, rerun the job with ?force=true to send them anyway.`
+
+// STATIONS_COMMAND_MAX_STATIONS is how many of the closest stations the stations command lists
+const STATIONS_COMMAND_MAX_STATIONS = 5
+
+// MONTH_COMMAND_DAYS is how far ahead the month command looks
+const MONTH_COMMAND_DAYS = 30
 
 const QUICK_REPLY_MESSAGE_TEMPLATE_SID = "HX6f156e3466407a835bef6505f85cf9b1"
 const DAILY_TIDE_NOTIFICATION_TEMPLATE_SID = "HX7161523078d66056973776cbf70f583a"
+
+// DAILY_TIDE_NOTIFICATION_TEMPLATE_TIDES is how many tides the daily notification template has variables for
+const DAILY_TIDE_NOTIFICATION_TEMPLATE_TIDES = 4
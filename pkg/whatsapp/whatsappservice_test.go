@@ -0,0 +1,47 @@
+package whatsapp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"tidebot/pkg/astronomy"
+	"tidebot/pkg/common"
+	"tidebot/pkg/i18n"
+	spotModels "tidebot/pkg/spots/models"
+	"tidebot/pkg/worldtides"
+	"time"
+)
+
+func TestBuildDailyTidesNotificationVariablesFitsTheTemplate(t *testing.T) {
+	spot := spotModels.Spot{Slug: "el-cotillo", Latitude: 28.6833, Longitude: -14.0167, Timezone: "Atlantic/Canary"}
+	today := common.TodayIn(spot.Location())
+	daylight := astronomy.NewDaylight(spot.Latitude, spot.Longitude, today)
+	service := &whatsappServiceImpl{}
+
+	for _, count := range []int{4, 5, 6} {
+		t.Run(fmt.Sprintf("%d extremes", count), func(t *testing.T) {
+			var extremes []worldtides.Extreme
+			for i := range count {
+				extreme := worldtides.Extreme{Dt: time.Date(today.Year(), today.Month(), today.Day(), i*4, 0, 0, 0, spot.Location()).Unix(), Height: 1, Type: "High"}
+				if i%2 == 1 {
+					extreme.Height, extreme.Type = -1, "Low"
+				}
+				extremes = append(extremes, extreme)
+			}
+
+			variables := service.buildDailyTidesNotificationVariables(i18n.NewLocalizer(i18n.English), "Ana", spot, extremes, daylight, common.UnitsMeters)
+
+			if len(variables) != 9 {
+				t.Fatalf("got %d variables, want 9", len(variables))
+			}
+			if variables[8] != "Ana" {
+				t.Errorf("user name = %q, want Ana", variables[8])
+			}
+
+			// The fourth tide, at 12:00, is the last one the template shows
+			if variables[6] != "Low" || !strings.HasPrefix(variables[7], "12:00") {
+				t.Errorf("fourth tide = %q %q, want the low at 12:00", variables[6], variables[7])
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package worldtides
+
+import (
+	"context"
+	"sync"
+)
+
+// requestGroup coalesces concurrent requests for the same key into a single call.
+// The shared call outlives the caller that started it and is only cancelled once
+// every caller waiting for it has given up.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*request
+}
+
+type request struct {
+	done      chan struct{}
+	responses []*WorldTidesResponse
+	err       error
+	waiters   int
+	cancel    context.CancelFunc
+}
+
+func newRequestGroup() *requestGroup {
+	return &requestGroup{calls: make(map[string]*request)}
+}
+
+// do runs fn unless a call for key is already in flight, in which case it waits for that call's result.
+// The returned bool reports whether the result came from another caller's call.
+func (g *requestGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]*WorldTidesResponse, error)) ([]*WorldTidesResponse, bool, error) {
+	g.mu.Lock()
+	call, shared := g.calls[key]
+	if !shared {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &request{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+
+		go func() {
+			call.responses, call.err = fn(callCtx)
+
+			g.mu.Lock()
+			g.forget(key, call)
+			g.mu.Unlock()
+
+			cancel()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.responses, shared, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody wants the result anymore, and later callers must not join a cancelled call
+			g.forget(key, call)
+			call.cancel()
+		}
+		g.mu.Unlock()
+
+		return nil, shared, ctx.Err()
+	}
+}
+
+// forget removes call from the group unless it has already been replaced. g.mu must be held.
+func (g *requestGroup) forget(key string, call *request) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
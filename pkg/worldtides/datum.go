@@ -0,0 +1,49 @@
+package worldtides
+
+import (
+	"strings"
+	"tidebot/pkg/spots/models"
+)
+
+// Datums heights can be relative to -- https://www.worldtides.info/datums
+const (
+	DatumMLS  = "MLS"  // Mean Sea Level
+	DatumLAT  = "LAT"  // Lowest Astronomical Tide
+	DatumCD   = "CD"   // Chart Datum of the local nautical charts
+	DatumMLLW = "MLLW" // Mean Lower Low Water
+)
+
+// Datums lists the datums users and spots can choose from
+var Datums = []string{DatumMLS, DatumLAT, DatumCD, DatumMLLW}
+
+// ParseDatum normalizes a datum name, also accepting the common "MSL" for mean sea level
+func ParseDatum(name string) (string, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "MSL" {
+		return DatumMLS, true
+	}
+
+	for _, datum := range Datums {
+		if name == datum {
+			return datum, true
+		}
+	}
+
+	return "", false
+}
+
+// SpotDatum returns the datum the tides of the spot are requested in
+func SpotDatum(spot models.Spot) string {
+	if spot.Datum != nil && *spot.Datum != "" {
+		return *spot.Datum
+	}
+	return DefaultDatum
+}
+
+// WithDatum returns a copy of the spot whose tides are requested in the given datum, or the spot itself if datum is nil
+func WithDatum(spot models.Spot, datum *string) models.Spot {
+	if datum != nil && *datum != "" {
+		spot.Datum = datum
+	}
+	return spot
+}
@@ -0,0 +1,203 @@
+package worldtides
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrOutsideHeights is returned when asking for the water level at a time the heights don't cover
+var ErrOutsideHeights = errors.New("time is outside the predicted heights")
+
+// TideState describes the water level at a moment
+type TideState struct {
+	Time   time.Time
+	Height float64
+	Rising bool
+	// NextExtreme is nil when the response has no extreme after Time
+	NextExtreme *Extreme
+}
+
+// StateAt linearly interpolates the heights around t
+func (r *WorldTidesResponse) StateAt(t time.Time) (*TideState, error) {
+	heights := r.Heights
+	unix := t.Unix()
+
+	if len(heights) < 2 || unix < heights[0].Dt || unix > heights[len(heights)-1].Dt {
+		return nil, ErrOutsideHeights
+	}
+
+	before, after := r.segmentAt(unix)
+
+	fraction := float64(unix-before.Dt) / float64(after.Dt-before.Dt)
+	state := &TideState{
+		Time:   t,
+		Height: before.Height + fraction*(after.Height-before.Height),
+		Rising: after.Height > before.Height,
+	}
+
+	for i := range r.Extremes {
+		if r.Extremes[i].Dt > unix {
+			state.NextExtreme = &r.Extremes[i]
+			// The next extreme is more reliable than the slope right at a turn of the tide
+			state.Rising = state.NextExtreme.IsHighTide()
+			break
+		}
+	}
+
+	return state, nil
+}
+
+// segmentAt returns the heights around unix, which must be within the heights
+func (r *WorldTidesResponse) segmentAt(unix int64) (Height, Height) {
+	heights := r.Heights
+
+	// Index of the first height at or after unix, at least 1 so there is a height before it
+	i := max(sort.Search(len(heights), func(i int) bool { return heights[i].Dt >= unix }), 1)
+	return heights[i-1], heights[i]
+}
+
+// TideProgress places a moment in the tidal cycle, which runs from low water to the next low water
+type TideProgress struct {
+	TideState
+	// RatePerHour is how fast the water level changes in meters per hour, negative when falling
+	RatePerHour float64
+	// PreviousLow, NextHigh and NextLow are nil when the response doesn't reach them
+	PreviousLow *Extreme
+	NextHigh    *Extreme
+	NextLow     *Extreme
+}
+
+// ProgressAt extends the state at t with the trend and the extremes of the cycle around it.
+// The response should cover the day before and after t for the cycle to be complete.
+func (r *WorldTidesResponse) ProgressAt(t time.Time) (*TideProgress, error) {
+	state, err := r.StateAt(t)
+	if err != nil {
+		return nil, err
+	}
+
+	before, after := r.segmentAt(t.Unix())
+	progress := &TideProgress{
+		TideState:   *state,
+		RatePerHour: (after.Height - before.Height) / after.Time().Sub(before.Time()).Hours(),
+	}
+
+	for i := range r.Extremes {
+		extreme := &r.Extremes[i]
+		switch {
+		case extreme.Dt <= t.Unix():
+			if extreme.IsLowTide() {
+				progress.PreviousLow = extreme
+			}
+		case extreme.IsHighTide() && progress.NextHigh == nil:
+			progress.NextHigh = extreme
+		case extreme.IsLowTide() && progress.NextLow == nil:
+			progress.NextLow = extreme
+		}
+	}
+
+	return progress, nil
+}
+
+// CycleFraction is how far through the cycle the moment is, from 0 at low water to 1 at the next one
+func (p *TideProgress) CycleFraction() (float64, bool) {
+	if p.PreviousLow == nil || p.NextLow == nil {
+		return 0, false
+	}
+
+	return p.Time.Sub(p.PreviousLow.Time()).Seconds() / p.NextLow.Time().Sub(p.PreviousLow.Time()).Seconds(), true
+}
+
+// Merge joins the responses of consecutive days into a single response
+func Merge(responses []*WorldTidesResponse) *WorldTidesResponse {
+	if len(responses) == 0 {
+		return nil
+	}
+
+	merged := *responses[0]
+	merged.Heights = nil
+	merged.Extremes = nil
+	merged.Anomalies = nil
+
+	for _, response := range responses {
+		merged.Heights = append(merged.Heights, response.Heights...)
+		merged.Extremes = append(merged.Extremes, response.Extremes...)
+		merged.Anomalies = append(merged.Anomalies, response.Anomalies...)
+	}
+
+	return &merged
+}
+
+// Window is a time interval, To is exclusive
+type Window struct {
+	From time.Time
+	To   time.Time
+}
+
+func (w Window) Duration() time.Duration {
+	return w.To.Sub(w.From)
+}
+
+// Windows returns the intervals between from and to during which the heights, linearly
+// interpolated, are below the threshold, or above it when below is false
+func (r *WorldTidesResponse) Windows(threshold float64, below bool, from time.Time, to time.Time) []Window {
+	matches := func(height float64) bool {
+		if below {
+			return height < threshold
+		}
+		return height > threshold
+	}
+
+	var windows []Window
+	var start time.Time
+	open := false
+
+	for i, height := range r.Heights {
+		inside := matches(height.Height)
+
+		if i == 0 {
+			if inside {
+				start, open = height.Time(), true
+			}
+			continue
+		}
+
+		previous := r.Heights[i-1]
+		if inside == matches(previous.Height) {
+			continue
+		}
+
+		crossing := thresholdCrossing(previous, height, threshold)
+		if inside {
+			start, open = crossing, true
+		} else {
+			windows = append(windows, Window{From: start, To: crossing})
+			open = false
+		}
+	}
+
+	if open {
+		windows = append(windows, Window{From: start, To: r.Heights[len(r.Heights)-1].Time()})
+	}
+
+	var clipped []Window
+	for _, window := range windows {
+		if window.From.Before(from) {
+			window.From = from
+		}
+		if window.To.After(to) {
+			window.To = to
+		}
+		if window.From.Before(window.To) {
+			clipped = append(clipped, window)
+		}
+	}
+
+	return clipped
+}
+
+// thresholdCrossing returns when the line between two heights crosses the threshold
+func thresholdCrossing(a Height, b Height, threshold float64) time.Time {
+	fraction := (threshold - a.Height) / (b.Height - a.Height)
+	return time.Unix(a.Dt+int64(fraction*float64(b.Dt-a.Dt)), 0)
+}
@@ -0,0 +1,77 @@
+package worldtides
+
+import (
+	"context"
+	"fmt"
+	"tidebot/pkg/spots/models"
+	"time"
+)
+
+// LocalDays returns the midnights, in the spot's timezone, of the days days starting with from's date
+func LocalDays(spot models.Spot, from time.Time, days int) []time.Time {
+	location := spot.Location()
+	dates := make([]time.Time, days)
+
+	for i := range dates {
+		dates[i] = time.Date(from.Year(), from.Month(), from.Day()+i, 0, 0, 0, 0, location)
+	}
+
+	return dates
+}
+
+// SplitByDay splits a multi-day response into one response per local day of the spot
+func SplitByDay(response *WorldTidesResponse, spot models.Spot, from time.Time, days int) []*WorldTidesResponse {
+	dates := LocalDays(spot, from, days)
+	split := make([]*WorldTidesResponse, days)
+
+	for i, dayStart := range dates {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+
+		dayResponse := *response
+		dayResponse.Heights = nil
+		dayResponse.Extremes = nil
+
+		for _, height := range response.Heights {
+			if isWithin(height.Time(), dayStart, dayEnd) {
+				dayResponse.Heights = append(dayResponse.Heights, height)
+			}
+		}
+
+		for _, extreme := range response.Extremes {
+			if isWithin(extreme.Time(), dayStart, dayEnd) {
+				dayResponse.Extremes = append(dayResponse.Extremes, extreme)
+			}
+		}
+
+		split[i] = &dayResponse
+	}
+
+	return split
+}
+
+// GetTidesDayByDay implements GetTidesRange for clients where a multi-day request is no cheaper than several single days
+func GetTidesDayByDay(ctx context.Context, client WorldTidesClient, spot models.Spot, from time.Time, days int, trigger Trigger) ([]*WorldTidesResponse, error) {
+	if days < 1 {
+		return nil, fmt.Errorf("invalid number of days: %d", days)
+	}
+
+	var responses []*WorldTidesResponse
+
+	for _, date := range LocalDays(spot, from, days) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		response, err := client.GetTides(ctx, spot, date, trigger)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+func isWithin(t time.Time, from time.Time, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
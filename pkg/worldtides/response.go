@@ -20,6 +20,12 @@ type WorldTidesResponse struct {
 	Extremes      []Extreme `json:"extremes,omitempty"`
 	Datums        []Datum   `json:"datums,omitempty"`
 	Stations      []Station `json:"stations,omitempty"`
+	// Source names the provider that produced the response, set by the provider chain
+	Source string `json:"source,omitempty"`
+	// FetchedAt is when the response was fetched from WorldTides in Unix seconds, 0 for computed responses
+	FetchedAt int64 `json:"fetchedAt,omitempty"`
+	// Anomalies are the warnings validation flagged the response with, see ValidateDay
+	Anomalies []Anomaly `json:"anomalies,omitempty"`
 }
 
 type Height struct {
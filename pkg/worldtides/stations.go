@@ -0,0 +1,131 @@
+package worldtides
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"tidebot/pkg/common"
+	"tidebot/pkg/spots/models"
+	stationSearchModels "tidebot/pkg/stationsearches/models"
+	"time"
+)
+
+// MaxStationRadiusKm is the largest search radius WorldTides accepts
+const MaxStationRadiusKm = 500
+
+// NearbyStation is a tide station found around a position
+type NearbyStation struct {
+	Station
+	DistanceKm float64 `json:"distance_km"`
+}
+
+type StationsClient interface {
+	// GetStations returns the stations within radiusKm of the position, closest first
+	GetStations(ctx context.Context, latitude float64, longitude float64, radiusKm int, trigger Trigger) ([]NearbyStation, error)
+}
+
+// WorldTidesAPIClient serves tides like every other provider and can also look up WorldTides stations
+type WorldTidesAPIClient interface {
+	WorldTidesClient
+	StationsClient
+}
+
+// GetStations serves searches from the cache, as stations rarely change, and spends credits otherwise
+func (c *worldTidesClientImpl) GetStations(ctx context.Context, latitude float64, longitude float64, radiusKm int, trigger Trigger) ([]NearbyStation, error) {
+	if radiusKm < 1 || radiusKm > MaxStationRadiusKm {
+		return nil, fmt.Errorf("invalid station search radius %dkm, it must be from 1 to %dkm", radiusKm, MaxStationRadiusKm)
+	}
+
+	key := stationSearchModels.NewStationSearchKey(latitude, longitude, radiusKm)
+
+	stations, exists := c.readStationsCache(key)
+	if exists {
+		c.log.Debugf("Cache hit for stations within %dkm of %f,%f", radiusKm, latitude, longitude)
+		return nearbyStations(stations, latitude, longitude), nil
+	}
+
+	err := c.creditBudget.Allow(trigger)
+	if err != nil {
+		c.log.Warnf("Refusing WorldTides stations request triggered by %s %s: %v", trigger.Kind, trigger.Ref, err)
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("key", c.apiKey)
+	params.Set("lat", strconv.FormatFloat(key.Latitude, 'f', -1, 64))
+	params.Set("lon", strconv.FormatFloat(key.Longitude, 'f', -1, 64))
+	params.Set("stations", "")
+	params.Set("stationDistance", strconv.Itoa(radiusKm))
+
+	response, err := c.makeRequestWithRetries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Credits are booked against a pseudo spot since the search isn't for a spot
+	search := models.Spot{Slug: fmt.Sprintf("stations@%.3f,%.3f", key.Latitude, key.Longitude)}
+	c.creditBudget.Record(trigger, search, time.Now(), 1, max(response.CallCount, 1))
+
+	c.writeStationsCache(key, response.Stations)
+
+	return nearbyStations(response.Stations, latitude, longitude), nil
+}
+
+func nearbyStations(stations []Station, latitude float64, longitude float64) []NearbyStation {
+	nearby := make([]NearbyStation, len(stations))
+	for i, station := range stations {
+		nearby[i] = NearbyStation{
+			Station:    station,
+			DistanceKm: common.DistanceKm(latitude, longitude, station.Lat, station.Lon),
+		}
+	}
+
+	sort.Slice(nearby, func(i, j int) bool {
+		return nearby[i].DistanceKm < nearby[j].DistanceKm
+	})
+
+	return nearby
+}
+
+// readStationsCache treats any cache failure as a miss
+func (c *worldTidesClientImpl) readStationsCache(key stationSearchModels.StationSearchKey) ([]Station, bool) {
+	search, err := c.stationSearchRepository.Get(key)
+	if err != nil {
+		c.log.Errorf("Failed to read station search cache for %+v: %v", key, err)
+		return nil, false
+	}
+
+	if search == nil {
+		return nil, false
+	}
+
+	var stations []Station
+	err = json.Unmarshal([]byte(search.Response), &stations)
+	if err != nil {
+		c.log.Errorf("Failed to unmarshal cached station search for %+v: %v", key, err)
+		return nil, false
+	}
+
+	return stations, true
+}
+
+func (c *worldTidesClientImpl) writeStationsCache(key stationSearchModels.StationSearchKey, stations []Station) {
+	// An empty list is cached too, an empty area stays empty
+	if stations == nil {
+		stations = []Station{}
+	}
+
+	body, err := json.Marshal(stations)
+	if err != nil {
+		c.log.Errorf("Failed to marshal station search for %+v: %v", key, err)
+		return
+	}
+
+	err = c.stationSearchRepository.Save(key, string(body), time.Now().Add(c.stationsCacheTTL))
+	if err != nil {
+		c.log.Errorf("Failed to write station search cache for %+v: %v", key, err)
+	}
+}
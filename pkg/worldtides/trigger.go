@@ -0,0 +1,42 @@
+package worldtides
+
+import (
+	"errors"
+	"tidebot/pkg/spots/models"
+	"time"
+)
+
+type TriggerKind string
+
+const (
+	TriggerUser  TriggerKind = "user"
+	TriggerJob   TriggerKind = "job"
+	TriggerAdmin TriggerKind = "admin"
+)
+
+// Trigger records who or what caused a tides request, so credit usage can be attributed and budgeted
+type Trigger struct {
+	Kind TriggerKind `json:"kind"`
+	Ref  string      `json:"ref"`
+}
+
+func UserTrigger(phoneNumber string) Trigger {
+	return Trigger{Kind: TriggerUser, Ref: phoneNumber}
+}
+
+func JobTrigger(jobName string) Trigger {
+	return Trigger{Kind: TriggerJob, Ref: jobName}
+}
+
+func AdminTrigger(action string) Trigger {
+	return Trigger{Kind: TriggerAdmin, Ref: action}
+}
+
+// ErrCreditBudgetExhausted is returned instead of calling the API when the credit budget doesn't allow the request
+var ErrCreditBudgetExhausted = errors.New("WorldTides credit budget exhausted")
+
+// CreditBudget decides whether a request may spend credits and records what was spent
+type CreditBudget interface {
+	Allow(trigger Trigger) error
+	Record(trigger Trigger, spot models.Spot, date time.Time, days int, credits int)
+}
@@ -0,0 +1,226 @@
+package worldtides
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"tidebot/pkg/spots/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// ErrInvalidResponse is returned for tide data failing validation, so the next provider is tried
+var ErrInvalidResponse = errors.New("invalid tide data")
+
+type Severity string
+
+const (
+	// SeverityError rejects the response
+	SeverityError Severity = "error"
+	// SeverityWarning keeps the response but flags it as suspect
+	SeverityWarning Severity = "warning"
+)
+
+type AnomalyKind string
+
+const (
+	AnomalyNoExtremes        AnomalyKind = "no_extremes"
+	AnomalyTooManyExtremes   AnomalyKind = "too_many_extremes"
+	AnomalyOutsideDay        AnomalyKind = "outside_day"
+	AnomalyUnordered         AnomalyKind = "unordered"
+	AnomalyNotAlternating    AnomalyKind = "not_alternating"
+	AnomalyImplausibleHeight AnomalyKind = "implausible_height"
+	AnomalyInvertedRange     AnomalyKind = "inverted_range"
+	AnomalySpacing           AnomalyKind = "spacing"
+	AnomalyHeightsGap        AnomalyKind = "heights_gap"
+)
+
+const (
+	// The largest tidal ranges, in the Bay of Fundy, stay below 17m
+	maxPlausibleHeight = 20.0
+	// Even mixed and shallow water tides rarely have more than 6 extremes a day
+	maxExtremesPerDay = 6
+	minExtremeSpacing = 2 * time.Hour
+	maxExtremeSpacing = 16 * time.Hour
+	// Heights are requested every 30 minutes
+	maxHeightsGap = 2 * time.Hour
+)
+
+// Anomaly is something wrong with the tide data of a day
+type Anomaly struct {
+	Kind     AnomalyKind `json:"kind"`
+	Severity Severity    `json:"severity"`
+	Message  string      `json:"message"`
+	// Dt is the time of the offending extreme or height, 0 for the whole day
+	Dt int64 `json:"dt,omitempty"`
+}
+
+// ValidateDay checks the tide data of the spot for the local day starting at date
+func ValidateDay(response *WorldTidesResponse, spot models.Spot, date time.Time) []Anomaly {
+	location := spot.Location()
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	var anomalies []Anomaly
+	add := func(kind AnomalyKind, severity Severity, dt int64, format string, args ...any) {
+		anomalies = append(anomalies, Anomaly{Kind: kind, Severity: severity, Message: fmt.Sprintf(format, args...), Dt: dt})
+	}
+
+	extremes := response.Extremes
+	if len(extremes) == 0 {
+		add(AnomalyNoExtremes, SeverityError, 0, "no extremes on %s", dayStart.Format("2006-01-02"))
+	}
+	if len(extremes) > maxExtremesPerDay {
+		add(AnomalyTooManyExtremes, SeverityWarning, 0, "%d extremes on %s", len(extremes), dayStart.Format("2006-01-02"))
+	}
+
+	for i, extreme := range extremes {
+		at := extreme.Time().In(location)
+
+		if !isWithin(at, dayStart, dayEnd) {
+			add(AnomalyOutsideDay, SeverityError, extreme.Dt, "%s tide at %s is outside %s", extreme.Type, at.Format(time.RFC3339), dayStart.Format("2006-01-02"))
+		}
+		if !extreme.IsHighTide() && !extreme.IsLowTide() {
+			add(AnomalyNotAlternating, SeverityError, extreme.Dt, "unknown extreme type %q at %s", extreme.Type, at.Format(time.RFC3339))
+		}
+		if math.IsNaN(extreme.Height) || math.Abs(extreme.Height) > maxPlausibleHeight {
+			add(AnomalyImplausibleHeight, SeverityError, extreme.Dt, "%s tide of %.2fm at %s", extreme.Type, extreme.Height, at.Format(time.RFC3339))
+		}
+
+		if i == 0 {
+			continue
+		}
+		previous := extremes[i-1]
+		spacing := time.Duration(extreme.Dt-previous.Dt) * time.Second
+
+		switch {
+		case spacing <= 0:
+			add(AnomalyUnordered, SeverityError, extreme.Dt, "%s tide at %s doesn't follow the previous extreme", extreme.Type, at.Format(time.RFC3339))
+		case spacing < minExtremeSpacing:
+			add(AnomalySpacing, SeverityError, extreme.Dt, "%s tide at %s only %s after the previous extreme", extreme.Type, at.Format(time.RFC3339), spacing)
+		case spacing > maxExtremeSpacing:
+			add(AnomalySpacing, SeverityWarning, extreme.Dt, "%s tide at %s %s after the previous extreme", extreme.Type, at.Format(time.RFC3339), spacing)
+		}
+
+		if extreme.Type == previous.Type {
+			add(AnomalyNotAlternating, SeverityError, extreme.Dt, "two %s tides in a row at %s", extreme.Type, at.Format(time.RFC3339))
+		} else if (extreme.IsHighTide() && extreme.Height <= previous.Height) || (extreme.IsLowTide() && extreme.Height >= previous.Height) {
+			add(AnomalyInvertedRange, SeverityError, extreme.Dt, "%s tide of %.2fm at %s after a %s tide of %.2fm", extreme.Type, extreme.Height, at.Format(time.RFC3339), previous.Type, previous.Height)
+		}
+	}
+
+	anomalies = append(anomalies, validateHeights(response.Heights, dayStart, dayEnd)...)
+
+	return anomalies
+}
+
+// validateHeights only flags, as reports are built from the extremes and the heights just refine them
+func validateHeights(heights []Height, dayStart time.Time, dayEnd time.Time) []Anomaly {
+	// Not every provider returns heights
+	if len(heights) == 0 {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	gap := func(from time.Time, to time.Time, dt int64) {
+		if to.Sub(from) > maxHeightsGap {
+			anomalies = append(anomalies, Anomaly{
+				Kind:     AnomalyHeightsGap,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("no heights from %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339)),
+				Dt:       dt,
+			})
+		}
+	}
+
+	gap(dayStart, heights[0].Time().In(dayStart.Location()), heights[0].Dt)
+
+	for i, height := range heights {
+		if math.IsNaN(height.Height) || math.Abs(height.Height) > maxPlausibleHeight {
+			anomalies = append(anomalies, Anomaly{
+				Kind:     AnomalyImplausibleHeight,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("height of %.2fm at %s", height.Height, height.Time().In(dayStart.Location()).Format(time.RFC3339)),
+				Dt:       height.Dt,
+			})
+		}
+		if i > 0 {
+			gap(heights[i-1].Time().In(dayStart.Location()), height.Time().In(dayStart.Location()), height.Dt)
+		}
+	}
+
+	last := heights[len(heights)-1]
+	gap(last.Time().In(dayStart.Location()), dayEnd, last.Dt)
+
+	return anomalies
+}
+
+// CheckDays validates the consecutive day responses starting at from, flagging the warnings on each response.
+// It returns an ErrInvalidResponse error when any day has errors.
+func CheckDays(responses []*WorldTidesResponse, spot models.Spot, from time.Time) ([][]Anomaly, error) {
+	dates := LocalDays(spot, from, len(responses))
+	all := make([][]Anomaly, len(responses))
+
+	var problems []string
+	for i, response := range responses {
+		anomalies := ValidateDay(response, spot, dates[i])
+		all[i] = anomalies
+
+		response.Anomalies = nil
+		for _, anomaly := range anomalies {
+			if anomaly.Severity == SeverityError {
+				problems = append(problems, anomaly.Message)
+			} else {
+				response.Anomalies = append(response.Anomalies, anomaly)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return all, fmt.Errorf("%w for %s: %s", ErrInvalidResponse, spot.Slug, strings.Join(problems, "; "))
+	}
+
+	return all, nil
+}
+
+func hasErrors(anomalies []Anomaly) bool {
+	for _, anomaly := range anomalies {
+		if anomaly.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSuspect reports whether the response was flagged with warnings
+func (r *WorldTidesResponse) IsSuspect() bool {
+	return len(r.Anomalies) > 0
+}
+
+// LogAnomalies logs one structured entry per anomaly, so they can be searched and counted
+func LogAnomalies(logger echo.Logger, source string, spot models.Spot, date time.Time, anomalies []Anomaly) {
+	for _, anomaly := range anomalies {
+		entry := log.JSON{
+			"event":    "tide_anomaly",
+			"source":   source,
+			"spot":     spot.Slug,
+			"datum":    SpotDatum(spot),
+			"date":     date.Format("2006-01-02"),
+			"kind":     anomaly.Kind,
+			"severity": anomaly.Severity,
+			"message":  anomaly.Message,
+		}
+		if anomaly.Dt != 0 {
+			entry["dt"] = anomaly.Dt
+		}
+
+		if anomaly.Severity == SeverityError {
+			logger.Errorj(entry)
+		} else {
+			logger.Warnj(entry)
+		}
+	}
+}
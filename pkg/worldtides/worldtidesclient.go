@@ -1,13 +1,19 @@
 package worldtides
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"strconv"
-	"sync"
+	"tidebot/pkg/spots/models"
+	stationSearchRepos "tidebot/pkg/stationsearches/repositories"
+	tidePredictionModels "tidebot/pkg/tidepredictions/models"
+	"tidebot/pkg/tidepredictions/repositories"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -15,116 +21,281 @@ import (
 
 const (
 	WorldTidesAPIURL = "https://www.worldtides.info/api/v3"
-	// Hardcoded coordinates for Fuerteventura, Canary Islands
-	DefaultLatitude  = 28.110419112734185
-	DefaultLongitude = -14.260264983464896
+	DefaultDatum     = DatumMLS
+
+	// Transient failures are retried up to maxAttempts times in total, waiting a jittered,
+	// exponentially growing delay starting at retryBaseDelay between attempts
+	maxAttempts    = 3
+	retryBaseDelay = 500 * time.Millisecond
 )
 
+// ErrNotSupported is returned by clients that have no data for the requested spot or date.
+// It doesn't count against the health of a provider in a provider chain.
+var ErrNotSupported = errors.New("not supported by this provider")
+
 type WorldTidesClient interface {
-	GetTides(date time.Time) (*WorldTidesResponse, error)
+	GetTides(ctx context.Context, spot models.Spot, date time.Time, trigger Trigger) (*WorldTidesResponse, error)
+	// GetTidesRange returns one response per local day of the spot, starting with from's date
+	GetTidesRange(ctx context.Context, spot models.Spot, from time.Time, days int, trigger Trigger) ([]*WorldTidesResponse, error)
 }
 
-type Cache struct {
-	mu   sync.RWMutex
-	data map[string]*WorldTidesResponse
+type worldTidesClientImpl struct {
+	apiKey                   string
+	httpClient               *http.Client
+	log                      echo.Logger
+	tidePredictionRepository repositories.TidePredictionRepository
+	stationSearchRepository  stationSearchRepos.StationSearchRepository
+	cacheTTL                 time.Duration
+	stationsCacheTTL         time.Duration
+	creditBudget             CreditBudget
+	requests                 *requestGroup
 }
 
-func NewCache() Cache {
-	return Cache{
-		data: make(map[string]*WorldTidesResponse),
+func NewWorldTidesClient(apiKey string, tidePredictionRepository repositories.TidePredictionRepository, stationSearchRepository stationSearchRepos.StationSearchRepository, cacheTTL time.Duration, stationsCacheTTL time.Duration, creditBudget CreditBudget, log echo.Logger) WorldTidesAPIClient {
+	return &worldTidesClientImpl{
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		log:                      log,
+		tidePredictionRepository: tidePredictionRepository,
+		stationSearchRepository:  stationSearchRepository,
+		cacheTTL:                 cacheTTL,
+		stationsCacheTTL:         stationsCacheTTL,
+		creditBudget:             creditBudget,
+		requests:                 newRequestGroup(),
 	}
 }
 
-func (c *Cache) read(key string) (*WorldTidesResponse, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	value, exists := c.data[key]
-	return value, exists
+// CacheKey returns the persistent cache key under which the tides for the spot and date are stored in the spot's datum
+func CacheKey(spot models.Spot, date time.Time) tidePredictionModels.TidePredictionKey {
+	return tidePredictionModels.NewTidePredictionKey(spot.Latitude, spot.Longitude, date, SpotDatum(spot))
 }
 
-func (c *Cache) write(key string, value *WorldTidesResponse) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *worldTidesClientImpl) GetTides(ctx context.Context, spot models.Spot, date time.Time, trigger Trigger) (*WorldTidesResponse, error) {
+	responses, err := c.GetTidesRange(ctx, spot, date, 1, trigger)
+	if err != nil {
+		return nil, err
+	}
 
-	c.data[key] = value
+	return responses[0], nil
 }
 
-type worldTidesClientImpl struct {
-	apiKey     string
-	httpClient *http.Client
-	log        echo.Logger
-	cache      Cache
-}
+// GetTidesRange serves cached days from the cache and fetches all the missing ones with a single
+// request, since WorldTides bills per 7 days of data rather than per request. Concurrent callers
+// missing the same days share one request.
+func (c *worldTidesClientImpl) GetTidesRange(ctx context.Context, spot models.Spot, from time.Time, days int, trigger Trigger) ([]*WorldTidesResponse, error) {
+	if days < 1 {
+		return nil, fmt.Errorf("invalid number of days: %d", days)
+	}
 
-func NewWorldTidesClient(apiKey string, log echo.Logger) WorldTidesClient {
-	return &worldTidesClientImpl{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		log:   log,
-		cache: NewCache(),
+	dates := LocalDays(spot, from, days)
+	c.log.Debugf("Getting tides for spot %s from %s for %d days", spot.Slug, dates[0].Format("2006-01-02"), days)
+
+	responses := make([]*WorldTidesResponse, days)
+	firstMissing, lastMissing := -1, -1
+
+	for i, date := range dates {
+		cached, exists := c.readCache(CacheKey(spot, date))
+		if exists {
+			responses[i] = cached
+			continue
+		}
+
+		if firstMissing == -1 {
+			firstMissing = i
+		}
+		lastMissing = i
+	}
+
+	if firstMissing == -1 {
+		c.log.Debugf("Cache hit for tides request for %s from %s for %d days", spot.Slug, dates[0].Format("2006-01-02"), days)
+		return responses, nil
 	}
-}
 
-func (c *worldTidesClientImpl) GetTides(date time.Time) (*WorldTidesResponse, error) {
-	dateFormatted := date.Format("2006-01-02")
-	c.log.Debugf("Getting tides for date: %s", dateFormatted)
+	missingDays := lastMissing - firstMissing + 1
+	startDate := dates[firstMissing].Format("2006-01-02")
 
-	cacheKey := fmt.Sprintf("tides:%s", dateFormatted)
+	err := c.creditBudget.Allow(trigger)
+	if err != nil {
+		c.log.Warnf("Refusing WorldTides request for %s from %s triggered by %s %s: %v", spot.Slug, startDate, trigger.Kind, trigger.Ref, err)
+		return nil, err
+	}
 
-	c.log.Debugf("Cache key: %s", cacheKey)
-	c.log.Debugf("Cache contents: %+v", c.cache.data)
+	key := CacheKey(spot, dates[firstMissing])
+	requestKey := fmt.Sprintf("%f,%f,%s,%s,%d", key.Latitude, key.Longitude, key.Date, key.Datum, missingDays)
 
-	cached, exists := c.cache.read(cacheKey)
+	fetched, shared, err := c.requests.do(ctx, requestKey, func(ctx context.Context) ([]*WorldTidesResponse, error) {
+		return c.fetchRange(ctx, spot, dates[firstMissing:lastMissing+1], trigger)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if exists {
-		c.log.Debugf("Cache hit for tides request for %s", dateFormatted)
-		return cached, nil
+	if shared {
+		c.log.Debugf("Shared an in-flight WorldTides request for %s from %s for %d days", spot.Slug, startDate, missingDays)
 	}
 
+	copy(responses[firstMissing:], fetched)
+
+	return responses, nil
+}
+
+// fetchRange requests the given consecutive days, records the credits spent and caches each day
+func (c *worldTidesClientImpl) fetchRange(ctx context.Context, spot models.Spot, dates []time.Time, trigger Trigger) ([]*WorldTidesResponse, error) {
 	params := url.Values{}
 	params.Set("key", c.apiKey)
-	params.Set("lat", strconv.FormatFloat(DefaultLatitude, 'f', -1, 64))
-	params.Set("lon", strconv.FormatFloat(DefaultLongitude, 'f', -1, 64))
-	params.Set("date", dateFormatted)
-	params.Set("days", "1")
+	params.Set("lat", strconv.FormatFloat(spot.Latitude, 'f', -1, 64))
+	params.Set("lon", strconv.FormatFloat(spot.Longitude, 'f', -1, 64))
+	if spot.WorldTidesStationID != nil && *spot.WorldTidesStationID != "" {
+		// The station's predictions replace the model's at the spot's coordinates
+		params.Set("station", *spot.WorldTidesStationID)
+	}
+	params.Set("date", dates[0].Format("2006-01-02"))
+	params.Set("days", strconv.Itoa(len(dates)))
 	params.Set("extremes", "")
 	params.Set("heights", "")
-	params.Set("datum", "MLS") // Mean Sea Level -- https://www.worldtides.info/datums
+	params.Set("datum", SpotDatum(spot))
 	params.Set("localtime", "")
 
-	response, err := c.makeRequest(params)
-
+	response, err := c.makeRequestWithRetries(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	c.cache.write(cacheKey, response)
+	c.creditBudget.Record(trigger, spot, dates[0], len(dates), response.CallCount)
+
+	response.FetchedAt = time.Now().Unix()
+	dayResponses := SplitByDay(response, spot, dates[0], len(dates))
+
+	// Bad days are not cached, so the next request fetches them again
+	anomalies, invalidErr := CheckDays(dayResponses, spot, dates[0])
+	for i, dayResponse := range dayResponses {
+		LogAnomalies(c.log, "worldtides", spot, dates[i], anomalies[i])
+		if hasErrors(anomalies[i]) {
+			continue
+		}
+		c.writeCache(CacheKey(spot, dates[i]), dayResponse)
+	}
+	if invalidErr != nil {
+		return nil, invalidErr
+	}
+
+	return dayResponses, nil
+}
+
+// readCache treats any cache failure as a miss, so a database hiccup costs credits instead of failing the request
+func (c *worldTidesClientImpl) readCache(key tidePredictionModels.TidePredictionKey) (*WorldTidesResponse, bool) {
+	prediction, err := c.tidePredictionRepository.Get(key)
+	if err != nil {
+		c.log.Errorf("Failed to read tide prediction cache for %+v: %v", key, err)
+		return nil, false
+	}
+
+	if prediction == nil {
+		return nil, false
+	}
+
+	var response WorldTidesResponse
+	err = json.Unmarshal([]byte(prediction.Response), &response)
+	if err != nil {
+		c.log.Errorf("Failed to unmarshal cached tide prediction for %+v: %v", key, err)
+		return nil, false
+	}
+
+	return &response, true
+}
+
+func (c *worldTidesClientImpl) writeCache(key tidePredictionModels.TidePredictionKey, response *WorldTidesResponse) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		c.log.Errorf("Failed to marshal tide prediction for %+v: %v", key, err)
+		return
+	}
+
+	err = c.tidePredictionRepository.Save(key, string(body), response.ResponseDatum, time.Now().Add(c.cacheTTL))
+	if err != nil {
+		c.log.Errorf("Failed to write tide prediction cache for %+v: %v", key, err)
+	}
+}
+
+func (c *worldTidesClientImpl) makeRequestWithRetries(ctx context.Context, params url.Values) (*WorldTidesResponse, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := c.makeRequest(ctx, params)
+		if err == nil {
+			return response, nil
+		}
+
+		var transient *transientError
+		if !errors.As(err, &transient) || attempt == maxAttempts || ctx.Err() != nil {
+			return nil, err
+		}
+
+		delay := retryDelay(attempt)
+		c.log.Warnf("WorldTides request attempt %d of %d failed, retrying in %s: %v", attempt, maxAttempts, delay, err)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// transientError marks failures worth retrying: network errors, 5xx and rate limiting
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
 
-	return response, nil
+func isTransientStatus(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests
 }
 
-func (c *worldTidesClientImpl) makeRequest(params url.Values) (*WorldTidesResponse, error) {
+// retryDelay returns a random delay between half and all of the exponential backoff for the attempt
+func retryDelay(attempt int) time.Duration {
+	backoff := retryBaseDelay << (attempt - 1)
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+func (c *worldTidesClientImpl) makeRequest(ctx context.Context, params url.Values) (*WorldTidesResponse, error) {
 	requestURL := fmt.Sprintf("%s?%s", WorldTidesAPIURL, params.Encode())
 
 	c.log.Debugf("Making WorldTides API request to: %s", requestURL)
 
-	resp, err := c.httpClient.Get(requestURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to make HTTP request: %w", err)
+		}
+		return nil, &transientError{fmt.Errorf("failed to make HTTP request: %w", err)}
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, &transientError{fmt.Errorf("failed to read response body: %w", err)}
 	}
 
 	c.log.Debugf("WorldTides API response status: %d, body length: %d bytes", resp.StatusCode, len(body))
 	c.log.Debugf("WorldTides API response body: %s", string(body))
 
+	if isTransientStatus(resp.StatusCode) {
+		return nil, &transientError{fmt.Errorf("WorldTides API HTTP error (status %d): %s", resp.StatusCode, string(body))}
+	}
+
 	var worldTidesResponse WorldTidesResponse
 	err = json.Unmarshal(body, &worldTidesResponse)
 	if err != nil {
@@ -133,7 +304,11 @@ func (c *worldTidesClientImpl) makeRequest(params url.Values) (*WorldTidesRespon
 
 	// Check for API errors
 	if worldTidesResponse.Status != 200 {
-		return nil, fmt.Errorf("WorldTides API error (status %d): %s", worldTidesResponse.Status, worldTidesResponse.Error)
+		err := fmt.Errorf("WorldTides API error (status %d): %s", worldTidesResponse.Status, worldTidesResponse.Error)
+		if isTransientStatus(worldTidesResponse.Status) {
+			return nil, &transientError{err}
+		}
+		return nil, err
 	}
 
 	c.log.Debugf("Successfully received WorldTides data - Heights: %d, Extremes: %d",